@@ -103,7 +103,6 @@ func TestBaseChainFields(t *testing.T) {
 			Miner:            "0x0000000000000000000000000000000000000000",
 			ExtraData:        "0x",
 			RequestsHash:     "0x7685abcdef1234567890abcdef1234567890abcdef1234567890abcdef123456",
-			Transactions:     []interface{}{},
 		}
 
 		protoBlock, err := block.ToProto()
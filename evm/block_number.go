@@ -0,0 +1,230 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// BlockTag names a block relative to the node's view of the chain.
+type BlockTag int
+
+const (
+	// BlockTagNone marks selectors that use a number or a hash instead of a tag
+	BlockTagNone BlockTag = iota
+	BlockTagLatest
+	BlockTagEarliest
+	BlockTagPending
+	// BlockTagSafe is the most recent block unlikely to be reorged (post-merge only)
+	BlockTagSafe
+	// BlockTagFinalized is the most recent finalized block (post-merge only)
+	BlockTagFinalized
+)
+
+var blockTagNames = map[BlockTag]string{
+	BlockTagLatest:    "latest",
+	BlockTagEarliest:  "earliest",
+	BlockTagPending:   "pending",
+	BlockTagSafe:      "safe",
+	BlockTagFinalized: "finalized",
+}
+
+func (t BlockTag) String() string {
+	if name, ok := blockTagNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("BlockTag(%d)", int(t))
+}
+
+// BlockTagDetail is the BaseError detail key holding the rejected block tag.
+const BlockTagDetail = "blockTag"
+
+// BlockNumberOrTag is a JSON-RPC block parameter: a block number, a tag such as "latest" or
+// "finalized", or an EIP-1898 object selecting a block by hash or number. The zero value selects
+// block 0; use the constructors to build selectors.
+type BlockNumberOrTag struct {
+	// Tag is the selected tag, or BlockTagNone for numbers and hashes
+	Tag BlockTag
+	// Number is the selected block number when Tag is BlockTagNone and BlockHash is nil
+	Number uint64
+	// BlockHash selects the block by hash (EIP-1898)
+	BlockHash *Hash
+	// RequireCanonical makes a hash selector fail unless the block is on the canonical chain
+	// (EIP-1898)
+	RequireCanonical bool
+}
+
+// NewBlockNumber selects block n.
+func NewBlockNumber(n uint64) BlockNumberOrTag {
+	return BlockNumberOrTag{Number: n}
+}
+
+// NewBlockTag selects the block named by tag.
+func NewBlockTag(tag BlockTag) BlockNumberOrTag {
+	return BlockNumberOrTag{Tag: tag}
+}
+
+// NewBlockHash selects a block by hash, optionally requiring it to be canonical (EIP-1898).
+func NewBlockHash(h Hash, requireCanonical bool) BlockNumberOrTag {
+	return BlockNumberOrTag{BlockHash: &h, RequireCanonical: requireCanonical}
+}
+
+// IsTag reports whether b selects a block by tag.
+func (b BlockNumberOrTag) IsTag() bool { return b.Tag != BlockTagNone }
+
+// IsHash reports whether b selects a block by hash.
+func (b BlockNumberOrTag) IsHash() bool { return b.BlockHash != nil }
+
+// IsNumber reports whether b selects a block by number.
+func (b BlockNumberOrTag) IsNumber() bool { return !b.IsTag() && !b.IsHash() }
+
+// ParseBlockNumberOrTag parses the string form of a block parameter: a 0x-prefixed hex number or
+// one of the tags latest, earliest, pending, safe and finalized. Words that are not tags fail
+// with UNSUPPORTED_BLOCK_TAG and other input with INVALID_PARAMETER, both as common.BaseError.
+func ParseBlockNumberOrTag(s string) (BlockNumberOrTag, error) {
+	for tag, name := range blockTagNames {
+		if s == name {
+			return NewBlockTag(tag), nil
+		}
+	}
+	if isBlockTagWord(s) {
+		return BlockNumberOrTag{}, common.NewError(common.ErrorCode_UNSUPPORTED_BLOCK_TAG, fmt.Sprintf("unsupported block tag %q", s)).
+			WithDetail(BlockTagDetail, s)
+	}
+	n, err := decodeQuantity(s, false, 64)
+	if err != nil {
+		return BlockNumberOrTag{}, common.NewError(common.ErrorCode_INVALID_PARAMETER, fmt.Sprintf("invalid block number %q", s)).
+			WithCause(err)
+	}
+	return NewBlockNumber(n), nil
+}
+
+// isBlockTagWord reports whether s looks like a tag rather than a malformed number.
+func isBlockTagWord(s string) bool {
+	if s == "" || len(s) > 1 && s[0] == '0' && s[1]|0x20 == 'x' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the JSON-RPC string form of numbers and tags, and the hash of hash selectors.
+func (b BlockNumberOrTag) String() string {
+	switch {
+	case b.IsHash():
+		return b.BlockHash.Hex()
+	case b.IsTag():
+		return b.Tag.String()
+	}
+	return string(AppendQuantity(nil, b.Number))
+}
+
+// jsonRpcBlockObject is the EIP-1898 object form of a block parameter.
+type jsonRpcBlockObject struct {
+	BlockNumber      *string `json:"blockNumber,omitempty"`
+	BlockHash        *Hash   `json:"blockHash,omitempty"`
+	RequireCanonical *bool   `json:"requireCanonical,omitempty"`
+}
+
+// MarshalJSON encodes numbers and tags as strings and hash selectors as EIP-1898 objects.
+func (b BlockNumberOrTag) MarshalJSON() ([]byte, error) {
+	if !b.IsHash() {
+		return json.Marshal(b.String())
+	}
+	obj := jsonRpcBlockObject{BlockHash: b.BlockHash}
+	if b.RequireCanonical {
+		obj.RequireCanonical = &b.RequireCanonical
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON accepts the string form and EIP-1898 objects with either blockNumber or
+// blockHash and requireCanonical. Errors are common.BaseError as for ParseBlockNumberOrTag.
+func (b *BlockNumberOrTag) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return invalidBlockParameter(err)
+		}
+		v, err := ParseBlockNumberOrTag(s)
+		if err != nil {
+			return err
+		}
+		*b = v
+		return nil
+	}
+
+	var obj jsonRpcBlockObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return invalidBlockParameter(err)
+	}
+	switch {
+	case obj.BlockHash != nil && obj.BlockNumber != nil:
+		return invalidBlockParameter(fmt.Errorf("blockHash and blockNumber are mutually exclusive"))
+	case obj.BlockHash != nil:
+		*b = NewBlockHash(*obj.BlockHash, obj.RequireCanonical != nil && *obj.RequireCanonical)
+	case obj.BlockNumber != nil:
+		if obj.RequireCanonical != nil {
+			return invalidBlockParameter(fmt.Errorf("requireCanonical only applies to blockHash"))
+		}
+		v, err := ParseBlockNumberOrTag(*obj.BlockNumber)
+		if err != nil {
+			return err
+		}
+		*b = v
+	default:
+		return invalidBlockParameter(fmt.Errorf("expected a block number, tag, blockHash or blockNumber object"))
+	}
+	return nil
+}
+
+func invalidBlockParameter(cause error) error {
+	return common.NewError(common.ErrorCode_INVALID_PARAMETER, "invalid block parameter: "+cause.Error()).WithCause(cause)
+}
+
+// BlockSelector parses the blockNumber field.
+func (x *GetBlockByNumberRequest) BlockSelector() (BlockNumberOrTag, error) {
+	return parseRequiredBlockNumber("blockNumber", x.GetBlockNumber())
+}
+
+// BlockSelector parses the newestBlock field.
+func (x *GetFeeHistoryRequest) BlockSelector() (BlockNumberOrTag, error) {
+	return parseRequiredBlockNumber("newestBlock", x.GetNewestBlock())
+}
+
+// BlockSelector returns the block selected by either blockNumber or blockHash, failing with
+// INVALID_PARAMETER when both or neither are set.
+func (x *GetBlockReceiptsRequest) BlockSelector() (BlockNumberOrTag, error) {
+	switch {
+	case x.BlockNumber != nil && x.BlockHash != nil:
+		return BlockNumberOrTag{}, common.NewError(common.ErrorCode_INVALID_PARAMETER, "blockNumber and blockHash are mutually exclusive")
+	case x.BlockHash != nil:
+		h, err := NewHash(x.BlockHash)
+		if err != nil {
+			return BlockNumberOrTag{}, common.NewError(common.ErrorCode_INVALID_PARAMETER, "invalid blockHash: "+err.Error()).
+				WithCause(err).
+				WithDetail(ConvertErrorPathDetail, "blockHash")
+		}
+		return NewBlockHash(h, false), nil
+	}
+	return parseRequiredBlockNumber("blockNumber", x.GetBlockNumber())
+}
+
+func parseRequiredBlockNumber(field, s string) (BlockNumberOrTag, error) {
+	if s == "" {
+		return BlockNumberOrTag{}, common.NewError(common.ErrorCode_INVALID_PARAMETER, field+" is required").
+			WithDetail(ConvertErrorPathDetail, field)
+	}
+	b, err := ParseBlockNumberOrTag(s)
+	if baseErr, ok := err.(*common.BaseError); ok {
+		return b, baseErr.WithDetail(ConvertErrorPathDetail, field)
+	}
+	return b, err
+}
@@ -0,0 +1,190 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+func blockNumberErrorCode(t *testing.T, err error) common.ErrorCode {
+	t.Helper()
+	var baseErr *common.BaseError
+	if !errors.As(err, &baseErr) {
+		t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+	}
+	return baseErr.Code
+}
+
+func TestParseBlockNumberOrTag(t *testing.T) {
+	valid := map[string]BlockNumberOrTag{
+		"0x0":        NewBlockNumber(0),
+		"0x14ee0030": NewBlockNumber(0x14ee0030),
+		"0X10":       NewBlockNumber(16),
+		"latest":     NewBlockTag(BlockTagLatest),
+		"earliest":   NewBlockTag(BlockTagEarliest),
+		"pending":    NewBlockTag(BlockTagPending),
+		"safe":       NewBlockTag(BlockTagSafe),
+		"finalized":  NewBlockTag(BlockTagFinalized),
+	}
+	for s, want := range valid {
+		got, err := ParseBlockNumberOrTag(s)
+		if err != nil {
+			t.Errorf("ParseBlockNumberOrTag(%q) failed: %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseBlockNumberOrTag(%q) = %+v, want %+v", s, got, want)
+		}
+	}
+
+	invalid := map[string]common.ErrorCode{
+		"":                    common.ErrorCode_INVALID_PARAMETER,
+		"0x":                  common.ErrorCode_INVALID_PARAMETER,
+		"123":                 common.ErrorCode_INVALID_PARAMETER,
+		"0xzz":                common.ErrorCode_INVALID_PARAMETER,
+		"0x10000000000000000": common.ErrorCode_INVALID_PARAMETER,
+		"newest":              common.ErrorCode_UNSUPPORTED_BLOCK_TAG,
+		"Latest":              common.ErrorCode_UNSUPPORTED_BLOCK_TAG,
+	}
+	for s, want := range invalid {
+		_, err := ParseBlockNumberOrTag(s)
+		if err == nil {
+			t.Errorf("ParseBlockNumberOrTag(%q) succeeded", s)
+			continue
+		}
+		if code := blockNumberErrorCode(t, err); code != want {
+			t.Errorf("ParseBlockNumberOrTag(%q) code = %v, want %v", s, code, want)
+		}
+	}
+}
+
+func TestBlockNumberOrTagString(t *testing.T) {
+	h := MustHexToHash("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6")
+	tests := map[string]BlockNumberOrTag{
+		"0x0":       NewBlockNumber(0),
+		"0x121eac0": NewBlockNumber(0x121eac0),
+		"finalized": NewBlockTag(BlockTagFinalized),
+		h.Hex():     NewBlockHash(h, true),
+	}
+	for want, b := range tests {
+		if got := b.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestBlockNumberOrTagJSON(t *testing.T) {
+	const hash = "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		inputs := []string{
+			`"0x1b4"`,
+			`"safe"`,
+			`{"blockHash":"` + hash + `"}`,
+			`{"blockHash":"` + hash + `","requireCanonical":true}`,
+		}
+		for _, in := range inputs {
+			var b BlockNumberOrTag
+			if err := json.Unmarshal([]byte(in), &b); err != nil {
+				t.Errorf("Unmarshal(%s) failed: %v", in, err)
+				continue
+			}
+			out, err := json.Marshal(b)
+			if err != nil {
+				t.Errorf("Marshal(%+v) failed: %v", b, err)
+				continue
+			}
+			if string(out) != in {
+				t.Errorf("Round trip of %s gave %s", in, out)
+			}
+		}
+	})
+
+	t.Run("BlockNumberObject", func(t *testing.T) {
+		var b BlockNumberOrTag
+		if err := json.Unmarshal([]byte(`{"blockNumber":"0x1b4"}`), &b); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !b.IsNumber() || b.Number != 0x1b4 {
+			t.Errorf("Unexpected selector %+v", b)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		invalid := map[string]common.ErrorCode{
+			`{"blockHash":"` + hash + `","blockNumber":"0x1"}`: common.ErrorCode_INVALID_PARAMETER,
+			`{"blockNumber":"0x1","requireCanonical":true}`:    common.ErrorCode_INVALID_PARAMETER,
+			`{}`:                       common.ErrorCode_INVALID_PARAMETER,
+			`{"blockHash":"0x1234"}`:   common.ErrorCode_INVALID_PARAMETER,
+			`12`:                       common.ErrorCode_INVALID_PARAMETER,
+			`"0xnope"`:                 common.ErrorCode_INVALID_PARAMETER,
+			`"justified"`:              common.ErrorCode_UNSUPPORTED_BLOCK_TAG,
+			`{"blockNumber":"unsafe"}`: common.ErrorCode_UNSUPPORTED_BLOCK_TAG,
+		}
+		for in, want := range invalid {
+			var b BlockNumberOrTag
+			err := json.Unmarshal([]byte(in), &b)
+			if err == nil {
+				t.Errorf("Unmarshal(%s) succeeded: %+v", in, b)
+				continue
+			}
+			if code := blockNumberErrorCode(t, err); code != want {
+				t.Errorf("Unmarshal(%s) code = %v, want %v", in, code, want)
+			}
+		}
+	})
+}
+
+func TestRequestBlockSelector(t *testing.T) {
+	t.Run("GetBlockByNumber", func(t *testing.T) {
+		b, err := (&GetBlockByNumberRequest{BlockNumber: "finalized"}).BlockSelector()
+		if err != nil || b != NewBlockTag(BlockTagFinalized) {
+			t.Errorf("Unexpected selector %+v (%v)", b, err)
+		}
+		_, err = (&GetBlockByNumberRequest{BlockNumber: "head"}).BlockSelector()
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_UNSUPPORTED_BLOCK_TAG {
+			t.Fatalf("Expected UNSUPPORTED_BLOCK_TAG, got %v", err)
+		}
+		if path := baseErr.Details[ConvertErrorPathDetail]; path != "blockNumber" {
+			t.Errorf("Unexpected path %v", path)
+		}
+		if tag := baseErr.Details[BlockTagDetail]; tag != "head" {
+			t.Errorf("Unexpected tag detail %v", tag)
+		}
+	})
+
+	t.Run("GetFeeHistory", func(t *testing.T) {
+		b, err := (&GetFeeHistoryRequest{NewestBlock: "0x10"}).BlockSelector()
+		if err != nil || b != NewBlockNumber(16) {
+			t.Errorf("Unexpected selector %+v (%v)", b, err)
+		}
+	})
+
+	t.Run("GetBlockReceipts", func(t *testing.T) {
+		number, hash := "safe", make([]byte, 32)
+		hash[31] = 1
+
+		b, err := (&GetBlockReceiptsRequest{BlockNumber: &number}).BlockSelector()
+		if err != nil || b != NewBlockTag(BlockTagSafe) {
+			t.Errorf("Unexpected selector %+v (%v)", b, err)
+		}
+		b, err = (&GetBlockReceiptsRequest{BlockHash: hash}).BlockSelector()
+		if err != nil || !b.IsHash() || b.BlockHash[31] != 1 {
+			t.Errorf("Unexpected selector %+v (%v)", b, err)
+		}
+
+		invalid := map[string]*GetBlockReceiptsRequest{
+			"Both":      {BlockNumber: &number, BlockHash: hash},
+			"Neither":   {},
+			"ShortHash": {BlockHash: hash[:20]},
+		}
+		for name, req := range invalid {
+			if _, err := req.BlockSelector(); err == nil || blockNumberErrorCode(t, err) != common.ErrorCode_INVALID_PARAMETER {
+				t.Errorf("%s: expected INVALID_PARAMETER, got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// TransactionsRoot returns the root of the trie of the raw signed transactions, as committed to
+// by BlockHeader.transactionsRoot. Errors are as for EncodeTransaction, with paths such as
+// "transactions[3].r".
+func TransactionsRoot(txs []*Transaction) (Hash, error) {
+	p := newFieldParser(true)
+	root := transactionsRoot(p, "transactions", txs)
+	return root, p.err()
+}
+
+// ReceiptsRoot returns the root of the trie of the consensus-encoded receipts, as committed to
+// by BlockHeader.receiptsRoot. Receipts must be in transaction order. Errors are as for
+// EncodeReceipt, with paths such as "receipts[3].logs[0].address".
+func ReceiptsRoot(receipts []*Receipt) (Hash, error) {
+	p := newFieldParser(true)
+	root := receiptsRoot(p, "receipts", receipts)
+	return root, p.err()
+}
+
+// WithdrawalsRoot returns the root of the trie of the RLP-encoded withdrawals
+// [index, validatorIndex, address, amount], as committed to by BlockHeader.withdrawalsRoot.
+func WithdrawalsRoot(withdrawals []*Withdrawal) (Hash, error) {
+	p := newFieldParser(true)
+	root := withdrawalsRoot(p, "withdrawals", withdrawals)
+	return root, p.err()
+}
+
+func transactionsRoot(p *fieldParser, name string, txs []*Transaction) Hash {
+	items := make([][]byte, len(txs))
+	for i, tx := range txs {
+		if tx == nil {
+			p.item(name, i).fail("", errors.New("transaction is nil"))
+			continue
+		}
+		items[i] = appendSignedTransaction(p.item(name, i), nil, tx)
+	}
+	return OrderedTrieRoot(items)
+}
+
+func receiptsRoot(p *fieldParser, name string, receipts []*Receipt) Hash {
+	items := make([][]byte, len(receipts))
+	for i, r := range receipts {
+		if r == nil {
+			p.item(name, i).fail("", errors.New("receipt is nil"))
+			continue
+		}
+		items[i] = appendReceipt(p.item(name, i), nil, r)
+	}
+	return OrderedTrieRoot(items)
+}
+
+func withdrawalsRoot(p *fieldParser, name string, withdrawals []*Withdrawal) Hash {
+	items := make([][]byte, len(withdrawals))
+	for i, w := range withdrawals {
+		wp := p.item(name, i)
+		if w == nil {
+			wp.fail("", errors.New("withdrawal is nil"))
+			continue
+		}
+		var payload []byte
+		payload = rlpAppendUint(payload, w.Index)
+		payload = rlpAppendUint(payload, w.ValidatorIndex)
+		payload = appendRlpFixed(wp, payload, "address", w.Address, AddressLength)
+		payload = rlpAppendUint(payload, w.Amount)
+		items[i] = rlpAppendList(nil, payload)
+	}
+	return OrderedTrieRoot(items)
+}
+
+// VerifyBlockRoots recomputes the transactions, receipts and withdrawals roots of block and
+// compares them with its header. The block must carry full transactions, and receipts must be
+// the block's receipts in transaction order. The withdrawals root is checked when the header
+// has one. The error is a common.BaseError with code INVALID_PARAMETER whose path detail names
+// the inconsistent root, e.g. "header.receiptsRoot", with the computed root under the
+// computedHash detail; with several failures every path is listed under the paths detail.
+// Fields that cannot be encoded are reported under their own paths, e.g.
+// "fullTransactions[2].v", and their root is not compared. A missing header is reported under
+// "header".
+func VerifyBlockRoots(block *Block, receipts []*Receipt) error {
+	p := newFieldParser(true)
+	if block == nil || block.Header == nil {
+		p.fail("header", errors.New("block header is required"))
+		return p.err()
+	}
+	header := block.Header
+	hp := p.child("header")
+
+	txCount := max(len(block.FullTransactions), len(block.TransactionHashes))
+	if len(block.FullTransactions) < txCount {
+		p.fail("fullTransactions", fmt.Errorf("%d of %d transactions are missing, full transactions are required to compute transactionsRoot", txCount-len(block.FullTransactions), txCount))
+	} else {
+		n := len(p.errs.list)
+		root := transactionsRoot(p, "fullTransactions", block.FullTransactions)
+		if len(p.errs.list) == n {
+			compareRoot(hp, "transactionsRoot", root, header.TransactionsRoot)
+		}
+	}
+
+	if len(receipts) != txCount {
+		p.fail("receipts", fmt.Errorf("expected %d receipts, got %d", txCount, len(receipts)))
+	} else {
+		n := len(p.errs.list)
+		root := receiptsRoot(p, "receipts", receipts)
+		if len(p.errs.list) == n {
+			compareRoot(hp, "receiptsRoot", root, header.ReceiptsRoot)
+		}
+	}
+
+	switch {
+	case header.WithdrawalsRoot != nil:
+		n := len(p.errs.list)
+		root := withdrawalsRoot(p, "withdrawals", block.Withdrawals)
+		if len(p.errs.list) == n {
+			compareRoot(hp, "withdrawalsRoot", root, header.WithdrawalsRoot)
+		}
+	case len(block.Withdrawals) > 0:
+		p.fail("withdrawals", errors.New("block has withdrawals but its header has no withdrawalsRoot"))
+	}
+	return p.err()
+}
+
+// compareRoot records a mismatch between a computed and a reported root.
+func compareRoot(p *fieldParser, name string, computed Hash, reported []byte) {
+	if !bytes.Equal(computed[:], reported) {
+		p.failMismatch(name, fmt.Errorf("computed %s, not %s", computed.Hex(), BytesToHex(reported)), ComputedHashDetail, computed.Hex())
+	}
+}
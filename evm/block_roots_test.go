@@ -0,0 +1,227 @@
+package evm
+
+import (
+	"errors"
+	"math/bits"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+func testReceipt(typ TransactionType, cumulativeGasUsed uint64) *Receipt {
+	status := uint32(1)
+	return &Receipt{
+		Type:              uint32(typ),
+		Status:            &status,
+		CumulativeGasUsed: cumulativeGasUsed,
+		Logs: []*Log{{
+			Address: MustHexToBytes("0xdac17f958d2ee523a2206206994597c13d831ec7"),
+			Topics:  [][]byte{MustHexToBytes(TransferEventSignature), make([]byte, 32)},
+			Data:    MustHexToBytes("0x01"),
+		}},
+	}
+}
+
+// testBlockWithRoots returns a block with consistent roots and its receipts.
+func testBlockWithRoots(t *testing.T) (*Block, []*Receipt) {
+	t.Helper()
+	txs := []*Transaction{
+		eip155ExampleTransaction(),
+		typedTestTransaction(TransactionType_DYNAMIC_FEE),
+		typedTestTransaction(TransactionType_BLOB),
+	}
+	receipts := []*Receipt{
+		testReceipt(TransactionType_LEGACY, 21000),
+		testReceipt(TransactionType_DYNAMIC_FEE, 80000),
+		testReceipt(TransactionType_BLOB, 130000),
+	}
+	withdrawals := []*Withdrawal{
+		{Index: 1, ValidatorIndex: 2, Address: MustHexToBytes("0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f"), Amount: 32},
+		{Index: 2, ValidatorIndex: 3, Address: MustHexToBytes("0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f"), Amount: 1},
+	}
+	txRoot, err := TransactionsRoot(txs)
+	if err != nil {
+		t.Fatalf("TransactionsRoot failed: %v", err)
+	}
+	receiptRoot, err := ReceiptsRoot(receipts)
+	if err != nil {
+		t.Fatalf("ReceiptsRoot failed: %v", err)
+	}
+	withdrawalRoot, err := WithdrawalsRoot(withdrawals)
+	if err != nil {
+		t.Fatalf("WithdrawalsRoot failed: %v", err)
+	}
+	block := &Block{
+		Header: &BlockHeader{
+			Number:           1,
+			TransactionsRoot: txRoot.Bytes(),
+			ReceiptsRoot:     receiptRoot.Bytes(),
+			WithdrawalsRoot:  withdrawalRoot.Bytes(),
+		},
+		FullTransactions: txs,
+		Withdrawals:      withdrawals,
+	}
+	return block, receipts
+}
+
+func TestEncodeReceipt(t *testing.T) {
+	t.Run("Status", func(t *testing.T) {
+		for status, want := range map[uint32]byte{0: 0x80, 1: 0x01} {
+			r := testReceipt(TransactionType_LEGACY, 21000)
+			r.Status = &status
+			enc, err := EncodeReceipt(r)
+			if err != nil {
+				t.Fatalf("EncodeReceipt failed: %v", err)
+			}
+			items, err := rlpDecodeList(enc)
+			if err != nil || len(items) != 4 {
+				t.Fatalf("Expected a 4 item list, got %d (%v)", len(items), err)
+			}
+			if items[0][0] != want {
+				t.Errorf("Status %d encoded as %x", status, items[0])
+			}
+		}
+	})
+
+	t.Run("PreByzantiumRoot", func(t *testing.T) {
+		r := testReceipt(TransactionType_LEGACY, 21000)
+		r.Status = nil
+		r.Root = Keccak256([]byte("state"))
+		enc, err := EncodeReceipt(r)
+		if err != nil {
+			t.Fatalf("EncodeReceipt failed: %v", err)
+		}
+		items, _ := rlpDecodeList(enc)
+		if root, _ := rlpDecodeBytes(items[0]); BytesToHex(root) != BytesToHex(r.Root) {
+			t.Errorf("Expected the state root first, got %x", items[0])
+		}
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		enc, err := EncodeReceipt(testReceipt(TransactionType_BLOB, 21000))
+		if err != nil {
+			t.Fatalf("EncodeReceipt failed: %v", err)
+		}
+		if enc[0] != byte(TransactionType_BLOB) {
+			t.Fatalf("Expected type prefix 3, got %d", enc[0])
+		}
+		if _, err := rlpDecodeList(enc[1:]); err != nil {
+			t.Errorf("Payload is not an RLP list: %v", err)
+		}
+	})
+
+	t.Run("Bloom", func(t *testing.T) {
+		r := testReceipt(TransactionType_LEGACY, 21000)
+		bloom := logsBloom(r.Logs)
+		set := 0
+		for _, b := range bloom {
+			set += bits.OnesCount8(b)
+		}
+		if set == 0 || set > 9 {
+			t.Errorf("Expected 1 to 9 bloom bits for an address and two topics, got %d", set)
+		}
+		computed, _ := EncodeReceipt(r)
+		r.LogsBloom = bloom
+		provided, _ := EncodeReceipt(r)
+		if BytesToHex(computed) != BytesToHex(provided) {
+			t.Errorf("Expected a missing logsBloom to be computed from the logs")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		r := testReceipt(TransactionType_DYNAMIC_FEE, 21000)
+		r.Status = nil
+		r.LogsBloom = []byte{1}
+		r.Logs[0].Topics[1] = []byte{1}
+		_, err := EncodeReceipt(r)
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %v", err)
+		}
+		want := []string{"status", "logsBloom", "logs[0].topics[1]"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Expected paths %v, got %v", want, paths)
+		}
+	})
+}
+
+func TestVerifyBlockRoots(t *testing.T) {
+	block, receipts := testBlockWithRoots(t)
+	if err := VerifyBlockRoots(block, receipts); err != nil {
+		t.Fatalf("VerifyBlockRoots failed: %v", err)
+	}
+
+	mismatches := map[string]func(b *Block, r []*Receipt){
+		"header.transactionsRoot": func(b *Block, r []*Receipt) { b.FullTransactions[1].Nonce++ },
+		"header.receiptsRoot":     func(b *Block, r []*Receipt) { r[2].CumulativeGasUsed++ },
+		"header.withdrawalsRoot":  func(b *Block, r []*Receipt) { b.Withdrawals[0].Amount++ },
+	}
+	for path, modify := range mismatches {
+		t.Run(path, func(t *testing.T) {
+			block, receipts := testBlockWithRoots(t)
+			modify(block, receipts)
+			err := VerifyBlockRoots(block, receipts)
+			var baseErr *common.BaseError
+			if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+				t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+			}
+			if got := baseErr.Details[ConvertErrorPathDetail]; got != path {
+				t.Errorf("Expected path %s, got %v (%v)", path, got, err)
+			}
+			if _, ok := baseErr.Details[ComputedHashDetail]; !ok {
+				t.Errorf("Expected the computed root under %s", ComputedHashDetail)
+			}
+		})
+	}
+
+	t.Run("Several", func(t *testing.T) {
+		block, receipts := testBlockWithRoots(t)
+		block.Header.TransactionsRoot = EmptyTrieRoot
+		block.Header.WithdrawalsRoot = EmptyTrieRoot
+		err := VerifyBlockRoots(block, receipts)
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %v", err)
+		}
+		want := []string{"header.transactionsRoot", "header.withdrawalsRoot"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Expected paths %v, got %v", want, paths)
+		}
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		block, receipts := testBlockWithRoots(t)
+		block.TransactionHashes = make([][]byte, 3)
+		block.FullTransactions = nil
+		err := VerifyBlockRoots(block, receipts[:2])
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %v", err)
+		}
+		want := []string{"fullTransactions", "receipts"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Expected paths %v, got %v", want, paths)
+		}
+	})
+
+	t.Run("NoHeader", func(t *testing.T) {
+		for _, block := range []*Block{nil, {}} {
+			err := VerifyBlockRoots(block, nil)
+			var baseErr *common.BaseError
+			if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+				t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+			}
+			if got := baseErr.Details[ConvertErrorPathDetail]; got != "header" {
+				t.Errorf("Expected path header, got %v", got)
+			}
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		block := &Block{Header: &BlockHeader{TransactionsRoot: EmptyTrieRoot, ReceiptsRoot: EmptyTrieRoot}}
+		if err := VerifyBlockRoots(block, nil); err != nil {
+			t.Errorf("VerifyBlockRoots failed: %v", err)
+		}
+	})
+}
@@ -0,0 +1,593 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// Chain extensions group the fields of one L2 or sidechain ecosystem into a typed message
+// (Transaction.optimism, Receipt.arbitrum, ...) instead of adding flat fields to the core
+// models for every new chain.
+//
+// Migration from the flat fields happens in three steps:
+//  1. Converters fill both the flat fields and the extensions, so existing consumers keep
+//     working. Stored messages are upgraded with PopulateTransactionExtensions and
+//     PopulateReceiptExtensions.
+//  2. Consumers read the extensions. Producers that no longer need the flat fields drop them
+//     with ClearFlatTransactionFields and ClearFlatReceiptFields; the ...ToJsonRpc and
+//     Append...JsonRpc functions emit the same JSON either way.
+//  3. The flat fields are removed from the schema (their numbers reserved) in the next major
+//     version. FlattenTransactionExtensions and FlattenReceiptExtensions serve consumers that
+//     still need the flat layout until then.
+
+// Ecosystem identifies a chain family with its own extension message.
+type Ecosystem int
+
+const (
+	// EcosystemAuto detects the ecosystem of each object from its transaction type and the
+	// fields present. Polygon is never detected, since Bor objects look like Ethereum ones.
+	EcosystemAuto Ecosystem = iota
+	// EcosystemOptimism covers OP stack chains (Optimism, Base, ...).
+	EcosystemOptimism
+	// EcosystemArbitrum covers Arbitrum One, Nova and Orbit chains.
+	EcosystemArbitrum
+	// EcosystemCelo covers Celo, which is an OP stack chain since its L2 migration, so it
+	// populates the optimism extension as well.
+	EcosystemCelo
+	// EcosystemZkSync covers zkSync Era and ZK stack chains.
+	EcosystemZkSync
+	// EcosystemPolygon covers Polygon PoS (Bor).
+	EcosystemPolygon
+)
+
+func (e Ecosystem) String() string {
+	switch e {
+	case EcosystemAuto:
+		return "auto"
+	case EcosystemOptimism:
+		return "optimism"
+	case EcosystemArbitrum:
+		return "arbitrum"
+	case EcosystemCelo:
+		return "celo"
+	case EcosystemZkSync:
+		return "zksync"
+	case EcosystemPolygon:
+		return "polygon"
+	}
+	return fmt.Sprintf("Ecosystem(%d)", int(e))
+}
+
+// includes reports whether objects converted for e may carry the extension of o.
+func (e Ecosystem) includes(o Ecosystem) bool {
+	switch e {
+	case EcosystemAuto:
+		return o != EcosystemPolygon
+	case EcosystemCelo:
+		return o == EcosystemCelo || o == EcosystemOptimism
+	}
+	return e == o
+}
+
+// transactionTypeEcosystem returns the ecosystem that defines the EIP-2718 transaction type,
+// or EcosystemAuto for Ethereum types.
+func transactionTypeEcosystem(typ uint32) Ecosystem {
+	switch t := TransactionType(typ); {
+	case t == TransactionType_OPTIMISM_DEPOSIT:
+		return EcosystemOptimism
+	case t >= TransactionType_ARBITRUM_DEPOSIT && t <= TransactionType_ARBITRUM_INTERNAL:
+		return EcosystemArbitrum
+	case typ >= 0x7a && typ <= 0x7c:
+		// Celo CIP-66, CIP-64 and CIP-42
+		return EcosystemCelo
+	case typ == 0x71 || typ == 0xff:
+		// zkSync EIP-712 and priority (L1) transactions
+		return EcosystemZkSync
+	}
+	return EcosystemAuto
+}
+
+// PopulateTransactionExtensions fills the chain extensions of tx from its flat L2 fields.
+// An extension is attached when the transaction type belongs to its ecosystem or one of its
+// flat fields is set, and only for the ecosystems the given one includes. Existing extension
+// values are kept unless the corresponding flat field is set. The flat fields are left in
+// place; see ClearFlatTransactionFields.
+func PopulateTransactionExtensions(tx *Transaction, ecosystem Ecosystem) {
+	if tx == nil {
+		return
+	}
+	typeEcosystem := transactionTypeEcosystem(tx.Type)
+
+	if ecosystem.includes(EcosystemOptimism) && (typeEcosystem == EcosystemOptimism || hasFlatOptimismFields(tx)) {
+		ext := tx.Optimism
+		if ext == nil {
+			ext = &OptimismTransactionExtension{}
+		}
+		setIfPresent(&ext.L1Fee, tx.L1Fee)
+		setIfPresent(&ext.L1GasPrice, tx.L1GasPrice)
+		setIfPresent(&ext.L1GasUsed, tx.L1GasUsed)
+		setIfPresent(&ext.L1FeeScalar, tx.L1FeeScalar)
+		setIfPresent(&ext.L1BlobBaseFee, tx.L1BlobBaseFee)
+		setIfPresent(&ext.L1BlobBaseFeeScalar, tx.L1BlobBaseFeeScalar)
+		setIfPresent(&ext.IsSystemTx, tx.IsSystemTx)
+		setIfPresent(&ext.DepositReceiptVersion, tx.DepositReceiptVersion)
+		tx.Optimism = ext
+	}
+
+	if ecosystem.includes(EcosystemArbitrum) && (typeEcosystem == EcosystemArbitrum || hasFlatArbitrumFields(tx)) {
+		ext := tx.Arbitrum
+		if ext == nil {
+			ext = &ArbitrumTransactionExtension{}
+		}
+		setBytesIfPresent(&ext.Beneficiary, tx.Beneficiary)
+		setIfPresent(&ext.DepositValue, tx.DepositValue)
+		setIfPresent(&ext.L1BaseFee, tx.L1BaseFee)
+		setIfPresent(&ext.MaxSubmissionFee, tx.MaxSubmissionFee)
+		setBytesIfPresent(&ext.RefundTo, tx.RefundTo)
+		setBytesIfPresent(&ext.RequestId, tx.RequestId)
+		setBytesIfPresent(&ext.RetryData, tx.RetryData)
+		setBytesIfPresent(&ext.RetryTo, tx.RetryTo)
+		setIfPresent(&ext.RetryValue, tx.RetryValue)
+		setIfPresent(&ext.MaxRefund, tx.MaxRefund)
+		setIfPresent(&ext.SubmissionFeeRefund, tx.SubmissionFeeRefund)
+		setBytesIfPresent(&ext.TicketId, tx.TicketId)
+		tx.Arbitrum = ext
+	}
+
+	if ecosystem.includes(EcosystemCelo) && (typeEcosystem == EcosystemCelo || hasFlatCeloFields(tx)) {
+		ext := tx.Celo
+		if ext == nil {
+			ext = &CeloTransactionExtension{}
+		}
+		setBytesIfPresent(&ext.FeeCurrency, tx.FeeCurrency)
+		setIfPresent(&ext.GatewayFee, tx.GatewayFee)
+		setBytesIfPresent(&ext.GatewayFeeRecipient, tx.GatewayFeeRecipient)
+		tx.Celo = ext
+	}
+
+	if ecosystem.includes(EcosystemZkSync) && typeEcosystem == EcosystemZkSync && tx.ZkSync == nil {
+		tx.ZkSync = &ZkSyncTransactionExtension{}
+	}
+
+	if ecosystem == EcosystemPolygon && tx.Polygon == nil {
+		tx.Polygon = &PolygonTransactionExtension{StateSync: isBorStateSync(tx.From, tx.To)}
+	}
+}
+
+// ClearFlatTransactionFields unsets the deprecated flat L2 fields of tx whose ecosystem has an
+// extension attached, so the values are only stored once.
+func ClearFlatTransactionFields(tx *Transaction) {
+	if tx == nil {
+		return
+	}
+	if tx.Optimism != nil {
+		tx.L1Fee = nil
+		tx.L1GasPrice = nil
+		tx.L1GasUsed = nil
+		tx.L1FeeScalar = nil
+		tx.L1BlobBaseFee = nil
+		tx.L1BlobBaseFeeScalar = nil
+		tx.IsSystemTx = nil
+		tx.DepositReceiptVersion = nil
+	}
+	if tx.Arbitrum != nil {
+		tx.Beneficiary = nil
+		tx.DepositValue = nil
+		tx.L1BaseFee = nil
+		tx.MaxSubmissionFee = nil
+		tx.RefundTo = nil
+		tx.RequestId = nil
+		tx.RetryData = nil
+		tx.RetryTo = nil
+		tx.RetryValue = nil
+		tx.MaxRefund = nil
+		tx.SubmissionFeeRefund = nil
+		tx.TicketId = nil
+	}
+	if tx.Celo != nil {
+		tx.FeeCurrency = nil
+		tx.GatewayFee = nil
+		tx.GatewayFeeRecipient = nil
+	}
+}
+
+// FlattenTransactionExtensions copies the values of the chain extensions of tx into the
+// deprecated flat fields, for consumers that have not migrated yet.
+func FlattenTransactionExtensions(tx *Transaction) {
+	if tx == nil {
+		return
+	}
+	if ext := tx.Optimism; ext != nil {
+		setIfPresent(&tx.L1Fee, ext.L1Fee)
+		setIfPresent(&tx.L1GasPrice, ext.L1GasPrice)
+		setIfPresent(&tx.L1GasUsed, ext.L1GasUsed)
+		setIfPresent(&tx.L1FeeScalar, ext.L1FeeScalar)
+		setIfPresent(&tx.L1BlobBaseFee, ext.L1BlobBaseFee)
+		setIfPresent(&tx.L1BlobBaseFeeScalar, ext.L1BlobBaseFeeScalar)
+		setIfPresent(&tx.IsSystemTx, ext.IsSystemTx)
+		setIfPresent(&tx.DepositReceiptVersion, ext.DepositReceiptVersion)
+	}
+	if ext := tx.Arbitrum; ext != nil {
+		setBytesIfPresent(&tx.Beneficiary, ext.Beneficiary)
+		setIfPresent(&tx.DepositValue, ext.DepositValue)
+		setIfPresent(&tx.L1BaseFee, ext.L1BaseFee)
+		setIfPresent(&tx.MaxSubmissionFee, ext.MaxSubmissionFee)
+		setBytesIfPresent(&tx.RefundTo, ext.RefundTo)
+		setBytesIfPresent(&tx.RequestId, ext.RequestId)
+		setBytesIfPresent(&tx.RetryData, ext.RetryData)
+		setBytesIfPresent(&tx.RetryTo, ext.RetryTo)
+		setIfPresent(&tx.RetryValue, ext.RetryValue)
+		setIfPresent(&tx.MaxRefund, ext.MaxRefund)
+		setIfPresent(&tx.SubmissionFeeRefund, ext.SubmissionFeeRefund)
+		setBytesIfPresent(&tx.TicketId, ext.TicketId)
+	}
+	if ext := tx.Celo; ext != nil {
+		setBytesIfPresent(&tx.FeeCurrency, ext.FeeCurrency)
+		setIfPresent(&tx.GatewayFee, ext.GatewayFee)
+		setBytesIfPresent(&tx.GatewayFeeRecipient, ext.GatewayFeeRecipient)
+	}
+}
+
+// PopulateReceiptExtensions fills the chain extensions of r from its flat L2 fields and, for
+// zkSync, from the l1BatchNumber and l1BatchTxIndex members of its extensions map. The rules
+// match PopulateTransactionExtensions.
+func PopulateReceiptExtensions(r *Receipt, ecosystem Ecosystem) {
+	if r == nil {
+		return
+	}
+	typeEcosystem := transactionTypeEcosystem(r.Type)
+
+	if ecosystem.includes(EcosystemOptimism) && (typeEcosystem == EcosystemOptimism || hasFlatOptimismReceiptFields(r)) {
+		ext := r.Optimism
+		if ext == nil {
+			ext = &OptimismReceiptExtension{}
+		}
+		setIfPresent(&ext.L1Fee, r.L1Fee)
+		setIfPresent(&ext.L1GasPrice, r.L1GasPrice)
+		setIfPresent(&ext.L1GasUsed, r.L1GasUsed)
+		setIfPresent(&ext.L1FeeScalar, r.L1FeeScalar)
+		setIfPresent(&ext.L1BaseFeeScalar, r.L1BaseFeeScalar)
+		setIfPresent(&ext.L1BlobBaseFee, r.L1BlobBaseFee)
+		setIfPresent(&ext.L1BlobBaseFeeScalar, r.L1BlobBaseFeeScalar)
+		setIfPresent(&ext.DepositNonce, r.DepositNonce)
+		setIfPresent(&ext.DepositReceiptVersion, r.DepositReceiptVersion)
+		r.Optimism = ext
+	}
+
+	if ecosystem.includes(EcosystemArbitrum) && (typeEcosystem == EcosystemArbitrum || hasFlatArbitrumReceiptFields(r)) {
+		ext := r.Arbitrum
+		if ext == nil {
+			ext = &ArbitrumReceiptExtension{}
+		}
+		setIfPresent(&ext.GasUsedForL1, r.GasUsedForL1)
+		setIfPresent(&ext.L1BlockNumber, r.L1BlockNumber)
+		setIfPresent(&ext.Timeboosted, r.Timeboosted)
+		r.Arbitrum = ext
+	}
+
+	if ecosystem.includes(EcosystemCelo) && (typeEcosystem == EcosystemCelo || r.GatewayFee != nil) {
+		ext := r.Celo
+		if ext == nil {
+			ext = &CeloReceiptExtension{}
+		}
+		setIfPresent(&ext.GatewayFee, r.GatewayFee)
+		r.Celo = ext
+	}
+
+	if ecosystem.includes(EcosystemZkSync) {
+		batch := extensionQuantity(r.Extensions, "l1BatchNumber")
+		index := extensionQuantity(r.Extensions, "l1BatchTxIndex")
+		if typeEcosystem == EcosystemZkSync || batch != nil || index != nil {
+			ext := r.ZkSync
+			if ext == nil {
+				ext = &ZkSyncReceiptExtension{}
+			}
+			setIfPresent(&ext.L1BatchNumber, batch)
+			setIfPresent(&ext.L1BatchTxIndex, index)
+			r.ZkSync = ext
+		}
+	}
+
+	if ecosystem == EcosystemPolygon && r.Polygon == nil {
+		r.Polygon = &PolygonReceiptExtension{StateSync: isBorStateSync(r.From, r.To)}
+	}
+}
+
+// ClearFlatReceiptFields unsets the deprecated flat L2 fields of r whose ecosystem has an
+// extension attached, including the zkSync members of the extensions map.
+func ClearFlatReceiptFields(r *Receipt) {
+	if r == nil {
+		return
+	}
+	if r.Optimism != nil {
+		r.L1Fee = nil
+		r.L1GasPrice = nil
+		r.L1GasUsed = nil
+		r.L1FeeScalar = nil
+		r.L1BaseFeeScalar = nil
+		r.L1BlobBaseFee = nil
+		r.L1BlobBaseFeeScalar = nil
+		r.DepositNonce = nil
+		r.DepositReceiptVersion = nil
+	}
+	if r.Arbitrum != nil {
+		r.GasUsedForL1 = nil
+		r.L1BlockNumber = nil
+		r.Timeboosted = nil
+	}
+	if r.Celo != nil {
+		r.GatewayFee = nil
+	}
+	if r.ZkSync != nil && r.Extensions != nil {
+		delete(r.Extensions, "l1BatchNumber")
+		delete(r.Extensions, "l1BatchTxIndex")
+	}
+}
+
+// FlattenReceiptExtensions copies the values of the chain extensions of r into the deprecated
+// flat fields and, for zkSync, into the extensions map.
+func FlattenReceiptExtensions(r *Receipt) {
+	if r == nil {
+		return
+	}
+	if ext := r.Optimism; ext != nil {
+		setIfPresent(&r.L1Fee, ext.L1Fee)
+		setIfPresent(&r.L1GasPrice, ext.L1GasPrice)
+		setIfPresent(&r.L1GasUsed, ext.L1GasUsed)
+		setIfPresent(&r.L1FeeScalar, ext.L1FeeScalar)
+		setIfPresent(&r.L1BaseFeeScalar, ext.L1BaseFeeScalar)
+		setIfPresent(&r.L1BlobBaseFee, ext.L1BlobBaseFee)
+		setIfPresent(&r.L1BlobBaseFeeScalar, ext.L1BlobBaseFeeScalar)
+		setIfPresent(&r.DepositNonce, ext.DepositNonce)
+		setIfPresent(&r.DepositReceiptVersion, ext.DepositReceiptVersion)
+	}
+	if ext := r.Arbitrum; ext != nil {
+		setIfPresent(&r.GasUsedForL1, ext.GasUsedForL1)
+		setIfPresent(&r.L1BlockNumber, ext.L1BlockNumber)
+		setIfPresent(&r.Timeboosted, ext.Timeboosted)
+	}
+	if ext := r.Celo; ext != nil {
+		setIfPresent(&r.GatewayFee, ext.GatewayFee)
+	}
+	if ext := r.ZkSync; ext != nil {
+		setExtensionQuantity(r, "l1BatchNumber", ext.L1BatchNumber)
+		setExtensionQuantity(r, "l1BatchTxIndex", ext.L1BatchTxIndex)
+	}
+}
+
+// transactionWithFlatFields returns tx itself when its flat L2 fields are populated, and
+// otherwise a flattened shallow copy, so JSON-RPC encoders read a single layout.
+func transactionWithFlatFields(tx *Transaction) *Transaction {
+	if (tx.Optimism == nil || hasFlatOptimismFields(tx)) &&
+		(tx.Arbitrum == nil || hasFlatArbitrumFields(tx)) &&
+		(tx.Celo == nil || hasFlatCeloFields(tx)) {
+		return tx
+	}
+	flat := &Transaction{}
+	shallowCopyMessage(flat, tx)
+	FlattenTransactionExtensions(flat)
+	return flat
+}
+
+// receiptWithFlatFields is transactionWithFlatFields for receipts.
+func receiptWithFlatFields(r *Receipt) *Receipt {
+	if (r.Optimism == nil || hasFlatOptimismReceiptFields(r)) &&
+		(r.Arbitrum == nil || hasFlatArbitrumReceiptFields(r)) &&
+		(r.Celo == nil || r.GatewayFee != nil) &&
+		(r.ZkSync == nil || hasZkSyncReceiptExtensions(r)) {
+		return r
+	}
+	flat := &Receipt{}
+	shallowCopyMessage(flat, r)
+	flat.Extensions = maps.Clone(r.Extensions)
+	FlattenReceiptExtensions(flat)
+	return flat
+}
+
+// transactionL2Fields are the flat L2 fields of a transaction as the JSON-RPC encoders emit
+// them: the flat fields themselves or, for an ecosystem with an extension and none of its flat
+// fields set, the extension's values, as transactionWithFlatFields would produce without the
+// copy. Wei amounts carry their U256 bytes for withU256Strings' fallback.
+type transactionL2Fields struct {
+	l1Fee, l1GasPrice, l1GasUsed, l1BlobBaseFee                  weiField
+	l1FeeScalar                                                  *float64
+	l1BlobBaseFeeScalar                                          *uint64
+	isSystemTx                                                   *bool
+	depositReceiptVersion                                        *string
+	beneficiary, refundTo, requestId, retryData, retryTo, ticket []byte
+	depositValue, l1BaseFee, maxSubmissionFee                    weiField
+	retryValue, maxRefund, submissionFeeRefund                   weiField
+	feeCurrency, gatewayFeeRecipient                             []byte
+	gatewayFee                                                   weiField
+}
+
+func flatTransactionL2Fields(tx *Transaction) transactionL2Fields {
+	var f transactionL2Fields
+	if ext := tx.Optimism; ext != nil && !hasFlatOptimismFields(tx) {
+		f.l1Fee = weiField{ext.L1Fee, ext.L1FeeU256}
+		f.l1GasPrice = weiField{ext.L1GasPrice, ext.L1GasPriceU256}
+		f.l1GasUsed = weiField{str: ext.L1GasUsed}
+		f.l1FeeScalar = ext.L1FeeScalar
+		f.l1BlobBaseFee = weiField{ext.L1BlobBaseFee, ext.L1BlobBaseFeeU256}
+		f.l1BlobBaseFeeScalar = ext.L1BlobBaseFeeScalar
+		f.isSystemTx = ext.IsSystemTx
+		f.depositReceiptVersion = ext.DepositReceiptVersion
+	} else {
+		f.l1Fee = weiField{str: tx.L1Fee}
+		f.l1GasPrice = weiField{str: tx.L1GasPrice}
+		f.l1GasUsed = weiField{str: tx.L1GasUsed}
+		f.l1FeeScalar = tx.L1FeeScalar
+		f.l1BlobBaseFee = weiField{str: tx.L1BlobBaseFee}
+		f.l1BlobBaseFeeScalar = tx.L1BlobBaseFeeScalar
+		f.isSystemTx = tx.IsSystemTx
+		f.depositReceiptVersion = tx.DepositReceiptVersion
+	}
+	if ext := tx.Arbitrum; ext != nil && !hasFlatArbitrumFields(tx) {
+		f.beneficiary, f.refundTo, f.requestId = ext.Beneficiary, ext.RefundTo, ext.RequestId
+		f.retryData, f.retryTo, f.ticket = ext.RetryData, ext.RetryTo, ext.TicketId
+		f.depositValue = weiField{ext.DepositValue, ext.DepositValueU256}
+		f.l1BaseFee = weiField{ext.L1BaseFee, ext.L1BaseFeeU256}
+		f.maxSubmissionFee = weiField{ext.MaxSubmissionFee, ext.MaxSubmissionFeeU256}
+		f.retryValue = weiField{ext.RetryValue, ext.RetryValueU256}
+		f.maxRefund = weiField{ext.MaxRefund, ext.MaxRefundU256}
+		f.submissionFeeRefund = weiField{ext.SubmissionFeeRefund, ext.SubmissionFeeRefundU256}
+	} else {
+		f.beneficiary, f.refundTo, f.requestId = tx.Beneficiary, tx.RefundTo, tx.RequestId
+		f.retryData, f.retryTo, f.ticket = tx.RetryData, tx.RetryTo, tx.TicketId
+		f.depositValue = weiField{str: tx.DepositValue}
+		f.l1BaseFee = weiField{str: tx.L1BaseFee}
+		f.maxSubmissionFee = weiField{str: tx.MaxSubmissionFee}
+		f.retryValue = weiField{str: tx.RetryValue}
+		f.maxRefund = weiField{str: tx.MaxRefund}
+		f.submissionFeeRefund = weiField{str: tx.SubmissionFeeRefund}
+	}
+	if ext := tx.Celo; ext != nil && !hasFlatCeloFields(tx) {
+		f.feeCurrency, f.gatewayFeeRecipient = ext.FeeCurrency, ext.GatewayFeeRecipient
+		f.gatewayFee = weiField{ext.GatewayFee, ext.GatewayFeeU256}
+	} else {
+		f.feeCurrency, f.gatewayFeeRecipient = tx.FeeCurrency, tx.GatewayFeeRecipient
+		f.gatewayFee = weiField{str: tx.GatewayFee}
+	}
+	return f
+}
+
+// receiptL2Fields is transactionL2Fields for receipts. The zkSync batch fields are set when
+// receiptWithFlatFields would add them to the extensions map.
+type receiptL2Fields struct {
+	l1Fee, l1GasPrice, l1BlobBaseFee          weiField
+	l1GasUsed, depositNonce                   *string
+	depositReceiptVersion                     *string
+	l1FeeScalar                               *float64
+	l1BaseFeeScalar, l1BlobBaseFeeScalar      *uint64
+	gasUsedForL1, l1BlockNumber               *uint64
+	timeboosted                               *bool
+	gatewayFee                                weiField
+	zkSyncL1BatchNumber, zkSyncL1BatchTxIndex *uint64
+}
+
+func flatReceiptL2Fields(r *Receipt) receiptL2Fields {
+	var f receiptL2Fields
+	if ext := r.Optimism; ext != nil && !hasFlatOptimismReceiptFields(r) {
+		f.l1Fee = weiField{ext.L1Fee, ext.L1FeeU256}
+		f.l1GasPrice = weiField{ext.L1GasPrice, ext.L1GasPriceU256}
+		f.l1BlobBaseFee = weiField{ext.L1BlobBaseFee, ext.L1BlobBaseFeeU256}
+		f.l1GasUsed, f.depositNonce, f.depositReceiptVersion = ext.L1GasUsed, ext.DepositNonce, ext.DepositReceiptVersion
+		f.l1FeeScalar, f.l1BaseFeeScalar, f.l1BlobBaseFeeScalar = ext.L1FeeScalar, ext.L1BaseFeeScalar, ext.L1BlobBaseFeeScalar
+	} else {
+		f.l1Fee = weiField{str: r.L1Fee}
+		f.l1GasPrice = weiField{str: r.L1GasPrice}
+		f.l1BlobBaseFee = weiField{str: r.L1BlobBaseFee}
+		f.l1GasUsed, f.depositNonce, f.depositReceiptVersion = r.L1GasUsed, r.DepositNonce, r.DepositReceiptVersion
+		f.l1FeeScalar, f.l1BaseFeeScalar, f.l1BlobBaseFeeScalar = r.L1FeeScalar, r.L1BaseFeeScalar, r.L1BlobBaseFeeScalar
+	}
+	if ext := r.Arbitrum; ext != nil && !hasFlatArbitrumReceiptFields(r) {
+		f.gasUsedForL1, f.l1BlockNumber, f.timeboosted = ext.GasUsedForL1, ext.L1BlockNumber, ext.Timeboosted
+	} else {
+		f.gasUsedForL1, f.l1BlockNumber, f.timeboosted = r.GasUsedForL1, r.L1BlockNumber, r.Timeboosted
+	}
+	if ext := r.Celo; ext != nil && r.GatewayFee == nil {
+		f.gatewayFee = weiField{ext.GatewayFee, ext.GatewayFeeU256}
+	} else {
+		f.gatewayFee = weiField{str: r.GatewayFee}
+	}
+	if ext := r.ZkSync; ext != nil && !hasZkSyncReceiptExtensions(r) {
+		f.zkSyncL1BatchNumber, f.zkSyncL1BatchTxIndex = ext.L1BatchNumber, ext.L1BatchTxIndex
+	}
+	return f
+}
+
+// shallowCopyMessage copies the exported fields of the generated message src into dst. Unlike
+// proto.Clone it shares nested messages and keeps nil list elements, which the encoders emit
+// as null.
+func shallowCopyMessage(dst, src interface{}) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		if sv.Type().Field(i).IsExported() {
+			dv.Field(i).Set(sv.Field(i))
+		}
+	}
+}
+
+func hasFlatOptimismFields(tx *Transaction) bool {
+	return tx.L1Fee != nil || tx.L1GasPrice != nil || tx.L1GasUsed != nil || tx.L1FeeScalar != nil ||
+		tx.L1BlobBaseFee != nil || tx.L1BlobBaseFeeScalar != nil || tx.IsSystemTx != nil || tx.DepositReceiptVersion != nil
+}
+
+func hasFlatArbitrumFields(tx *Transaction) bool {
+	return len(tx.Beneficiary) > 0 || tx.DepositValue != nil || tx.L1BaseFee != nil || tx.MaxSubmissionFee != nil ||
+		len(tx.RefundTo) > 0 || len(tx.RequestId) > 0 || len(tx.RetryData) > 0 || len(tx.RetryTo) > 0 ||
+		tx.RetryValue != nil || tx.MaxRefund != nil || tx.SubmissionFeeRefund != nil || len(tx.TicketId) > 0
+}
+
+func hasFlatCeloFields(tx *Transaction) bool {
+	return len(tx.FeeCurrency) > 0 || tx.GatewayFee != nil || len(tx.GatewayFeeRecipient) > 0
+}
+
+func hasFlatOptimismReceiptFields(r *Receipt) bool {
+	return r.L1Fee != nil || r.L1GasPrice != nil || r.L1GasUsed != nil || r.L1FeeScalar != nil || r.L1BaseFeeScalar != nil ||
+		r.L1BlobBaseFee != nil || r.L1BlobBaseFeeScalar != nil || r.DepositNonce != nil || r.DepositReceiptVersion != nil
+}
+
+func hasFlatArbitrumReceiptFields(r *Receipt) bool {
+	return r.GasUsedForL1 != nil || r.L1BlockNumber != nil || r.Timeboosted != nil
+}
+
+func hasZkSyncReceiptExtensions(r *Receipt) bool {
+	_, batch := r.Extensions["l1BatchNumber"]
+	_, index := r.Extensions["l1BatchTxIndex"]
+	return batch || index
+}
+
+// isBorStateSync reports whether a transaction or receipt belongs to a Bor state-sync
+// transaction, which is sent from and to the zero address.
+func isBorStateSync(from, to []byte) bool {
+	zero := make([]byte, 20)
+	return bytes.Equal(from, zero) && bytes.Equal(to, zero)
+}
+
+// extensionQuantity parses a captured extension holding a QUANTITY string or a JSON number.
+func extensionQuantity(extensions map[string]string, key string) *uint64 {
+	raw, ok := extensions[key]
+	if !ok {
+		return nil
+	}
+	s := raw
+	var str string
+	if json.Unmarshal([]byte(raw), &str) == nil {
+		s = str
+	}
+	n, err := NumberishToUint64(s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func setExtensionQuantity(r *Receipt, key string, v *uint64) {
+	if v == nil {
+		return
+	}
+	if r.Extensions == nil {
+		r.Extensions = make(map[string]string)
+	}
+	r.Extensions[key] = fmt.Sprintf(`"0x%x"`, *v)
+}
+
+// setIfPresent copies *v into a new value at *dst, so flat fields and extensions never share
+// storage; nil leaves *dst untouched.
+func setIfPresent[T any](dst **T, v *T) {
+	if v != nil {
+		c := *v
+		*dst = &c
+	}
+}
+
+func setBytesIfPresent(dst *[]byte, v []byte) {
+	if len(v) > 0 {
+		*dst = append([]byte(nil), v...)
+	}
+}
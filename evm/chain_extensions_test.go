@@ -0,0 +1,188 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestChainExtensionsFromJsonRpc(t *testing.T) {
+	t.Run("OptimismDeposit", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.Type = "0x7e"
+		jt.IsSystemTx = BoolPtr(false)
+		jt.DepositReceiptVersion = "0x1"
+		tx, err := jt.ToProto(WithDialect(DialectOpGeth()))
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if tx.Optimism == nil || tx.Optimism.GetIsSystemTx() || tx.Optimism.GetDepositReceiptVersion() != "0x1" {
+			t.Errorf("Unexpected optimism extension: %v", tx.Optimism)
+		}
+		if tx.DepositReceiptVersion == nil {
+			t.Error("Expected flat fields to be kept")
+		}
+		if tx.Arbitrum != nil || tx.Celo != nil || tx.ZkSync != nil || tx.Polygon != nil {
+			t.Errorf("Expected only the optimism extension, got %v", tx)
+		}
+	})
+
+	t.Run("ArbitrumTypeWithoutFields", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.Type = "0x6a"
+		tx, err := jt.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if tx.Arbitrum == nil {
+			t.Error("Expected an arbitrum extension for an Arbitrum internal transaction")
+		}
+	})
+
+	t.Run("DialectRestrictsEcosystem", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.TicketId = "0x0123"
+		tx, err := jt.ToProto(WithDialect(DialectOpGeth()))
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if tx.Arbitrum != nil {
+			t.Error("Expected no arbitrum extension with the op-geth dialect")
+		}
+		if tx, _ = jt.ToProto(); tx.Arbitrum == nil || !bytes.Equal(tx.Arbitrum.TicketId, []byte{0x01, 0x23}) {
+			t.Errorf("Expected the ticket id to be detected, got %v", tx.Arbitrum)
+		}
+	})
+
+	t.Run("CeloIsOptimismToo", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.Type = "0x7b"
+		jt.FeeCurrency = "0x765de816845861e75a25fca122bb6898b8b1282a"
+		jt.L1Fee = "0x10"
+		tx, err := jt.ToProto(WithDialect(DialectCelo()))
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if tx.Celo == nil || BytesToHex(tx.Celo.FeeCurrency) != jt.FeeCurrency {
+			t.Errorf("Unexpected celo extension: %v", tx.Celo)
+		}
+		if tx.Optimism == nil || tx.Optimism.GetL1Fee() != "0x10" {
+			t.Errorf("Unexpected optimism extension: %v", tx.Optimism)
+		}
+	})
+
+	t.Run("ZkSync", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.Type = "0x71"
+		jt.L1BatchNumber = "0x5"
+		jt.L1BatchTxIndex = "0x2"
+		tx, err := jt.ToProto(WithDialect(DialectZkSync()), WithStrict(true))
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if tx.ZkSync.GetL1BatchNumber() != 5 || tx.ZkSync.GetL1BatchTxIndex() != 2 {
+			t.Errorf("Unexpected zkSync extension: %v", tx.ZkSync)
+		}
+		out := TransactionToJsonRpc(tx)
+		if out["l1BatchNumber"] != "0x5" || out["l1BatchTxIndex"] != "0x2" {
+			t.Errorf("Batch fields not emitted: %v", out)
+		}
+		assertSameAsMap(t, out, AppendTransactionJsonRpc(nil, tx))
+
+		r := dialectTestReceipt()
+		r.Extensions = map[string]string{"l1BatchNumber": `"0x5"`, "l1BatchTxIndex": `2`}
+		receipt, err := r.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert receipt: %v", err)
+		}
+		if receipt.ZkSync.GetL1BatchNumber() != 5 || receipt.ZkSync.GetL1BatchTxIndex() != 2 {
+			t.Errorf("Unexpected zkSync receipt extension: %v", receipt.ZkSync)
+		}
+	})
+
+	t.Run("BorStateSync", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.Type = "0x0"
+		jt.From = "0x0000000000000000000000000000000000000000"
+		jt.To = "0x0000000000000000000000000000000000000000"
+		tx, err := jt.ToProto(WithDialect(DialectBor()))
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if !tx.Polygon.GetStateSync() {
+			t.Errorf("Expected a state-sync transaction, got %v", tx.Polygon)
+		}
+		if tx, _ = jt.ToProto(); tx.Polygon != nil {
+			t.Error("Expected Polygon not to be detected without the bor dialect")
+		}
+
+		receipt := &Receipt{From: tx.From, To: tx.To}
+		PopulateReceiptExtensions(receipt, EcosystemPolygon)
+		if !receipt.Polygon.GetStateSync() {
+			t.Errorf("Expected a state-sync receipt, got %v", receipt.Polygon)
+		}
+		receipt = &Receipt{From: tx.From, To: MustHexToBytes("0x0000000000000000000000000000000000001001")}
+		PopulateReceiptExtensions(receipt, EcosystemPolygon)
+		if receipt.Polygon == nil || receipt.Polygon.StateSync {
+			t.Errorf("Expected a regular Polygon receipt, got %v", receipt.Polygon)
+		}
+	})
+}
+
+func TestChainExtensionsMigration(t *testing.T) {
+	t.Run("Transaction", func(t *testing.T) {
+		flat := fullEncodeTestTransaction()
+		want := TransactionToJsonRpc(flat)
+
+		migrated := fullEncodeTestTransaction()
+		PopulateTransactionExtensions(migrated, EcosystemAuto)
+		if migrated.Optimism == nil || migrated.Arbitrum == nil || migrated.Celo == nil {
+			t.Fatalf("Expected every ecosystem with flat fields to be populated, got %v", migrated)
+		}
+		ClearFlatTransactionFields(migrated)
+		if migrated.L1Fee != nil || migrated.TicketId != nil || migrated.FeeCurrency != nil {
+			t.Fatal("Expected flat fields to be cleared")
+		}
+
+		got, _ := json.Marshal(TransactionToJsonRpc(migrated))
+		wantJson, _ := json.Marshal(want)
+		if !bytes.Equal(got, wantJson) {
+			t.Errorf("Extension-only transaction encodes differently\nwant: %s\ngot:  %s", wantJson, got)
+		}
+		assertSameAsMap(t, want, AppendTransactionJsonRpc(nil, migrated))
+		if migrated.L1Fee != nil {
+			t.Error("Encoding must not modify the transaction")
+		}
+
+		FlattenTransactionExtensions(migrated)
+		migrated.Optimism, migrated.Arbitrum, migrated.Celo = nil, nil, nil
+		if !proto.Equal(migrated, flat) {
+			t.Errorf("Flattening did not restore the flat fields\nwant: %v\ngot:  %v", flat, migrated)
+		}
+	})
+
+	t.Run("Receipt", func(t *testing.T) {
+		flat := fullEncodeTestReceipt()
+		flat.Extensions = map[string]string{"l1BatchNumber": `"0x5"`}
+		want := ReceiptToJsonRpc(flat)
+
+		migrated := fullEncodeTestReceipt()
+		migrated.Extensions = map[string]string{"l1BatchNumber": `"0x5"`}
+		PopulateReceiptExtensions(migrated, EcosystemAuto)
+		if migrated.Optimism == nil || migrated.ZkSync.GetL1BatchNumber() != 5 {
+			t.Fatalf("Unexpected extensions: %v", migrated)
+		}
+		ClearFlatReceiptFields(migrated)
+		if migrated.L1Fee != nil || len(migrated.Extensions) != 0 {
+			t.Fatal("Expected flat fields to be cleared")
+		}
+		assertSameAsMap(t, want, AppendReceiptJsonRpc(nil, migrated))
+		got, _ := json.Marshal(ReceiptToJsonRpc(migrated))
+		wantJson, _ := json.Marshal(want)
+		if !bytes.Equal(got, wantJson) {
+			t.Errorf("Extension-only receipt encodes differently\nwant: %s\ngot:  %s", wantJson, got)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package evm
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Mixed-case address checksums: EIP-55 (https://eips.ethereum.org/EIPS/eip-55) and its
+// chain-aware variant EIP-1191 (https://eips.ethereum.org/EIPS/eip-1191), used by RSK.
+
+// AddressOption configures how addresses are parsed and formatted.
+type AddressOption func(*addressConfig)
+
+// WithChecksum formats addresses with the EIP-55 mixed-case checksum and makes parsing reject
+// mixed-case addresses whose checksum is wrong. All-lowercase and all-uppercase addresses
+// carry no checksum and are still accepted unless WithRequireChecksum is also given.
+func WithChecksum() AddressOption {
+	return func(c *addressConfig) {
+		c.checksum = true
+	}
+}
+
+// WithRequireChecksum makes parsing reject addresses that are not checksummed. It implies
+// WithChecksum.
+func WithRequireChecksum() AddressOption {
+	return func(c *addressConfig) {
+		c.checksum = true
+		c.requireChecksum = true
+	}
+}
+
+// WithChecksumChainId switches the checksum to EIP-1191, which mixes chainId into the hash so
+// that addresses checksummed for one chain fail validation on another. It implies WithChecksum.
+func WithChecksumChainId(chainId uint64) AddressOption {
+	return func(c *addressConfig) {
+		c.checksum = true
+		c.chainId = &chainId
+	}
+}
+
+type addressConfig struct {
+	checksum        bool
+	requireChecksum bool
+	chainId         *uint64
+}
+
+func newAddressConfig(opts []AddressOption) *addressConfig {
+	c := &addressConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ChecksumHex returns the EIP-55 checksummed encoding of the address.
+func (a Address) ChecksumHex() string {
+	return string(checksumHex(a, nil))
+}
+
+// ChecksumHexForChain returns the EIP-1191 checksummed encoding of the address for chainId.
+func (a Address) ChecksumHexForChain(chainId uint64) string {
+	return string(checksumHex(a, &chainId))
+}
+
+// AddressToHex formats an address as lowercase hex, or checksummed when WithChecksum or
+// WithChecksumChainId is given.
+func AddressToHex(a Address, opts ...AddressOption) string {
+	c := newAddressConfig(opts)
+	if !c.checksum {
+		return a.Hex()
+	}
+	return string(checksumHex(a, c.chainId))
+}
+
+// VerifyAddressChecksum checks the casing of a 0x-prefixed hex address against its EIP-55
+// checksum, or its EIP-1191 checksum when WithChecksumChainId is given. Addresses in a single
+// case pass unless WithRequireChecksum is given.
+func VerifyAddressChecksum(s string, opts ...AddressOption) error {
+	a, err := HexToAddress(s)
+	if err != nil {
+		return err
+	}
+	return newAddressConfig(append([]AddressOption{WithChecksum()}, opts...)).verify(a, s)
+}
+
+func (c *addressConfig) verify(a Address, s string) error {
+	if !c.checksum {
+		return nil
+	}
+	digits := RemoveHexPrefix(s)
+	if !c.requireChecksum && !isMixedCase(digits) {
+		return nil
+	}
+	if want := checksumHex(a, c.chainId); digits != string(want[2:]) {
+		return fmt.Errorf("address %s has an invalid checksum, expected %s", s, want)
+	}
+	return nil
+}
+
+// checksumHex uppercases each letter of the lowercase hex address whose nibble in the
+// Keccak-256 hash of the address text is 8 or more. EIP-1191 prefixes the hashed text with the
+// decimal chain id and the 0x prefix.
+func checksumHex(a Address, chainId *uint64) []byte {
+	out := []byte(a.Hex())
+	digits := out[2:]
+	var h []byte
+	if chainId != nil {
+		h = Keccak256([]byte(strconv.FormatUint(*chainId, 10)), out)
+	} else {
+		h = Keccak256(digits)
+	}
+	for i, ch := range digits {
+		nibble := h[i/2] >> 4
+		if i%2 == 1 {
+			nibble = h[i/2] & 0x0f
+		}
+		if ch >= 'a' && nibble >= 8 {
+			digits[i] = ch - 'a' + 'A'
+		}
+	}
+	return out
+}
+
+func isMixedCase(s string) bool {
+	var lower, upper bool
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch >= 'a' && ch <= 'f':
+			lower = true
+		case ch >= 'A' && ch <= 'F':
+			upper = true
+		}
+	}
+	return lower && upper
+}
@@ -0,0 +1,92 @@
+package evm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddressChecksum(t *testing.T) {
+	tests := []struct {
+		name    string
+		chainId *uint64
+		want    []string
+	}{
+		{
+			name: "EIP55",
+			want: []string{
+				"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+				"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+				"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+				"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+			},
+		},
+		{
+			name:    "EIP1191Chain30",
+			chainId: Uint64Ptr(30),
+			want: []string{
+				"0x5aaEB6053f3e94c9b9a09f33669435E7ef1bEAeD",
+				"0xFb6916095cA1Df60bb79ce92cE3EA74c37c5d359",
+				"0xDBF03B407c01E7CD3cBea99509D93F8Dddc8C6FB",
+				"0xD1220A0Cf47c7B9BE7a2e6ba89F429762E7B9adB",
+			},
+		},
+		{
+			name:    "EIP1191Chain31",
+			chainId: Uint64Ptr(31),
+			want: []string{
+				"0x5aAeb6053F3e94c9b9A09F33669435E7EF1BEaEd",
+				"0xFb6916095CA1dF60bb79CE92ce3Ea74C37c5D359",
+				"0xdbF03B407C01E7cd3cbEa99509D93f8dDDc8C6fB",
+				"0xd1220a0CF47c7B9Be7A2E6Ba89f429762E7b9adB",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []AddressOption
+			if tt.chainId != nil {
+				opts = append(opts, WithChecksumChainId(*tt.chainId))
+			}
+			for _, want := range tt.want {
+				a := MustHexToAddress(strings.ToLower(want))
+				got := a.ChecksumHex()
+				if tt.chainId != nil {
+					got = a.ChecksumHexForChain(*tt.chainId)
+				}
+				if got != want {
+					t.Errorf("checksum = %s, want %s", got, want)
+				}
+				if AddressToHex(a, append(opts, WithChecksum())...) != want {
+					t.Errorf("AddressToHex() did not checksum %s", want)
+				}
+				if err := VerifyAddressChecksum(want, opts...); err != nil {
+					t.Errorf("VerifyAddressChecksum(%s) error = %v", want, err)
+				}
+				if _, err := HexToAddress(want, append(opts, WithRequireChecksum())...); err != nil {
+					t.Errorf("HexToAddress(%s) error = %v", want, err)
+				}
+			}
+		})
+	}
+
+	const valid = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	miscased := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"
+	if _, err := HexToAddress(miscased); err != nil {
+		t.Errorf("Expected casing to be ignored without options, got %v", err)
+	}
+	if _, err := HexToAddress(miscased, WithChecksum()); err == nil {
+		t.Error("Expected a mis-cased address to be rejected")
+	}
+	if err := VerifyAddressChecksum(valid, WithChecksumChainId(30)); err == nil {
+		t.Error("Expected an EIP-55 checksum to fail on chain 30")
+	}
+	if _, err := HexToAddress(strings.ToLower(valid), WithChecksum()); err != nil {
+		t.Errorf("Expected an unchecksummed address to be accepted, got %v", err)
+	}
+	if err := VerifyAddressChecksum(strings.ToLower(valid), WithRequireChecksum()); err == nil {
+		t.Error("Expected an unchecksummed address to be rejected with WithRequireChecksum")
+	}
+	if AddressToHex(MustHexToAddress(valid)) != strings.ToLower(valid) {
+		t.Error("Expected lowercase output without options")
+	}
+}
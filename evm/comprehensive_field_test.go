@@ -22,7 +22,7 @@ func TestComprehensiveFieldCoverage(t *testing.T) {
 			"v":                     "0x1b",
 			"chainId":               "0x1",
 			"blockNumber":           "0x1000",
-			"blockHash":             "0xblockhash1234567890abcdef1234567890abcdef1234567890abcdef12345678",
+			"blockHash":             "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
 			"transactionIndex":      "0x5",
 			// Execution result fields
 			"gasUsed":               "0x5000",
@@ -138,7 +138,6 @@ func TestComprehensiveFieldCoverage(t *testing.T) {
 			Miner:            "0x0000000000000000000000000000000000000000",
 			ExtraData:        "0x",
 			RequestsHash:     "0x7685abcdef1234567890abcdef1234567890abcdef1234567890abcdef123456",
-			Transactions:     []interface{}{},
 		}
 
 		protoBlock, err := block.ToProto()
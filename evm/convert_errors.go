@@ -0,0 +1,201 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// ConvertErrorPathDetail is the BaseError detail key holding the path of the invalid field,
+// such as "transactions[17].accessList[2].storageKeys[0]".
+const ConvertErrorPathDetail = "path"
+
+// ConvertErrorPathsDetail is the BaseError detail key holding the paths of every invalid field
+// when errors are collected with WithCollectErrors.
+const ConvertErrorPathsDetail = "paths"
+
+// fieldErrors accumulates the conversion failures of one top-level ToProto call.
+type fieldErrors struct {
+	collect bool
+	list    []*common.BaseError
+}
+
+// fieldParser parses the fields of one JSON-RPC object and records failures under the object's
+// path. Nested objects share the same fieldErrors through child and item.
+type fieldParser struct {
+	path string
+	errs *fieldErrors
+}
+
+func newFieldParser(collect bool) *fieldParser {
+	return &fieldParser{errs: &fieldErrors{collect: collect}}
+}
+
+// field returns the path of the named field of the current object.
+func (p *fieldParser) field(name string) string {
+	if p.path == "" {
+		return name
+	}
+	if name == "" {
+		return p.path
+	}
+	return p.path + "." + name
+}
+
+// child returns a parser for the nested object stored in the named field.
+func (p *fieldParser) child(name string) *fieldParser {
+	return &fieldParser{path: p.field(name), errs: p.errs}
+}
+
+// item returns a parser for element i of the named array field.
+func (p *fieldParser) item(name string, i int) *fieldParser {
+	return &fieldParser{path: p.field(name) + "[" + strconv.Itoa(i) + "]", errs: p.errs}
+}
+
+// fail records an error for the named field ("" for the object itself). Unless errors are
+// collected only the first failure is kept.
+func (p *fieldParser) fail(name string, cause error) {
+	if p.done() {
+		return
+	}
+	path := p.field(name)
+	msg := cause.Error()
+	if path != "" {
+		msg = path + ": " + msg
+	}
+	p.errs.list = append(p.errs.list, common.NewError(common.ErrorCode_INVALID_PARAMETER, msg).
+		WithCause(cause).
+		WithDetail(ConvertErrorPathDetail, path))
+}
+
+// failMismatch records that the named field differs from the value computed from the rest of
+// the message, which is kept under the detail key.
+func (p *fieldParser) failMismatch(name string, cause error, key, computed string) {
+	n := len(p.errs.list)
+	p.fail(name, cause)
+	if len(p.errs.list) > n {
+		p.errs.list[n].WithDetail(key, computed)
+	}
+}
+
+// done reports whether parsing can stop early because a failure was recorded and errors are
+// not being collected.
+func (p *fieldParser) done() bool {
+	return len(p.errs.list) > 0 && !p.errs.collect
+}
+
+// failed reports whether any failure was recorded.
+func (p *fieldParser) failed() bool {
+	return len(p.errs.list) > 0
+}
+
+// err returns nil, the single recorded failure, or a BaseError aggregating every failure.
+func (p *fieldParser) err() error {
+	switch len(p.errs.list) {
+	case 0:
+		return nil
+	case 1:
+		return p.errs.list[0]
+	}
+	paths := make([]string, len(p.errs.list))
+	msgs := make([]string, len(p.errs.list))
+	causes := make([]error, len(p.errs.list))
+	for i, e := range p.errs.list {
+		paths[i] = e.Details[ConvertErrorPathDetail].(string)
+		msgs[i] = e.Message
+		causes[i] = e
+	}
+	return common.NewError(common.ErrorCode_INVALID_PARAMETER, fmt.Sprintf("%d invalid fields: %s", len(msgs), strings.Join(msgs, "; "))).
+		WithCause(errors.Join(causes...)).
+		WithDetail(ConvertErrorPathsDetail, paths)
+}
+
+func (p *fieldParser) uint64(name, s string) uint64 {
+	n, err := NumberishToUint64(s)
+	if err != nil {
+		p.fail(name, err)
+	}
+	return n
+}
+
+func (p *fieldParser) uint32(name, s string) uint32 {
+	n, err := NumberishToUint32(s)
+	if err != nil {
+		p.fail(name, err)
+	}
+	return n
+}
+
+func (p *fieldParser) bytes(name, s string) []byte {
+	b, err := HexToBytes(s)
+	if err != nil {
+		p.fail(name, err)
+	}
+	return b
+}
+
+// optUint64 parses an optional quantity, returning nil when the field is absent.
+func (p *fieldParser) optUint64(name, s string) *uint64 {
+	if s == "" {
+		return nil
+	}
+	n, err := NumberishToUint64(s)
+	if err != nil {
+		p.fail(name, err)
+		return nil
+	}
+	return &n
+}
+
+// optUint32 parses an optional quantity, returning nil when the field is absent.
+func (p *fieldParser) optUint32(name, s string) *uint32 {
+	if s == "" {
+		return nil
+	}
+	n, err := NumberishToUint32(s)
+	if err != nil {
+		p.fail(name, err)
+		return nil
+	}
+	return &n
+}
+
+// optBytes parses optional data, returning nil when the field is absent.
+func (p *fieldParser) optBytes(name, s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return p.bytes(name, s)
+}
+
+// bytesList parses an array of data fields, recording failures as name[i].
+func (p *fieldParser) bytesList(name string, list []string) [][]byte {
+	if list == nil {
+		return nil
+	}
+	out := make([][]byte, 0, len(list))
+	for i, s := range list {
+		b, err := HexToBytes(s)
+		if err != nil {
+			p.fail(name+"["+strconv.Itoa(i)+"]", err)
+			if p.done() {
+				return nil
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// weiList checks an array of wei amounts, recording failures as name[i].
+func (p *fieldParser) weiList(name string, list []string) []string {
+	for i, s := range list {
+		if _, ok := parseWei(s); !ok {
+			p.fail(name+"["+strconv.Itoa(i)+"]", fmt.Errorf("invalid quantity: %s", s))
+		}
+	}
+	return list
+}
@@ -0,0 +1,173 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+const convertErrorsBlockJson = `{
+  "number": "0x121eac0",
+  "hash": "0xzz",
+  "parentHash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+  "timestamp": "0x6553f100",
+  "gasLimit": "0x1c9c380",
+  "gasUsed": "0xe4e1c0",
+  "size": "0x400",
+  "transactions": [
+    {"hash": "0x01", "nonce": "0x0", "gas": "0x5208", "r": "0x01", "s": "0x01"},
+    {
+      "hash": "0x02", "nonce": "0x1", "gas": "0x5208", "r": "0x01", "s": "0x01",
+      "accessList": [{"address": "0x03", "storageKeys": ["0x01", "0xnothex"]}]
+    },
+    {"hash": "0x04", "nonce": "seven", "gas": "0x5208", "r": "0x01", "s": "0x01"}
+  ],
+  "withdrawals": [{"index": "0x1", "validatorIndex": "0x2", "address": "0x05", "amount": "-1"}]
+}`
+
+func convertErrorsTestBlock(t *testing.T) *JsonRpcBlock {
+	t.Helper()
+	var b JsonRpcBlock
+	if err := json.Unmarshal([]byte(convertErrorsBlockJson), &b); err != nil {
+		t.Fatalf("Failed to unmarshal block: %v", err)
+	}
+	return &b
+}
+
+func TestConvertErrorPaths(t *testing.T) {
+	t.Run("FirstError", func(t *testing.T) {
+		b := convertErrorsTestBlock(t)
+		b.Hash = "0xabcd"
+		_, err := b.ToProto()
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+		}
+		if baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+			t.Errorf("Expected INVALID_PARAMETER, got %v", baseErr.Code)
+		}
+		if path := baseErr.Details[ConvertErrorPathDetail]; path != "transactions[1].accessList[0].storageKeys[1]" {
+			t.Errorf("Unexpected path %v (%v)", path, err)
+		}
+	})
+
+	t.Run("CollectAll", func(t *testing.T) {
+		_, err := convertErrorsTestBlock(t).ToProto(WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+		}
+		want := []string{
+			"hash",
+			"transactions[1].accessList[0].storageKeys[1]",
+			"transactions[2].nonce",
+			"withdrawals[0].amount",
+		}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+
+		// The individual field errors stay reachable through the joined cause
+		var joined interface{ Unwrap() []error }
+		if !errors.As(baseErr.Cause, &joined) || len(joined.Unwrap()) != len(want) {
+			t.Errorf("Expected %d wrapped field errors, got %v", len(want), baseErr.Cause)
+		}
+	})
+
+	t.Run("GRPCStatus", func(t *testing.T) {
+		_, err := convertErrorsTestBlock(t).ToProto()
+		st := err.(*common.BaseError).ToGRPCStatus()
+		decoded, ok := common.FromGRPCStatus(st)
+		if !ok || decoded.Details[ConvertErrorPathDetail] != "hash" {
+			t.Errorf("Path not carried through gRPC status: %v", decoded)
+		}
+	})
+
+	t.Run("DialectFields", func(t *testing.T) {
+		b := convertErrorsTestBlock(t)
+		b.Hash = "0xabcd"
+		b.Transactions.Full[1].AccessList = nil
+		b.Transactions.Full[2].Nonce = "0x2"
+		b.Withdrawals = nil
+		b.Transactions.Full[0].RetryTo = "0x06"
+		b.SendCount = "0x1"
+
+		_, err := b.ToProto(WithDialect(DialectGeth()), WithStrict(true), WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+		}
+		want := []string{"sendCount", "transactions[0].retryTo"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+		if _, err := b.ToProto(WithDialect(DialectArbitrum()), WithStrict(true)); err != nil {
+			t.Errorf("Arbitrum dialect rejected block: %v", err)
+		}
+	})
+
+	t.Run("OptionalFields", func(t *testing.T) {
+		jtx := &JsonRpcTransaction{
+			Hash: "0x01", Nonce: "0x0", Gas: "0x5208", R: "0x01", S: "0x01",
+			BlockNumber: "0xzz", BlockHash: "0xzz", TransactionIndex: "-1", BlockTimestamp: "soon",
+			L1BlobBaseFeeScalar: "0xzz", GasUsed: "0xzz", BlobGasUsed: "0xzz",
+			Beneficiary: "0xzz", RefundTo: "0xzz", FeeCurrency: "0xzz", Type: "legacy", L1FeeScalar: "high",
+		}
+		_, err := jtx.ToProto(WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+		}
+		want := []string{
+			"type", "blockNumber", "blockHash", "transactionIndex", "blockTimestamp", "l1FeeScalar",
+			"l1BlobBaseFeeScalar", "feeCurrency", "beneficiary", "refundTo", "gasUsed", "blobGasUsed",
+		}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+
+		r := &JsonRpcReceipt{BlockNumber: "0x1", TransactionIndex: "0x0", GasUsed: "0x1", CumulativeGasUsed: "0x1", BlockTimestamp: "soon"}
+		if _, err := r.ToProto(); !errors.As(err, &baseErr) || baseErr.Details[ConvertErrorPathDetail] != "blockTimestamp" {
+			t.Errorf("Unexpected receipt error: %v", err)
+		}
+	})
+
+	t.Run("BlockTransactions", func(t *testing.T) {
+		transactions := []interface{}{
+			"0x01",
+			"0xzz",
+			map[string]interface{}{"hash": "0x02", "nonce": "seven", "gas": "0x5208", "r": "0x01", "s": "0x01"},
+			map[string]interface{}{"hash": "0x03", "nonce": 7},
+			true,
+		}
+		_, _, err := ParseJsonRpcTransactions(transactions, nil, WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+		}
+		want := []string{"transactions[1]", "transactions[2].nonce", "transactions[3].nonce", "transactions[4]"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+
+		hashes, txs, err := ParseJsonRpcTransactions(transactions[:1], nil)
+		if err != nil || len(hashes) != 1 || len(txs) != 0 {
+			t.Errorf("Expected one hash, got %v, %v, %v", hashes, txs, err)
+		}
+	})
+
+	t.Run("ReceiptLogs", func(t *testing.T) {
+		r := &JsonRpcReceipt{
+			BlockNumber: "0x1", TransactionIndex: "0x0", GasUsed: "0x1", CumulativeGasUsed: "0x1",
+			Logs: []*JsonRpcLog{{BlockNumber: "0x1", LogIndex: "0x0", TransactionIndex: "0x0", Topics: []string{"0xgg"}}},
+		}
+		_, err := r.ToProto()
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) || baseErr.Details[ConvertErrorPathDetail] != "logs[0].topics[0]" {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,318 @@
+package evm
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// L1FeeScalarEncoding describes how a client encodes the l1FeeScalar field of OP-stack
+// transactions and receipts.
+type L1FeeScalarEncoding int
+
+const (
+	// L1FeeScalarAny accepts a decimal float string or a hex quantity and emits a JSON number.
+	L1FeeScalarAny L1FeeScalarEncoding = iota
+	// L1FeeScalarDecimalString accepts and emits a decimal string such as "0.684" (op-geth, pre-Ecotone).
+	L1FeeScalarDecimalString
+	// L1FeeScalarQuantity accepts and emits an integer QUANTITY such as "0x2a".
+	L1FeeScalarQuantity
+)
+
+// BlockNonceEncoding describes how a client encodes the 8-byte block nonce.
+type BlockNonceEncoding int
+
+const (
+	// BlockNoncePadded encodes the nonce as 8-byte DATA ("0x0000000000000000").
+	BlockNoncePadded BlockNonceEncoding = iota
+	// BlockNonceQuantity encodes the nonce as a QUANTITY without leading zeros ("0x0").
+	BlockNonceQuantity
+)
+
+// SignatureEncoding describes which signature parity fields a client emits for typed transactions.
+type SignatureEncoding int
+
+const (
+	// SignatureVAndYParity emits both v and yParity for typed transactions.
+	SignatureVAndYParity SignatureEncoding = iota
+	// SignatureVOnly emits only v; yParity is derived from v when parsing.
+	SignatureVOnly
+)
+
+// Dialect captures the encoding differences of a node client's JSON-RPC output.
+// Converters select parsing and serialization rules from the dialect passed via WithDialect.
+// The Dialect... constructors return a new dialect on every call, so callers can adjust one
+// without affecting other conversions.
+type Dialect struct {
+	Name        string
+	L1FeeScalar L1FeeScalarEncoding
+	BlockNonce  BlockNonceEncoding
+	Signature   SignatureEncoding
+	// Ecosystem selects the chain extensions converters populate; the zero value detects them
+	// from the transaction type and the fields present.
+	Ecosystem Ecosystem
+	// SizeOptional allows blocks without a size field and omits a zero size when serializing.
+	SizeOptional bool
+	// AllowUnknownFields disables the field check performed in strict mode.
+	AllowUnknownFields bool
+	// BlockFields, TransactionFields and ReceiptFields list the JSON fields the client emits in
+	// addition to the standard Ethereum ones.
+	BlockFields       []string
+	TransactionFields []string
+	ReceiptFields     []string
+}
+
+// DialectLenient accepts every known encoding of every field. It is the default.
+func DialectLenient() *Dialect {
+	return &Dialect{
+		Name:               "lenient",
+		AllowUnknownFields: true,
+	}
+}
+
+// DialectGeth is the encoding of geth, which Nethermind and Reth follow for mainnet objects.
+func DialectGeth() *Dialect {
+	return &Dialect{
+		Name: "geth",
+	}
+}
+
+// DialectErigon is the geth encoding without yParity on typed transactions.
+func DialectErigon() *Dialect {
+	return &Dialect{
+		Name:      "erigon",
+		Signature: SignatureVOnly,
+	}
+}
+
+// DialectArbitrum adds the Arbitrum block, transaction and receipt fields of Nitro nodes.
+func DialectArbitrum() *Dialect {
+	return &Dialect{
+		Name:        "arbitrum",
+		Ecosystem:   EcosystemArbitrum,
+		BlockFields: []string{"l1BlockNumber", "sendCount", "sendRoot"},
+		TransactionFields: []string{
+			"requestId", "beneficiary", "depositValue", "l1BaseFee", "maxSubmissionFee", "refundTo",
+			"retryData", "retryTo", "retryValue", "maxRefund", "submissionFeeRefund", "ticketId",
+		},
+		ReceiptFields: []string{"gasUsedForL1", "l1BlockNumber", "timeboosted"},
+	}
+}
+
+// DialectOpGeth adds the OP stack deposit and L1 fee fields, with l1FeeScalar as a decimal string.
+func DialectOpGeth() *Dialect {
+	return &Dialect{
+		Name:        "op-geth",
+		Ecosystem:   EcosystemOptimism,
+		L1FeeScalar: L1FeeScalarDecimalString,
+		TransactionFields: []string{
+			"isSystemTx", "depositReceiptVersion", "sourceHash", "mint",
+			"l1Fee", "l1GasPrice", "l1GasUsed", "l1FeeScalar", "l1BlobBaseFee", "l1BlobBaseFeeScalar",
+		},
+		ReceiptFields: []string{
+			"l1Fee", "l1GasPrice", "l1GasUsed", "l1FeeScalar", "l1BaseFeeScalar",
+			"l1BlobBaseFee", "l1BlobBaseFeeScalar", "depositNonce", "depositReceiptVersion",
+		},
+	}
+}
+
+// DialectCelo is the op-geth dialect plus the Celo fee currency fields, with an optional block size.
+func DialectCelo() *Dialect {
+	return &Dialect{
+		Name:         "celo",
+		Ecosystem:    EcosystemCelo,
+		L1FeeScalar:  L1FeeScalarDecimalString,
+		SizeOptional: true,
+		TransactionFields: []string{
+			"isSystemTx", "depositReceiptVersion", "sourceHash", "mint",
+			"l1Fee", "l1GasPrice", "l1GasUsed", "l1FeeScalar", "l1BlobBaseFee", "l1BlobBaseFeeScalar",
+			"feeCurrency", "gatewayFee", "gatewayFeeRecipient",
+		},
+		ReceiptFields: []string{
+			"l1Fee", "l1GasPrice", "l1GasUsed", "l1FeeScalar", "l1BaseFeeScalar",
+			"l1BlobBaseFee", "l1BlobBaseFeeScalar", "depositNonce", "depositReceiptVersion", "gatewayFee",
+		},
+	}
+}
+
+// DialectZkSync adds the zkSync Era batch fields.
+func DialectZkSync() *Dialect {
+	return &Dialect{
+		Name:              "zksync",
+		Ecosystem:         EcosystemZkSync,
+		BlockFields:       []string{"l1BatchNumber", "l1BatchTimestamp", "sealFields"},
+		TransactionFields: []string{"l1BatchNumber", "l1BatchTxIndex"},
+		ReceiptFields:     []string{"l1BatchNumber", "l1BatchTxIndex", "l2ToL1Logs"},
+	}
+}
+
+// DialectBor adds no fields, as Bor encodes blocks, transactions and receipts like geth.
+// It only enables the polygon extensions, which mark state-sync transactions and receipts.
+func DialectBor() *Dialect {
+	return &Dialect{
+		Name:      "bor",
+		Ecosystem: EcosystemPolygon,
+	}
+}
+
+// standardBlockFields are the block fields emitted by Ethereum mainnet execution clients.
+var standardBlockFields = []string{
+	"baseFeePerGas", "blobGasUsed", "difficulty", "excessBlobGas", "extraData", "gasLimit", "gasUsed",
+	"hash", "logsBloom", "miner", "mixHash", "nonce", "number", "parentBeaconBlockRoot", "parentHash",
+	"receiptsRoot", "requestsHash", "sha3Uncles", "size", "stateRoot", "timestamp", "totalDifficulty",
+	"transactions", "transactionsRoot", "uncles", "withdrawals", "withdrawalsRoot",
+}
+
+// standardTransactionFields are the transaction fields emitted by Ethereum mainnet execution clients.
+var standardTransactionFields = []string{
+	"accessList", "authorizationList", "blobVersionedHashes", "blockHash", "blockNumber", "blockTimestamp",
+	"chainId", "from", "gas", "gasPrice", "hash", "input", "maxFeePerBlobGas", "maxFeePerGas",
+	"maxPriorityFeePerGas", "nonce", "r", "s", "to", "transactionIndex", "type", "v", "value", "yParity",
+}
+
+// standardReceiptFields are the receipt fields emitted by Ethereum mainnet execution clients.
+var standardReceiptFields = []string{
+	"blobGasPrice", "blobGasUsed", "blockHash", "blockNumber", "blockTimestamp", "contractAddress",
+	"cumulativeGasUsed", "effectiveGasPrice", "from", "gasUsed", "logs", "logsBloom", "root", "status",
+	"to", "transactionHash", "transactionIndex", "type",
+}
+
+// ConvertOption configures JSON-RPC conversions.
+type ConvertOption func(*convertConfig)
+
+// WithDialect selects the client dialect used to parse and serialize JSON-RPC objects.
+func WithDialect(d *Dialect) ConvertOption {
+	return func(c *convertConfig) {
+		if d != nil {
+			c.dialect = d
+		}
+	}
+}
+
+// WithStrict makes parsing fail on fields that do not belong to the selected dialect and on
+// values whose encoding does not match it, instead of ignoring them.
+func WithStrict(strict bool) ConvertOption {
+	return func(c *convertConfig) {
+		c.strict = strict
+	}
+}
+
+// WithCollectErrors makes conversions keep going after the first invalid field and return a
+// single error listing every invalid field, instead of stopping at the first one.
+func WithCollectErrors(collect bool) ConvertOption {
+	return func(c *convertConfig) {
+		c.collectErrors = collect
+	}
+}
+
+// defaultDialect is the dialect of conversions without WithDialect. It is never handed out.
+var defaultDialect = DialectLenient()
+
+type convertConfig struct {
+	dialect       *Dialect
+	strict        bool
+	collectErrors bool
+}
+
+func newConvertConfig(opts []ConvertOption) *convertConfig {
+	c := &convertConfig{dialect: defaultDialect}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// checkFields records a failure for every populated field of v (a pointer to a JSON-RPC
+// struct) and every captured extension that is neither a standard field nor listed in the
+// dialect's extra fields.
+func (c *convertConfig) checkFields(p *fieldParser, kind string, v interface{}, extensions map[string]string, standard, extra []string) {
+	if !c.strict || c.dialect.AllowUnknownFields {
+		return
+	}
+	allowed := make(map[string]bool, len(standard)+len(extra))
+	for _, f := range standard {
+		allowed[f] = true
+	}
+	for _, f := range extra {
+		allowed[f] = true
+	}
+	if kind == "transaction" && c.dialect.Signature == SignatureVOnly {
+		delete(allowed, "yParity")
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || allowed[name] || rv.Field(i).IsZero() {
+			continue
+		}
+		p.fail(name, fmt.Errorf("%s field is not part of the %s dialect", kind, c.dialect.Name))
+	}
+	for _, name := range sortedExtensionKeys(extensions) {
+		if !allowed[name] {
+			p.fail(name, fmt.Errorf("%s field is not part of the %s dialect", kind, c.dialect.Name))
+		}
+	}
+}
+
+// parseL1FeeScalar parses l1FeeScalar according to the dialect. ok is false when the value
+// does not match the dialect's encoding.
+func (c *convertConfig) parseL1FeeScalar(s string) (float64, bool) {
+	isQuantity := strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")
+	switch c.dialect.L1FeeScalar {
+	case L1FeeScalarDecimalString:
+		if isQuantity {
+			return 0, false
+		}
+	case L1FeeScalarQuantity:
+		n, err := NumberishToUint64(s)
+		if err != nil {
+			return 0, false
+		}
+		return float64(n), true
+	}
+	if isQuantity {
+		n, err := NumberishToUint64(s)
+		if err != nil {
+			return 0, false
+		}
+		return float64(n), true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// formatL1FeeScalar returns the dialect's JSON representation of l1FeeScalar.
+func (c *convertConfig) formatL1FeeScalar(f float64) interface{} {
+	switch c.dialect.L1FeeScalar {
+	case L1FeeScalarDecimalString:
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	case L1FeeScalarQuantity:
+		return fmt.Sprintf("0x%x", uint64(f))
+	}
+	return f
+}
+
+// formatBlockNonce returns the dialect's JSON representation of the block nonce.
+func (c *convertConfig) formatBlockNonce(n uint64) string {
+	if c.dialect.BlockNonce == BlockNonceQuantity {
+		return fmt.Sprintf("0x%x", n)
+	}
+	return fmt.Sprintf("0x%016x", n)
+}
+
+// checkBlockNonce verifies in strict mode that a padded-nonce dialect sent 8 bytes.
+func (c *convertConfig) checkBlockNonce(s string) error {
+	if !c.strict || c.dialect.BlockNonce != BlockNoncePadded {
+		return nil
+	}
+	if len(RemoveHexPrefix(s)) != 16 {
+		return fmt.Errorf("%s is not 8-byte padded as the %s dialect requires", s, c.dialect.Name)
+	}
+	return nil
+}
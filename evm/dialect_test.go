@@ -0,0 +1,198 @@
+package evm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const dialectTestBlockJson = `{
+  "number": "0x121eac0",
+  "hash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+  "parentHash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+  "timestamp": "0x6553f100",
+  "gasLimit": "0x1c9c380",
+  "gasUsed": "0xe4e1c0",
+  "nonce": "0x0000000000000042",
+  "size": "0x400",
+  "transactions": []
+}`
+
+func dialectTestReceipt() *JsonRpcReceipt {
+	return &JsonRpcReceipt{
+		TransactionHash:   "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		BlockNumber:       "0x121eac0",
+		TransactionIndex:  "0x0",
+		GasUsed:           "0x5208",
+		CumulativeGasUsed: "0x5208",
+		Status:            "0x1",
+	}
+}
+
+func dialectTestTransaction() *JsonRpcTransaction {
+	return &JsonRpcTransaction{
+		Hash:  "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		Nonce: "0x0",
+		Gas:   "0x5208",
+		Type:  "0x2",
+		V:     "0x1",
+		R:     "0x01",
+		S:     "0x02",
+	}
+}
+
+func TestDialectL1FeeScalar(t *testing.T) {
+	t.Run("OpGethDecimalString", func(t *testing.T) {
+		r := dialectTestReceipt()
+		r.L1FeeScalar = "0.684"
+		receipt, err := r.ToProto(WithDialect(DialectOpGeth()), WithStrict(true))
+		if err != nil {
+			t.Fatalf("Failed to convert receipt: %v", err)
+		}
+		if receipt.GetL1FeeScalar() != 0.684 {
+			t.Errorf("Expected l1FeeScalar 0.684, got %v", receipt.GetL1FeeScalar())
+		}
+		if out := ReceiptToJsonRpc(receipt, WithDialect(DialectOpGeth())); out["l1FeeScalar"] != "0.684" {
+			t.Errorf("Expected decimal string l1FeeScalar, got %#v", out["l1FeeScalar"])
+		}
+	})
+
+	t.Run("OpGethRejectsQuantity", func(t *testing.T) {
+		r := dialectTestReceipt()
+		r.L1FeeScalar = "0x2a"
+		if _, err := r.ToProto(WithDialect(DialectOpGeth())); err == nil {
+			t.Error("Expected error for hex l1FeeScalar under op-geth dialect")
+		}
+	})
+
+	t.Run("LenientAcceptsBoth", func(t *testing.T) {
+		for _, s := range []string{"0.684", "0x2a"} {
+			r := dialectTestReceipt()
+			r.L1FeeScalar = s
+			if _, err := r.ToProto(); err != nil {
+				t.Errorf("Lenient dialect rejected l1FeeScalar %q: %v", s, err)
+			}
+		}
+	})
+}
+
+func TestDialectSignature(t *testing.T) {
+	tx := dialectTestTransaction()
+	converted, err := tx.ToProto(WithDialect(DialectErigon()), WithStrict(true))
+	if err != nil {
+		t.Fatalf("Failed to convert transaction: %v", err)
+	}
+	if converted.YParity == nil || *converted.YParity != 1 {
+		t.Fatalf("Expected yParity derived from v, got %v", converted.YParity)
+	}
+
+	if _, ok := TransactionToJsonRpc(converted, WithDialect(DialectErigon()))["yParity"]; ok {
+		t.Error("Erigon dialect should not emit yParity")
+	}
+	if _, ok := TransactionToJsonRpc(converted, WithDialect(DialectGeth()))["yParity"]; !ok {
+		t.Error("Geth dialect should emit yParity")
+	}
+
+	tx.YParity = "0x1"
+	if _, err := tx.ToProto(WithDialect(DialectErigon()), WithStrict(true)); err == nil {
+		t.Error("Expected strict Erigon dialect to reject yParity")
+	}
+}
+
+func TestDialectStrictFields(t *testing.T) {
+	r := dialectTestReceipt()
+	r.GasUsedForL1 = "0x64"
+	if _, err := r.ToProto(WithDialect(DialectGeth()), WithStrict(true)); err == nil {
+		t.Error("Expected strict geth dialect to reject gasUsedForL1")
+	}
+	if _, err := r.ToProto(WithDialect(DialectArbitrum()), WithStrict(true)); err != nil {
+		t.Errorf("Arbitrum dialect rejected gasUsedForL1: %v", err)
+	}
+	if _, err := r.ToProto(WithDialect(DialectGeth())); err != nil {
+		t.Errorf("Non-strict geth dialect rejected gasUsedForL1: %v", err)
+	}
+
+	custom := DialectGeth()
+	custom.ReceiptFields = append(custom.ReceiptFields, "gasUsedForL1")
+	if _, err := r.ToProto(WithDialect(custom), WithStrict(true)); err != nil {
+		t.Errorf("Extended geth dialect rejected gasUsedForL1: %v", err)
+	}
+	if _, err := r.ToProto(WithDialect(DialectGeth()), WithStrict(true)); err == nil {
+		t.Error("Extending a dialect must not change DialectGeth")
+	}
+}
+
+func TestDialectBlock(t *testing.T) {
+	parse := func(t *testing.T, mutate func(b *JsonRpcBlock), opts ...ConvertOption) (*Block, error) {
+		t.Helper()
+		var b JsonRpcBlock
+		if err := json.Unmarshal([]byte(dialectTestBlockJson), &b); err != nil {
+			t.Fatalf("Failed to unmarshal block: %v", err)
+		}
+		if mutate != nil {
+			mutate(&b)
+		}
+		return b.ToProto(opts...)
+	}
+
+	t.Run("MissingSize", func(t *testing.T) {
+		noSize := func(b *JsonRpcBlock) { b.Size = "" }
+		if _, err := parse(t, noSize, WithDialect(DialectGeth()), WithStrict(true)); err == nil {
+			t.Error("Expected strict geth dialect to require size")
+		}
+		if _, err := parse(t, noSize, WithDialect(DialectCelo()), WithStrict(true)); err != nil {
+			t.Errorf("Celo dialect rejected block without size: %v", err)
+		}
+	})
+
+	t.Run("UnpaddedNonce", func(t *testing.T) {
+		short := func(b *JsonRpcBlock) { b.Nonce = "0x42" }
+		if _, err := parse(t, short, WithDialect(DialectGeth()), WithStrict(true)); err == nil {
+			t.Error("Expected strict geth dialect to reject unpadded nonce")
+		}
+		block, err := parse(t, short)
+		if err != nil {
+			t.Fatalf("Lenient dialect rejected unpadded nonce: %v", err)
+		}
+		if block.Header.GetNonce() != 0x42 {
+			t.Errorf("Expected nonce 0x42, got %d", block.Header.GetNonce())
+		}
+	})
+
+	t.Run("Serialize", func(t *testing.T) {
+		header := &BlockHeader{Nonce: Uint64Ptr(0x42)}
+		if out := BlockToJsonRpc(header, nil, nil, nil); out["nonce"] != "0x0000000000000042" {
+			t.Errorf("Expected padded nonce, got %v", out["nonce"])
+		}
+		quantity := &Dialect{Name: "quantity-nonce", BlockNonce: BlockNonceQuantity, SizeOptional: true}
+		out := BlockToJsonRpc(header, nil, nil, nil, WithDialect(quantity))
+		if out["nonce"] != "0x42" {
+			t.Errorf("Expected quantity nonce, got %v", out["nonce"])
+		}
+		if _, ok := out["size"]; ok {
+			t.Error("Expected zero size to be omitted for a size-optional dialect")
+		}
+	})
+}
+
+func TestDialectEncodersMatchMapOutput(t *testing.T) {
+	dialects := []*Dialect{
+		DialectLenient(), DialectGeth(), DialectErigon(), DialectOpGeth(), DialectCelo(),
+		{Name: "quantity", L1FeeScalar: L1FeeScalarQuantity, BlockNonce: BlockNonceQuantity},
+	}
+	for _, d := range dialects {
+		t.Run(d.Name, func(t *testing.T) {
+			opt := WithDialect(d)
+			tx := fullEncodeTestTransaction()
+			assertSameAsMap(t, TransactionToJsonRpc(tx, opt), AppendTransactionJsonRpc(nil, tx, opt))
+
+			r := fullEncodeTestReceipt()
+			assertSameAsMap(t, ReceiptsToJsonRpc([]*Receipt{r}, opt), AppendReceiptsJsonRpc(nil, []*Receipt{r}, opt))
+
+			header := fullEncodeTestHeader()
+			txs := []*Transaction{tx}
+			assertSameAsMap(t, BlockToJsonRpc(header, nil, txs, nil, opt), AppendBlockJsonRpc(nil, header, nil, txs, nil, opt))
+			empty := &BlockHeader{}
+			assertSameAsMap(t, BlockToJsonRpc(empty, nil, nil, nil, opt), AppendBlockJsonRpc(nil, empty, nil, nil, nil, opt))
+		})
+	}
+}
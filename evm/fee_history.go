@@ -0,0 +1,277 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// FeeParams are the EIP-1559 and EIP-4844 parameters of a chain, used to derive base fees and
+// blob gas ratios when computing a fee history from stored blocks.
+type FeeParams struct {
+	// ElasticityMultiplier is the ratio of the gas limit to the gas target
+	ElasticityMultiplier uint64
+	// BaseFeeChangeDenominator bounds the base fee change between blocks to 1/denominator
+	BaseFeeChangeDenominator uint64
+	// TargetBlobGasPerBlock is the blob gas target used to update excessBlobGas
+	TargetBlobGasPerBlock uint64
+	// MaxBlobGasPerBlock is the blob gas limit used for blobGasUsedRatio
+	MaxBlobGasPerBlock uint64
+	// BlobBaseFeeUpdateFraction controls how fast the blob base fee reacts to excessBlobGas
+	BlobBaseFeeUpdateFraction uint64
+}
+
+var (
+	// CancunFeeParams are the Ethereum mainnet fee parameters from the Cancun upgrade (3 target, 6 max blobs).
+	CancunFeeParams = &FeeParams{
+		ElasticityMultiplier:      2,
+		BaseFeeChangeDenominator:  8,
+		TargetBlobGasPerBlock:     393216,
+		MaxBlobGasPerBlock:        786432,
+		BlobBaseFeeUpdateFraction: 3338477,
+	}
+
+	// PragueFeeParams are the Ethereum mainnet fee parameters from the Prague upgrade (6 target, 9 max blobs).
+	PragueFeeParams = &FeeParams{
+		ElasticityMultiplier:      2,
+		BaseFeeChangeDenominator:  8,
+		TargetBlobGasPerBlock:     786432,
+		MaxBlobGasPerBlock:        1179648,
+		BlobBaseFeeUpdateFraction: 5007716,
+	}
+)
+
+// ComputeFeeHistory derives an eth_feeHistory result from stored blocks, so it can be served
+// without proxying to a node. headers must be consecutive blocks in ascending order, and when
+// rewardPercentiles is not empty transactions[i] must hold every transaction of headers[i] with
+// gasUsed set, since rewards are weighted by the gas each transaction used. A nil params uses
+// PragueFeeParams.
+func ComputeFeeHistory(headers []*BlockHeader, transactions [][]*Transaction, rewardPercentiles []float64, params *FeeParams) (*FeeHistory, error) {
+	if len(headers) == 0 {
+		return nil, errors.New("at least one block header is required")
+	}
+	for i, h := range headers {
+		if h == nil {
+			return nil, fmt.Errorf("headers[%d] is nil", i)
+		}
+	}
+	if params == nil {
+		params = PragueFeeParams
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("reward percentile %v is not between 0 and 100", p)
+		}
+		if i > 0 && p <= rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("reward percentiles must be ascending, got %v after %v", p, rewardPercentiles[i-1])
+		}
+	}
+	if len(rewardPercentiles) > 0 && len(transactions) != len(headers) {
+		return nil, fmt.Errorf("expected transactions for %d blocks, got %d", len(headers), len(transactions))
+	}
+
+	history := &FeeHistory{OldestBlock: headers[0].Number}
+	for i, h := range headers {
+		if i > 0 {
+			prev := headers[i-1]
+			if h.Number != prev.Number+1 {
+				return nil, fmt.Errorf("headers[%d] is block %d, expected %d", i, h.Number, prev.Number+1)
+			}
+			if len(h.ParentHash) > 0 && len(prev.Hash) > 0 && !bytes.Equal(h.ParentHash, prev.Hash) {
+				return nil, fmt.Errorf("headers[%d] does not extend block %d", i, prev.Number)
+			}
+		}
+
+		baseFee, err := headerBaseFee(h)
+		if err != nil {
+			return nil, fmt.Errorf("headers[%d]: %w", i, err)
+		}
+		history.BaseFeePerGas = append(history.BaseFeePerGas, bigToQuantityHex(baseFee))
+		history.GasUsedRatio = append(history.GasUsedRatio, ratio(h.GasUsed, h.GasLimit))
+		history.BaseFeePerBlobGas = append(history.BaseFeePerBlobGas, bigToQuantityHex(blobBaseFee(h.ExcessBlobGas, params)))
+		history.BlobGasUsedRatio = append(history.BlobGasUsedRatio, ratio(h.GetBlobGasUsed(), params.MaxBlobGasPerBlock))
+
+		if len(rewardPercentiles) > 0 {
+			reward, err := blockRewards(h, baseFee, transactions[i], rewardPercentiles)
+			if err != nil {
+				return nil, fmt.Errorf("transactions[%d]%w", i, err)
+			}
+			history.Reward = append(history.Reward, reward)
+		}
+	}
+
+	last := headers[len(headers)-1]
+	nextBaseFee, err := NextBaseFee(last, params)
+	if err != nil {
+		return nil, err
+	}
+	history.BaseFeePerGas = append(history.BaseFeePerGas, bigToQuantityHex(nextBaseFee))
+	history.BaseFeePerBlobGas = append(history.BaseFeePerBlobGas, bigToQuantityHex(blobBaseFee(nextExcessBlobGas(last, params), params)))
+	return history, nil
+}
+
+// NextBaseFee returns the EIP-1559 base fee of the block following parent. It returns zero when
+// the parent has no base fee. A nil params uses PragueFeeParams.
+func NextBaseFee(parent *BlockHeader, params *FeeParams) (*big.Int, error) {
+	parentBaseFee, err := headerBaseFee(parent)
+	if err != nil || parentBaseFee == nil {
+		return new(big.Int), err
+	}
+	if params == nil {
+		params = PragueFeeParams
+	}
+	target := parent.GasLimit / params.ElasticityMultiplier
+	if target == 0 || parent.GasUsed == target {
+		return parentBaseFee, nil
+	}
+	delta := new(big.Int)
+	if parent.GasUsed > target {
+		delta.SetUint64(parent.GasUsed - target)
+	} else {
+		delta.SetUint64(target - parent.GasUsed)
+	}
+	delta.Mul(delta, parentBaseFee)
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, new(big.Int).SetUint64(params.BaseFeeChangeDenominator))
+	if parent.GasUsed > target {
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+		return delta.Add(parentBaseFee, delta), nil
+	}
+	return delta.Sub(parentBaseFee, delta), nil
+}
+
+// blockRewards samples the effective priority fees of a block at the given percentiles of its
+// gas used, the way geth does: transactions are sorted by reward and the reward of the
+// transaction covering each percentile's share of the block's gas is taken.
+func blockRewards(h *BlockHeader, baseFee *big.Int, txs []*Transaction, percentiles []float64) (*FeeHistoryReward, error) {
+	reward := &FeeHistoryReward{Values: make([]string, len(percentiles))}
+	if len(txs) == 0 {
+		for i := range reward.Values {
+			reward.Values[i] = "0x0"
+		}
+		return reward, nil
+	}
+
+	type gasAndReward struct {
+		gasUsed uint64
+		reward  *big.Int
+	}
+	sorted := make([]gasAndReward, len(txs))
+	for j, tx := range txs {
+		if tx == nil || tx.GasUsed == nil {
+			return nil, fmt.Errorf("[%d]: gasUsed is required to weight rewards", j)
+		}
+		tip, err := effectiveGasTip(tx, baseFee)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", j, err)
+		}
+		sorted[j] = gasAndReward{gasUsed: *tx.GasUsed, reward: tip}
+	}
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].reward.Cmp(sorted[b].reward) < 0
+	})
+
+	txIndex := 0
+	sumGasUsed := sorted[0].gasUsed
+	for i, p := range percentiles {
+		threshold := uint64(float64(h.GasUsed) * p / 100)
+		for sumGasUsed < threshold && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].gasUsed
+		}
+		reward.Values[i] = bigToQuantityHex(sorted[txIndex].reward)
+	}
+	return reward, nil
+}
+
+// effectiveGasTip returns the priority fee per gas a transaction paid on top of baseFee:
+// min(maxPriorityFeePerGas, maxFeePerGas-baseFee) for EIP-1559 style transactions and
+// gasPrice-baseFee for legacy ones.
+func effectiveGasTip(tx *Transaction, baseFee *big.Int) (*big.Int, error) {
+	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+		feeCap, ok := parseWei(*tx.MaxFeePerGas)
+		if !ok {
+			return nil, fmt.Errorf("invalid maxFeePerGas %q", *tx.MaxFeePerGas)
+		}
+		tipCap, ok := parseWei(*tx.MaxPriorityFeePerGas)
+		if !ok {
+			return nil, fmt.Errorf("invalid maxPriorityFeePerGas %q", *tx.MaxPriorityFeePerGas)
+		}
+		if baseFee == nil {
+			return tipCap, nil
+		}
+		if headroom := new(big.Int).Sub(feeCap, baseFee); headroom.Cmp(tipCap) < 0 {
+			return headroom, nil
+		}
+		return tipCap, nil
+	}
+	gasPrice, ok := parseWei(tx.GetGasPrice())
+	if !ok {
+		return nil, fmt.Errorf("invalid gasPrice %q", tx.GetGasPrice())
+	}
+	if baseFee == nil {
+		return gasPrice, nil
+	}
+	return gasPrice.Sub(gasPrice, baseFee), nil
+}
+
+// headerBaseFee returns the header's base fee, or nil before EIP-1559.
+func headerBaseFee(h *BlockHeader) (*big.Int, error) {
+	if h.BaseFeePerGas == nil || *h.BaseFeePerGas == "" {
+		return nil, nil
+	}
+	baseFee, ok := parseWei(*h.BaseFeePerGas)
+	if !ok {
+		return nil, fmt.Errorf("invalid baseFeePerGas %q", *h.BaseFeePerGas)
+	}
+	return baseFee, nil
+}
+
+// nextExcessBlobGas returns the EIP-4844 excessBlobGas of the block following parent, or nil
+// when the parent predates EIP-4844.
+func nextExcessBlobGas(parent *BlockHeader, params *FeeParams) *uint64 {
+	if parent.ExcessBlobGas == nil {
+		return nil
+	}
+	total := parent.GetExcessBlobGas() + parent.GetBlobGasUsed()
+	if total < params.TargetBlobGasPerBlock {
+		return Uint64Ptr(0)
+	}
+	return Uint64Ptr(total - params.TargetBlobGasPerBlock)
+}
+
+// blobBaseFee returns the EIP-4844 blob base fee for excessBlobGas, or zero when it is nil.
+func blobBaseFee(excessBlobGas *uint64, params *FeeParams) *big.Int {
+	if excessBlobGas == nil || params.BlobBaseFeeUpdateFraction == 0 {
+		return new(big.Int)
+	}
+	// fake_exponential(MIN_BASE_FEE_PER_BLOB_GAS, excessBlobGas, BLOB_BASE_FEE_UPDATE_FRACTION)
+	denominator := new(big.Int).SetUint64(params.BlobBaseFeeUpdateFraction)
+	numerator := new(big.Int).SetUint64(*excessBlobGas)
+	output := new(big.Int)
+	accum := new(big.Int).Set(denominator)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, new(big.Int).Mul(denominator, big.NewInt(i)))
+	}
+	return output.Div(output, denominator)
+}
+
+func ratio(used, limit uint64) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return float64(used) / float64(limit)
+}
+
+func bigToQuantityHex(v *big.Int) string {
+	if v == nil || v.Sign() <= 0 {
+		return "0x0"
+	}
+	return AddHexPrefix(v.Text(16))
+}
@@ -0,0 +1,175 @@
+package evm
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		gasUsed uint64
+		want    int64
+	}{
+		{"Full", 30_000_000, 112_500_000_000},
+		{"Empty", 0, 87_500_000_000},
+		{"AtTarget", 15_000_000, 100_000_000_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &BlockHeader{GasLimit: 30_000_000, GasUsed: tt.gasUsed, BaseFeePerGas: StringPtr("0x174876e800")}
+			got, err := NextBaseFee(parent, nil)
+			if err != nil {
+				t.Fatalf("NextBaseFee() error = %v", err)
+			}
+			if got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("NextBaseFee() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("PreLondon", func(t *testing.T) {
+		got, err := NextBaseFee(&BlockHeader{GasLimit: 30_000_000}, nil)
+		if err != nil || got.Sign() != 0 {
+			t.Errorf("NextBaseFee() = %v, %v, want 0", got, err)
+		}
+	})
+}
+
+func TestBlobBaseFee(t *testing.T) {
+	if got := blobBaseFee(Uint64Ptr(0), PragueFeeParams); got.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("blobBaseFee(0) = %s, want 1", got)
+	}
+	// fake_exponential(1, 2, 1) from the EIP-4844 reference tests
+	if got := blobBaseFee(Uint64Ptr(2), &FeeParams{BlobBaseFeeUpdateFraction: 1}); got.Cmp(big.NewInt(6)) != 0 {
+		t.Errorf("blobBaseFee(2) = %s, want 6", got)
+	}
+	if got := blobBaseFee(nil, PragueFeeParams); got.Sign() != 0 {
+		t.Errorf("blobBaseFee(nil) = %s, want 0", got)
+	}
+}
+
+func feeHistoryTestBlocks() ([]*BlockHeader, [][]*Transaction) {
+	first := &BlockHeader{
+		Number:        100,
+		Hash:          MustHexToBytes("0x0000000000000000000000000000000000000000000000000000000000000064"),
+		GasLimit:      1000,
+		GasUsed:       600,
+		BaseFeePerGas: StringPtr("0x64"),
+	}
+	second := &BlockHeader{
+		Number:        101,
+		ParentHash:    first.Hash,
+		GasLimit:      1000,
+		GasUsed:       0,
+		BaseFeePerGas: StringPtr("0x66"),
+		BlobGasUsed:   Uint64Ptr(PragueFeeParams.MaxBlobGasPerBlock),
+		ExcessBlobGas: Uint64Ptr(0),
+	}
+	txs := [][]*Transaction{
+		{
+			{GasPrice: StringPtr("0x69"), GasUsed: Uint64Ptr(300)},
+			{MaxFeePerGas: StringPtr("0xc8"), MaxPriorityFeePerGas: StringPtr("0x2"), GasUsed: Uint64Ptr(300)},
+		},
+		nil,
+	}
+	return []*BlockHeader{first, second}, txs
+}
+
+func TestComputeFeeHistory(t *testing.T) {
+	headers, txs := feeHistoryTestBlocks()
+
+	t.Run("WithRewards", func(t *testing.T) {
+		history, err := ComputeFeeHistory(headers, txs, []float64{25, 75}, nil)
+		if err != nil {
+			t.Fatalf("ComputeFeeHistory() error = %v", err)
+		}
+		if history.OldestBlock != 100 {
+			t.Errorf("OldestBlock = %d, want 100", history.OldestBlock)
+		}
+		if want := []string{"0x64", "0x66", "0x5a"}; !reflect.DeepEqual(history.BaseFeePerGas, want) {
+			t.Errorf("BaseFeePerGas = %v, want %v", history.BaseFeePerGas, want)
+		}
+		if want := []float64{0.6, 0}; !reflect.DeepEqual(history.GasUsedRatio, want) {
+			t.Errorf("GasUsedRatio = %v, want %v", history.GasUsedRatio, want)
+		}
+		if len(history.Reward) != 2 {
+			t.Fatalf("Expected 2 reward entries, got %d", len(history.Reward))
+		}
+		if want := []string{"0x2", "0x5"}; !reflect.DeepEqual(history.Reward[0].Values, want) {
+			t.Errorf("Reward[0] = %v, want %v", history.Reward[0].Values, want)
+		}
+		if want := []string{"0x0", "0x0"}; !reflect.DeepEqual(history.Reward[1].Values, want) {
+			t.Errorf("Reward[1] = %v, want %v", history.Reward[1].Values, want)
+		}
+		if want := []string{"0x0", "0x1", "0x1"}; !reflect.DeepEqual(history.BaseFeePerBlobGas, want) {
+			t.Errorf("BaseFeePerBlobGas = %v, want %v", history.BaseFeePerBlobGas, want)
+		}
+		if want := []float64{0, 1}; !reflect.DeepEqual(history.BlobGasUsedRatio, want) {
+			t.Errorf("BlobGasUsedRatio = %v, want %v", history.BlobGasUsedRatio, want)
+		}
+	})
+
+	t.Run("WithoutRewards", func(t *testing.T) {
+		history, err := ComputeFeeHistory(headers, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("ComputeFeeHistory() error = %v", err)
+		}
+		if len(history.Reward) != 0 {
+			t.Errorf("Expected no rewards, got %v", history.Reward)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		missingGasUsed := [][]*Transaction{{{GasPrice: StringPtr("0x69")}}, nil}
+		if _, err := ComputeFeeHistory(headers, missingGasUsed, []float64{50}, nil); err == nil || !strings.Contains(err.Error(), "transactions[0][0]") {
+			t.Errorf("Expected transactions[0][0] error, got %v", err)
+		}
+		if _, err := ComputeFeeHistory(headers, txs, []float64{75, 25}, nil); err == nil {
+			t.Error("Expected descending percentiles to fail")
+		}
+		if _, err := ComputeFeeHistory([]*BlockHeader{headers[1], headers[0]}, nil, nil, nil); err == nil {
+			t.Error("Expected non-consecutive headers to fail")
+		}
+		if _, err := ComputeFeeHistory([]*BlockHeader{nil, headers[1]}, nil, nil, nil); err == nil || !strings.Contains(err.Error(), "headers[0] is nil") {
+			t.Errorf("Expected headers[0] error, got %v", err)
+		}
+	})
+}
+
+func TestJsonRpcFeeHistory(t *testing.T) {
+	raw := `{"oldestBlock":"0x1312d00","baseFeePerGas":["0x3b9aca00","0x3b9aca08","0x3a699d00"],"gasUsedRatio":[0.5000123,0.43],"reward":[["0x5f5e100","0x77359400"],["0x0","0x3b9aca00"]],"baseFeePerBlobGas":["0x1","0x1","0x1"],"blobGasUsedRatio":[0.5,0]}`
+	var jf JsonRpcFeeHistory
+	if err := json.Unmarshal([]byte(raw), &jf); err != nil {
+		t.Fatalf("Failed to unmarshal fee history: %v", err)
+	}
+	history, err := jf.ToProto()
+	if err != nil {
+		t.Fatalf("Failed to convert fee history: %v", err)
+	}
+	if history.OldestBlock != 20_000_000 || len(history.Reward) != 2 || history.Reward[1].Values[1] != "0x3b9aca00" {
+		t.Errorf("Unexpected fee history: %v", history)
+	}
+
+	out, err := json.Marshal(FeeHistoryToJsonRpc(history))
+	if err != nil {
+		t.Fatalf("Failed to marshal fee history: %v", err)
+	}
+	var want, got map[string]interface{}
+	json.Unmarshal([]byte(raw), &want)
+	json.Unmarshal(out, &got)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Round trip mismatch:\nwant %s\ngot  %s", raw, out)
+	}
+	if out := FeeHistoryToJsonRpc(nil); out != nil {
+		t.Errorf("Expected nil for a nil fee history, got %v", out)
+	}
+
+	jf.Reward[0][1] = "0xzz"
+	if _, err := jf.ToProto(); err == nil || !strings.Contains(err.Error(), "reward[0][1]") {
+		t.Errorf("Expected reward[0][1] error, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// EmptyUnclesHash is the sha3Uncles of a block without uncles (Keccak-256 of the empty RLP list),
+// which every post-merge block has.
+var EmptyUnclesHash = MustHexToBytes("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")
+
+// The block header is an RLP list whose length depends on the fork that produced it. Each fork
+// appends fields to the previous layout:
+//
+//	Frontier:  parentHash … extraData, mixHash, nonce (15 fields)
+//	London:    + baseFeePerGas
+//	Shanghai:  + withdrawalsRoot
+//	Cancun:    + blobGasUsed, excessBlobGas, parentBeaconBlockRoot
+//	Prague:    + requestsHash
+//
+// The layout is chosen from the optional fields that are set, so a header must carry every field
+// of its fork and of the forks before it. Chains with their own header formats (e.g. Celo before
+// its L2 migration) hash differently and cannot be verified with these functions.
+
+// EncodeBlockHeaderRlp returns the canonical RLP encoding of h, the bytes hashed into the block
+// hash. The 256-bit quantities are read from their U256 fields when set and from the string
+// fields otherwise. Errors are common.BaseError values with code INVALID_PARAMETER listing every
+// invalid field under the paths detail.
+func EncodeBlockHeaderRlp(h *BlockHeader) ([]byte, error) {
+	p := newFieldParser(true)
+	enc := appendBlockHeaderRlp(p, nil, h)
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+func appendBlockHeaderRlp(p *fieldParser, dst []byte, h *BlockHeader) []byte {
+	if h == nil {
+		p.fail("", errors.New("block header is nil"))
+		return dst
+	}
+	var payload []byte
+	payload = appendRlpFixed(p, payload, "parentHash", h.ParentHash, 32)
+	payload = appendRlpFixed(p, payload, "sha3Uncles", h.Sha3Uncles, 32)
+	payload = appendRlpFixed(p, payload, "miner", h.Miner, AddressLength)
+	payload = appendRlpFixed(p, payload, "stateRoot", h.StateRoot, 32)
+	payload = appendRlpFixed(p, payload, "transactionsRoot", h.TransactionsRoot, 32)
+	payload = appendRlpFixed(p, payload, "receiptsRoot", h.ReceiptsRoot, 32)
+	payload = appendRlpFixed(p, payload, "logsBloom", h.LogsBloom, 256)
+	payload = appendRlpQuantity(p, payload, "difficulty", h.Difficulty, h.DifficultyU256)
+	payload = rlpAppendUint(payload, h.Number)
+	payload = rlpAppendUint(payload, h.GasLimit)
+	payload = rlpAppendUint(payload, h.GasUsed)
+	payload = rlpAppendUint(payload, h.Timestamp)
+	payload = rlpAppendBytes(payload, h.ExtraData)
+	// Some chains omit the PoW seal from JSON-RPC; the encoded header always has one
+	if h.MixHash != nil {
+		payload = appendRlpFixed(p, payload, "mixHash", h.MixHash, 32)
+	} else {
+		payload = rlpAppendBytes(payload, make([]byte, 32))
+	}
+	var nonce [8]byte
+	for i, v := 0, h.GetNonce(); i < 8; i++ {
+		nonce[7-i] = byte(v >> (8 * i))
+	}
+	payload = rlpAppendBytes(payload, nonce[:])
+
+	// Converters set baseFeePerGas to "" for blocks without one
+	london := h.GetBaseFeePerGas() != "" || h.BaseFeePerGasU256 != nil
+	shanghai := h.WithdrawalsRoot != nil
+	cancun := h.BlobGasUsed != nil || h.ExcessBlobGas != nil || h.ParentBeaconBlockRoot != nil
+	prague := h.RequestsHash != nil
+	switch {
+	case prague && !cancun:
+		p.fail("requestsHash", errors.New("set without the Cancun blob fields"))
+	case cancun && !shanghai:
+		p.fail("withdrawalsRoot", errors.New("required by the Cancun blob fields"))
+	case shanghai && !london:
+		p.fail("baseFeePerGas", errors.New("required by withdrawalsRoot"))
+	}
+	if london {
+		payload = appendRlpQuantity(p, payload, "baseFeePerGas", h.BaseFeePerGas, h.BaseFeePerGasU256)
+	}
+	if shanghai {
+		payload = appendRlpFixed(p, payload, "withdrawalsRoot", h.WithdrawalsRoot, 32)
+	}
+	if cancun {
+		requireUint64(p, "blobGasUsed", h.BlobGasUsed)
+		requireUint64(p, "excessBlobGas", h.ExcessBlobGas)
+		payload = rlpAppendUint(payload, h.GetBlobGasUsed())
+		payload = rlpAppendUint(payload, h.GetExcessBlobGas())
+		payload = appendRlpFixed(p, payload, "parentBeaconBlockRoot", h.ParentBeaconBlockRoot, 32)
+	}
+	if prague {
+		payload = appendRlpFixed(p, payload, "requestsHash", h.RequestsHash, 32)
+	}
+	return rlpAppendList(dst, payload)
+}
+
+// BlockHeaderHash returns the block hash of h: the Keccak-256 of its canonical RLP encoding.
+func BlockHeaderHash(h *BlockHeader) (Hash, error) {
+	enc, err := EncodeBlockHeaderRlp(h)
+	if err != nil {
+		return Hash{}, err
+	}
+	return Hash(Keccak256(enc)), nil
+}
+
+// SetBlockHeaderRlp sets h.CanonicalRlp to the canonical RLP encoding of h, and h.Hash to its
+// hash when unset.
+func SetBlockHeaderRlp(h *BlockHeader) error {
+	enc, err := EncodeBlockHeaderRlp(h)
+	if err != nil {
+		return err
+	}
+	h.CanonicalRlp = enc
+	if len(h.Hash) == 0 {
+		h.Hash = Keccak256(enc)
+	}
+	return nil
+}
+
+// VerifyBlockHeaderHash checks that h.Hash is the hash of the header fields and, when
+// h.CanonicalRlp is set, that it is their canonical encoding. Errors are as for
+// EncodeBlockHeaderRlp; a mismatch is reported under the path "hash" or "canonicalRlp" with the
+// computed hash under the computedHash detail.
+func VerifyBlockHeaderHash(h *BlockHeader) error {
+	p := newFieldParser(true)
+	enc := appendBlockHeaderRlp(p, nil, h)
+	if p.failed() {
+		return p.err()
+	}
+	computed := Hash(Keccak256(enc))
+	switch {
+	case len(h.Hash) == 0:
+		p.fail("hash", errors.New("block header has no hash"))
+	case !bytes.Equal(computed[:], h.Hash):
+		p.failMismatch("hash", fmt.Errorf("block %d hashes to %s, not %s", h.Number, computed.Hex(), BytesToHex(h.Hash)), ComputedHashDetail, computed.Hex())
+	}
+	if h.CanonicalRlp != nil && !bytes.Equal(h.CanonicalRlp, enc) {
+		p.failMismatch("canonicalRlp", fmt.Errorf("does not match the fields of block %d", h.Number), ComputedHashDetail, computed.Hex())
+	}
+	return p.err()
+}
@@ -0,0 +1,266 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// mainnetGenesisHeader returns block 0 of Ethereum mainnet.
+func mainnetGenesisHeader() *BlockHeader {
+	difficulty := "0x400000000"
+	nonce := uint64(0x42)
+	return &BlockHeader{
+		Number:           0,
+		Hash:             MustHexToBytes("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"),
+		ParentHash:       make([]byte, 32),
+		Sha3Uncles:       EmptyUnclesHash,
+		Miner:            make([]byte, 20),
+		StateRoot:        MustHexToBytes("0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544"),
+		TransactionsRoot: EmptyTrieRoot,
+		ReceiptsRoot:     EmptyTrieRoot,
+		LogsBloom:        make([]byte, 256),
+		Difficulty:       &difficulty,
+		GasLimit:         0x1388,
+		Timestamp:        0,
+		ExtraData:        MustHexToBytes("0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa"),
+		MixHash:          make([]byte, 32),
+		Nonce:            &nonce,
+	}
+}
+
+// mainnetBlock1Header returns block 1 of Ethereum mainnet, with difficulty only in its U256 field.
+func mainnetBlock1Header() *BlockHeader {
+	nonce := uint64(0x539bd4979fef1ec4)
+	return &BlockHeader{
+		Number:           1,
+		Hash:             MustHexToBytes("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"),
+		ParentHash:       MustHexToBytes("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"),
+		Sha3Uncles:       EmptyUnclesHash,
+		Miner:            MustHexToBytes("0x05a56e2d52c817161883f50c441c3228cfe54d9f"),
+		StateRoot:        MustHexToBytes("0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3"),
+		TransactionsRoot: EmptyTrieRoot,
+		ReceiptsRoot:     EmptyTrieRoot,
+		LogsBloom:        make([]byte, 256),
+		DifficultyU256:   MustParseU256("0x3ff800000").Bytes(),
+		GasLimit:         0x1388,
+		Timestamp:        0x55ba4224,
+		ExtraData:        MustHexToBytes("0x476574682f76312e302e302f6c696e75782f676f312e342e32"),
+		MixHash:          MustHexToBytes("0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59"),
+		Nonce:            &nonce,
+	}
+}
+
+// pragueHeader returns a header with the fields of every fork up to Prague set.
+func pragueHeader() *BlockHeader {
+	h := mainnetBlock1Header()
+	baseFee := "7"
+	blobGasUsed, excessBlobGas := uint64(0x20000), uint64(0)
+	h.Hash = nil
+	h.BaseFeePerGas = &baseFee
+	h.WithdrawalsRoot = EmptyTrieRoot
+	h.BlobGasUsed = &blobGasUsed
+	h.ExcessBlobGas = &excessBlobGas
+	h.ParentBeaconBlockRoot = make([]byte, 32)
+	h.RequestsHash = MustHexToBytes("0xe3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	return h
+}
+
+func TestBlockHeaderHash(t *testing.T) {
+	for name, h := range map[string]*BlockHeader{
+		"Genesis": mainnetGenesisHeader(),
+		"Block1":  mainnetBlock1Header(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := BlockHeaderHash(h)
+			if err != nil {
+				t.Fatalf("BlockHeaderHash failed: %v", err)
+			}
+			if got.Hex() != BytesToHex(h.Hash) {
+				t.Errorf("Expected hash %s, got %s", BytesToHex(h.Hash), got.Hex())
+			}
+			if err := VerifyBlockHeaderHash(h); err != nil {
+				t.Errorf("VerifyBlockHeaderHash failed: %v", err)
+			}
+		})
+	}
+}
+
+// mainnetBlock1JsonRpc is block 1 of Ethereum mainnet as returned by eth_getBlockByNumber.
+const mainnetBlock1JsonRpc = `{
+	"difficulty": "0x3ff800000",
+	"extraData": "0x476574682f76312e302e302f6c696e75782f676f312e342e32",
+	"gasLimit": "0x1388",
+	"gasUsed": "0x0",
+	"hash": "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",
+	"logsBloom": "0x` + "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" + `",
+	"miner": "0x05a56e2d52c817161883f50c441c3228cfe54d9f",
+	"mixHash": "0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59",
+	"nonce": "0x539bd4979fef1ec4",
+	"number": "0x1",
+	"parentHash": "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+	"receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"size": "0x219",
+	"stateRoot": "0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3",
+	"timestamp": "0x55ba4224",
+	"totalDifficulty": "0x7ff800000",
+	"transactions": [],
+	"transactionsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"uncles": []
+}`
+
+func TestBlockHeaderHashFromJsonRpc(t *testing.T) {
+	var jb JsonRpcBlock
+	if err := json.Unmarshal([]byte(mainnetBlock1JsonRpc), &jb); err != nil {
+		t.Fatalf("Failed to unmarshal block: %v", err)
+	}
+	block, err := jb.ToProto()
+	if err != nil {
+		t.Fatalf("Failed to convert block: %v", err)
+	}
+	if err := VerifyBlockHeaderHash(block.Header); err != nil {
+		t.Errorf("VerifyBlockHeaderHash failed: %v", err)
+	}
+}
+
+func TestBlockHeaderRlpLayouts(t *testing.T) {
+	layouts := []struct {
+		name   string
+		fields int
+		strip  func(h *BlockHeader)
+	}{
+		{"Prague", 21, func(h *BlockHeader) {}},
+		{"Cancun", 20, func(h *BlockHeader) { h.RequestsHash = nil }},
+		{"Shanghai", 17, func(h *BlockHeader) {
+			h.RequestsHash, h.BlobGasUsed, h.ExcessBlobGas, h.ParentBeaconBlockRoot = nil, nil, nil, nil
+		}},
+		{"London", 16, func(h *BlockHeader) {
+			h.RequestsHash, h.BlobGasUsed, h.ExcessBlobGas, h.ParentBeaconBlockRoot = nil, nil, nil, nil
+			h.WithdrawalsRoot = nil
+		}},
+	}
+	for _, layout := range layouts {
+		t.Run(layout.name, func(t *testing.T) {
+			h := pragueHeader()
+			layout.strip(h)
+			enc, err := EncodeBlockHeaderRlp(h)
+			if err != nil {
+				t.Fatalf("EncodeBlockHeaderRlp failed: %v", err)
+			}
+			items, err := rlpDecodeList(enc)
+			if err != nil {
+				t.Fatalf("Encoding is not an RLP list: %v", err)
+			}
+			if len(items) != layout.fields {
+				t.Fatalf("Expected %d fields, got %d", layout.fields, len(items))
+			}
+			if baseFee, _ := rlpDecodeBytes(items[15]); len(baseFee) != 1 || baseFee[0] != 7 {
+				t.Errorf("Unexpected baseFeePerGas encoding %x", items[15])
+			}
+
+			if err := SetBlockHeaderRlp(h); err != nil {
+				t.Fatalf("SetBlockHeaderRlp failed: %v", err)
+			}
+			if BytesToHex(h.Hash) != BytesToHex(Keccak256(enc)) || BytesToHex(h.CanonicalRlp) != BytesToHex(enc) {
+				t.Errorf("SetBlockHeaderRlp did not fill hash and canonicalRlp")
+			}
+			if err := VerifyBlockHeaderHash(h); err != nil {
+				t.Errorf("VerifyBlockHeaderHash failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestBlockHeaderRlpU256Preferred(t *testing.T) {
+	h := mainnetBlock1Header()
+	stale := "0x1"
+	h.Difficulty = &stale
+	if err := VerifyBlockHeaderHash(h); err != nil {
+		t.Errorf("Expected difficultyU256 to take precedence: %v", err)
+	}
+}
+
+func TestBlockHeaderRlpErrors(t *testing.T) {
+	tests := map[string]struct {
+		modify func(h *BlockHeader)
+		path   string
+	}{
+		"ShortParentHash": {func(h *BlockHeader) { h.ParentHash = h.ParentHash[:31] }, "parentHash"},
+		"ShortBloom":      {func(h *BlockHeader) { h.LogsBloom = nil }, "logsBloom"},
+		"BadBaseFee": {func(h *BlockHeader) {
+			bad := "0xzz"
+			h.BaseFeePerGas = &bad
+		}, "baseFeePerGas"},
+		"MissingWithdrawalsRoot": {func(h *BlockHeader) { h.WithdrawalsRoot = nil }, "withdrawalsRoot"},
+		"MissingBaseFee":         {func(h *BlockHeader) { h.BaseFeePerGas = nil }, "baseFeePerGas"},
+		"PartialCancun":          {func(h *BlockHeader) { h.ExcessBlobGas = nil }, "excessBlobGas"},
+		"RequestsWithoutCancun": {func(h *BlockHeader) {
+			h.BlobGasUsed, h.ExcessBlobGas, h.ParentBeaconBlockRoot = nil, nil, nil
+		}, "requestsHash"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := pragueHeader()
+			tt.modify(h)
+			_, err := EncodeBlockHeaderRlp(h)
+			if path := headerErrorDetail(t, err, ConvertErrorPathDetail); path != tt.path {
+				t.Errorf("Expected path %q, got %v (%v)", tt.path, path, err)
+			}
+		})
+	}
+
+	t.Run("Collected", func(t *testing.T) {
+		h := pragueHeader()
+		h.ParentHash, h.LogsBloom = nil, nil
+		_, err := EncodeBlockHeaderRlp(h)
+		want := []string{"parentHash", "logsBloom"}
+		if paths := headerErrorDetail(t, err, ConvertErrorPathsDetail); !reflect.DeepEqual(paths, want) {
+			t.Errorf("Expected paths %v, got %v", want, paths)
+		}
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		h := mainnetBlock1Header()
+		h.GasUsed = 1
+		err := VerifyBlockHeaderHash(h)
+		if path := headerErrorDetail(t, err, ConvertErrorPathDetail); path != "hash" {
+			t.Errorf("Expected a hash mismatch, got %v", err)
+		}
+		computed, _ := BlockHeaderHash(h)
+		if got := headerErrorDetail(t, err, ComputedHashDetail); got != computed.Hex() {
+			t.Errorf("Expected the computed hash %s, got %v", computed.Hex(), got)
+		}
+	})
+
+	t.Run("CanonicalRlpMismatch", func(t *testing.T) {
+		h := mainnetBlock1Header()
+		h.CanonicalRlp = []byte{0xc0}
+		err := VerifyBlockHeaderHash(h)
+		if path := headerErrorDetail(t, err, ConvertErrorPathDetail); path != "canonicalRlp" {
+			t.Errorf("Expected a canonicalRlp mismatch, got %v", err)
+		}
+		if got := headerErrorDetail(t, err, ComputedHashDetail); got != BytesToHex(h.Hash) {
+			t.Errorf("Expected the computed hash %s, got %v", BytesToHex(h.Hash), got)
+		}
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		if _, err := EncodeBlockHeaderRlp(nil); headerErrorDetail(t, err, ConvertErrorPathDetail) != "" {
+			t.Errorf("Expected an error for the header itself, got %v", err)
+		}
+	})
+}
+
+// headerErrorDetail returns the named detail of an INVALID_PARAMETER BaseError.
+func headerErrorDetail(t *testing.T, err error, key string) interface{} {
+	t.Helper()
+	var baseErr *common.BaseError
+	if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+		t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+	}
+	return baseErr.Details[key]
+}
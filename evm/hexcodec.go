@@ -0,0 +1,143 @@
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// Hand-rolled hex decoding for JSON-RPC DATA and QUANTITY values. The decoders are generic over
+// string and []byte so raw JSON bytes can be parsed without converting them to strings first.
+
+var (
+	// ErrMissingHexPrefix is returned for quantities without the 0x prefix.
+	ErrMissingHexPrefix = errors.New("hex string without 0x prefix")
+	// ErrEmptyQuantity is returned for a bare "0x" quantity.
+	ErrEmptyQuantity = errors.New("hex quantity without digits")
+	// ErrLeadingZero is returned by canonical decoding for quantities such as "0x01".
+	ErrLeadingZero = errors.New("hex quantity with leading zero digits")
+	// ErrQuantityOverflow is returned for quantities that do not fit the target integer.
+	ErrQuantityOverflow = errors.New("hex quantity overflows")
+	// ErrHexSyntax is returned for characters that are not hex digits.
+	ErrHexSyntax = errors.New("invalid hex digit")
+)
+
+type hexInput interface {
+	~string | ~[]byte
+}
+
+// hexNibbles maps ASCII hex digits to their value and every other byte to 0xff.
+var hexNibbles = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xff
+	}
+	for c := '0'; c <= '9'; c++ {
+		t[c] = byte(c - '0')
+	}
+	for c := 'a'; c <= 'f'; c++ {
+		t[c] = byte(c-'a') + 10
+		t[c-'a'+'A'] = byte(c-'a') + 10
+	}
+	return t
+}()
+
+const hexDigits = "0123456789abcdef"
+
+// DecodeQuantity parses a 0x-prefixed hex QUANTITY into a uint64. Leading zeros are accepted
+// unless canonical is set, which enforces the EIP-1474 encoding where "0x0" is the only value
+// starting with a zero digit.
+func DecodeQuantity(src []byte, canonical bool) (uint64, error) {
+	return decodeQuantity(src, canonical, 64)
+}
+
+// DecodeHex appends the bytes encoded by src, with or without a 0x prefix, to dst. An odd number
+// of digits is read as if it had a leading zero, as some clients send "0x1" for one byte.
+func DecodeHex(dst, src []byte) ([]byte, error) {
+	return decodeHex(dst, src)
+}
+
+// AppendHex appends b as 0x-prefixed lowercase hex DATA to dst, matching BytesToHex.
+func AppendHex(dst, b []byte) []byte {
+	dst = append(dst, '0', 'x')
+	return hex.AppendEncode(dst, b)
+}
+
+// AppendQuantity appends v as a JSON-RPC QUANTITY to dst, matching fmt.Sprintf("0x%x", v).
+func AppendQuantity(dst []byte, v uint64) []byte {
+	dst = append(dst, '0', 'x')
+	if v == 0 {
+		return append(dst, '0')
+	}
+	for shift := (bits.Len64(v) - 1) &^ 3; shift >= 0; shift -= 4 {
+		dst = append(dst, hexDigits[v>>uint(shift)&0xf])
+	}
+	return dst
+}
+
+func decodeQuantity[T hexInput](s T, canonical bool, bitSize int) (uint64, error) {
+	if len(s) < 2 || s[0] != '0' || s[1]|0x20 != 'x' {
+		return 0, fmt.Errorf("%w: %q", ErrMissingHexPrefix, s)
+	}
+	digits := s[2:]
+	if len(digits) == 0 {
+		return 0, ErrEmptyQuantity
+	}
+	if canonical && len(digits) > 1 && digits[0] == '0' {
+		return 0, fmt.Errorf("%w: %q", ErrLeadingZero, s)
+	}
+	var v uint64
+	for i := 0; i < len(digits); i++ {
+		n := hexNibbles[digits[i]]
+		if n == 0xff {
+			return 0, fmt.Errorf("%w %q in %q", ErrHexSyntax, digits[i], s)
+		}
+		if v>>(bitSize-4) != 0 {
+			return 0, fmt.Errorf("%w %d bits: %q", ErrQuantityOverflow, bitSize, s)
+		}
+		v = v<<4 | uint64(n)
+	}
+	return v, nil
+}
+
+func decodeHex[T hexInput](dst []byte, s T) ([]byte, error) {
+	if len(s) >= 2 && s[0] == '0' && s[1]|0x20 == 'x' {
+		s = s[2:]
+	}
+	if len(s)%2 == 1 {
+		n := hexNibbles[s[0]]
+		if n == 0xff {
+			return nil, fmt.Errorf("%w %q", ErrHexSyntax, s[0])
+		}
+		dst = append(dst, n)
+		s = s[1:]
+	}
+	n := len(dst)
+	dst = slices.Grow(dst, len(s)/2)[:n+len(s)/2]
+	out := dst[n:]
+	for i := range out {
+		hi, lo := hexNibbles[s[2*i]], hexNibbles[s[2*i+1]]
+		if hi|lo == 0xff {
+			return nil, fmt.Errorf("%w in %q", ErrHexSyntax, s[2*i:2*i+2])
+		}
+		out[i] = hi<<4 | lo
+	}
+	return dst, nil
+}
+
+// hexString returns b as 0x-prefixed lowercase hex with a single allocation.
+func hexString(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(2 + 2*len(b))
+	sb.WriteString("0x")
+	var chunk [64]byte
+	for len(b) > 0 {
+		n := min(len(b), len(chunk)/2)
+		hex.Encode(chunk[:], b[:n])
+		sb.Write(chunk[:2*n])
+		b = b[n:]
+	}
+	return sb.String()
+}
@@ -0,0 +1,218 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestDecodeQuantity(t *testing.T) {
+	tests := []struct {
+		in        string
+		canonical bool
+		want      uint64
+		err       error
+	}{
+		{in: "0x0", want: 0},
+		{in: "0x1b4", want: 436},
+		{in: "0X1B4", want: 436},
+		{in: "0x01b4", want: 436},
+		{in: "0x01b4", canonical: true, err: ErrLeadingZero},
+		{in: "0x00", canonical: true, err: ErrLeadingZero},
+		{in: "0x0", canonical: true, want: 0},
+		{in: "0xffffffffffffffff", want: math.MaxUint64},
+		{in: "0x0000ffffffffffffffff", want: math.MaxUint64},
+		{in: "0x10000000000000000", err: ErrQuantityOverflow},
+		{in: "0x", err: ErrEmptyQuantity},
+		{in: "1b4", err: ErrMissingHexPrefix},
+		{in: "", err: ErrMissingHexPrefix},
+		{in: "0x1g", err: ErrHexSyntax},
+		{in: "0x12 ", err: ErrHexSyntax},
+	}
+	for _, tt := range tests {
+		got, err := DecodeQuantity([]byte(tt.in), tt.canonical)
+		if !errors.Is(err, tt.err) || got != tt.want && tt.err == nil {
+			t.Errorf("DecodeQuantity(%q, %v) = %d, %v; want %d, %v", tt.in, tt.canonical, got, err, tt.want, tt.err)
+		}
+		if tt.canonical {
+			continue
+		}
+		if got, err := HexToUint64(tt.in); !errors.Is(err, tt.err) || got != tt.want && tt.err == nil {
+			t.Errorf("HexToUint64(%q) = %d, %v", tt.in, got, err)
+		}
+	}
+
+	if v, err := HexToUint32("0xffffffff"); err != nil || v != math.MaxUint32 {
+		t.Errorf("HexToUint32(max) = %d, %v", v, err)
+	}
+	if _, err := HexToUint32("0x100000000"); !errors.Is(err, ErrQuantityOverflow) {
+		t.Errorf("Expected HexToUint32 to detect overflow, got %v", err)
+	}
+}
+
+func TestDecodeHex(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+		ok   bool
+	}{
+		{"0x", []byte{}, true},
+		{"", []byte{}, true},
+		{"0xdeadBEEF", []byte{0xde, 0xad, 0xbe, 0xef}, true},
+		{"deadbeef", []byte{0xde, 0xad, 0xbe, 0xef}, true},
+		{"0x1", []byte{0x01}, true},
+		{"0x123", []byte{0x01, 0x23}, true},
+		{"0xzz", nil, false},
+		{"0x1z", nil, false},
+		{"0xz12", nil, false},
+	}
+	for _, tt := range tests {
+		got, err := HexToBytes(tt.in)
+		if (err == nil) != tt.ok || !bytes.Equal(got, tt.want) || (tt.ok && got == nil) {
+			t.Errorf("HexToBytes(%q) = %#v, %v", tt.in, got, err)
+		}
+		prefix := []byte{0xaa}
+		appended, err := DecodeHex(prefix, []byte(tt.in))
+		if tt.ok && (err != nil || !bytes.Equal(appended, append([]byte{0xaa}, tt.want...))) {
+			t.Errorf("DecodeHex(%q) = %x, %v", tt.in, appended, err)
+		}
+	}
+}
+
+func TestAppendHex(t *testing.T) {
+	for _, v := range []uint64{0, 1, 0xf, 0x10, 0x1b4, 0xdeadbeef, math.MaxUint64} {
+		if got, want := string(AppendQuantity(nil, v)), fmt.Sprintf("0x%x", v); got != want {
+			t.Errorf("AppendQuantity(%d) = %s, want %s", v, got, want)
+		}
+	}
+	for _, b := range [][]byte{nil, {}, {0}, bytes.Repeat([]byte{0xab, 0x01}, 100)} {
+		want := "0x" + hex.EncodeToString(b)
+		if got := string(AppendHex([]byte("x"), b)); got != "x"+want {
+			t.Errorf("AppendHex(%x) = %s", b, got)
+		}
+		if got := BytesToHex(b); got != want {
+			t.Errorf("BytesToHex(%x) = %s", b, got)
+		}
+	}
+}
+
+// sscanfHexToUint64 is the fmt.Sscanf-based parser HexToUint64 used to be, kept as a baseline.
+func sscanfHexToUint64(s string) (uint64, error) {
+	var result uint64
+	_, err := fmt.Sscanf(s, "0x%x", &result)
+	return result, err
+}
+
+func BenchmarkHexToUint64(b *testing.B) {
+	const in = "0x121eac0"
+	b.Run("Sscanf", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := sscanfHexToUint64(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := HexToUint64(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("DecoderBytes", func(b *testing.B) {
+		raw := []byte(in)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeQuantity(raw, true); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// pooledHexToBytes is the sync.Pool-based decoder HexToBytes used to be, kept as a baseline.
+func pooledHexToBytes(s string) ([]byte, error) {
+	s = RemoveHexPrefix(s)
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	expectedLen := len(s) / 2
+	if expectedLen <= 64 {
+		poolBuf := legacyHexDecodePool.Get().([]byte)
+		defer legacyHexDecodePool.Put(poolBuf[:0])
+		if cap(poolBuf) < expectedLen {
+			poolBuf = make([]byte, 0, expectedLen*2)
+		}
+		decoded := poolBuf[:expectedLen]
+		n, err := hex.Decode(decoded, []byte(s))
+		if err != nil {
+			return nil, err
+		}
+		result := make([]byte, n)
+		copy(result, decoded[:n])
+		return result, nil
+	}
+	return hex.DecodeString(s)
+}
+
+var legacyHexDecodePool = sync.Pool{
+	New: func() interface{} {
+		return make([]byte, 0, 32)
+	},
+}
+
+func BenchmarkHexToBytes(b *testing.B) {
+	const in = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	b.Run("Pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := pooledHexToBytes(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := HexToBytes(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("DecoderAppend", func(b *testing.B) {
+		raw := []byte(in)
+		var buf []byte
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var err error
+			if buf, err = DecodeHex(buf[:0], raw); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkJsonRpcLogToProto(b *testing.B) {
+	l := &JsonRpcLog{
+		Address:          "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		BlockHash:        "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+		BlockNumber:      "0x121eac0",
+		Data:             "0x00000000000000000000000000000000000000000000000000000000000f4240",
+		LogIndex:         "0xfa",
+		Topics:           []string{TransferEventSignature, "0x0000000000000000000000001234567890123456789012345678901234567890", "0x0000000000000000000000009876543210987654321098765432109876543210"},
+		TransactionHash:  "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		TransactionIndex: "0x2a",
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := l.ToProto(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
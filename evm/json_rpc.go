@@ -1,6 +1,8 @@
 package evm
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 )
@@ -13,266 +15,131 @@ type JsonRpcWithdrawal struct {
 }
 
 func (w *JsonRpcWithdrawal) ToProto() (*Withdrawal, error) {
-	index, err := NumberishToUint64(w.Index)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse withdrawal index: %w", err)
-	}
-
-	validatorIndex, err := NumberishToUint64(w.ValidatorIndex)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse validator index: %w", err)
-	}
-
-	address, err := HexToBytes(w.Address)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse withdrawal address: %w", err)
-	}
-
-	amount, err := NumberishToUint64(w.Amount)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse withdrawal amount: %w", err)
+	p := newFieldParser(false)
+	withdrawal := w.toProto(p)
+	if err := p.err(); err != nil {
+		return nil, err
 	}
+	return withdrawal, nil
+}
 
+func (w *JsonRpcWithdrawal) toProto(p *fieldParser) *Withdrawal {
 	return &Withdrawal{
-		Index:          index,
-		ValidatorIndex: validatorIndex,
-		Address:        address,
-		Amount:         amount,
-	}, nil
+		Index:          p.uint64("index", w.Index),
+		ValidatorIndex: p.uint64("validatorIndex", w.ValidatorIndex),
+		Address:        p.bytes("address", w.Address),
+		Amount:         p.uint64("amount", w.Amount),
+	}
 }
 
 type JsonRpcBlock struct {
-	BaseFeePerGas         string               `json:"baseFeePerGas"`
-	BlobGasUsed           string               `json:"blobGasUsed"`
-	Difficulty            string               `json:"difficulty"`
-	ExcessBlobGas         string               `json:"excessBlobGas"`
-	ExtraData             string               `json:"extraData"`
-	GasLimit              string               `json:"gasLimit"`
-	GasUsed               string               `json:"gasUsed"`
-	Hash                  string               `json:"hash"`
-	LogsBloom             string               `json:"logsBloom"`
-	Miner                 string               `json:"miner"`
-	MixHash               string               `json:"mixHash"`
-	Nonce                 string               `json:"nonce"`
-	Number                string               `json:"number"`
-	ParentBeaconBlockRoot string               `json:"parentBeaconBlockRoot"`
-	ParentHash            string               `json:"parentHash"`
-	ReceiptsRoot          string               `json:"receiptsRoot"`
-	Sha3Uncles            string               `json:"sha3Uncles"`
-	Size                  string               `json:"size"`
-	StateRoot             string               `json:"stateRoot"`
-	Timestamp             string               `json:"timestamp"`
-	TotalDifficulty       string               `json:"totalDifficulty"`
-	TransactionsRoot      string               `json:"transactionsRoot"`
-	Uncles                []string             `json:"uncles"`
-	WithdrawalsRoot       string               `json:"withdrawalsRoot"`
-	RequestsHash          string               `json:"requestsHash"`
-	L1BlockNumber         string               `json:"l1BlockNumber"`
-	SendCount             string               `json:"sendCount"`
-	SendRoot              string               `json:"sendRoot"`
-	Epoch                 string               `json:"epoch"`
-	Slot                  string               `json:"slot"`
-	ProposerIndex         string               `json:"proposerIndex"`
-	TransactionCount      string               `json:"transactionCount"`
-	ProposerPublicKey     string               `json:"proposerPublicKey"`
-	Withdrawals           []*JsonRpcWithdrawal `json:"withdrawals"`
-	CanonicalRlp          string               `json:"canonicalRlp"`
-	Transactions          []interface{}        `json:"transactions"`
-	Timeboosted           bool                 `json:"timeboosted"`
+	BaseFeePerGas         string                   `json:"baseFeePerGas"`
+	BlobGasUsed           string                   `json:"blobGasUsed"`
+	Difficulty            string                   `json:"difficulty"`
+	ExcessBlobGas         string                   `json:"excessBlobGas"`
+	ExtraData             string                   `json:"extraData"`
+	GasLimit              string                   `json:"gasLimit"`
+	GasUsed               string                   `json:"gasUsed"`
+	Hash                  string                   `json:"hash"`
+	LogsBloom             string                   `json:"logsBloom"`
+	Miner                 string                   `json:"miner"`
+	MixHash               string                   `json:"mixHash"`
+	Nonce                 string                   `json:"nonce"`
+	Number                string                   `json:"number"`
+	ParentBeaconBlockRoot string                   `json:"parentBeaconBlockRoot"`
+	ParentHash            string                   `json:"parentHash"`
+	ReceiptsRoot          string                   `json:"receiptsRoot"`
+	Sha3Uncles            string                   `json:"sha3Uncles"`
+	Size                  string                   `json:"size"`
+	StateRoot             string                   `json:"stateRoot"`
+	Timestamp             string                   `json:"timestamp"`
+	TotalDifficulty       string                   `json:"totalDifficulty"`
+	TransactionsRoot      string                   `json:"transactionsRoot"`
+	Uncles                []string                 `json:"uncles"`
+	WithdrawalsRoot       string                   `json:"withdrawalsRoot"`
+	RequestsHash          string                   `json:"requestsHash"`
+	L1BlockNumber         string                   `json:"l1BlockNumber"`
+	SendCount             string                   `json:"sendCount"`
+	SendRoot              string                   `json:"sendRoot"`
+	Epoch                 string                   `json:"epoch"`
+	Slot                  string                   `json:"slot"`
+	ProposerIndex         string                   `json:"proposerIndex"`
+	TransactionCount      string                   `json:"transactionCount"`
+	ProposerPublicKey     string                   `json:"proposerPublicKey"`
+	Withdrawals           []*JsonRpcWithdrawal     `json:"withdrawals"`
+	CanonicalRlp          string                   `json:"canonicalRlp"`
+	Transactions          JsonRpcBlockTransactions `json:"transactions"`
+	Timeboosted           bool                     `json:"timeboosted"`
+	// Extensions holds undeclared fields captured by UnmarshalJSON as raw JSON text.
+	Extensions map[string]string `json:"-"`
 }
 
-func (b *JsonRpcBlock) ToProto() (*Block, error) {
-	number, err := NumberishToUint64(b.Number)
-	if err != nil {
-		return nil, err
-	}
-	hash, err := HexToBytes(b.Hash)
-	if err != nil {
-		return nil, err
-	}
-	parentHash, err := HexToBytes(b.ParentHash)
-	if err != nil {
-		return nil, err
-	}
-	timestamp, err := NumberishToUint64(b.Timestamp)
-	if err != nil {
-		return nil, err
-	}
-	gasLimit, err := NumberishToUint64(b.GasLimit)
-	if err != nil {
-		return nil, err
-	}
-	gasUsed, err := NumberishToUint64(b.GasUsed)
-	if err != nil {
+// ToProto converts the JSON-RPC block into a proto Block. Errors are common.BaseError values
+// with code INVALID_PARAMETER whose "path" detail names the invalid field, for example
+// "transactions[17].accessList[2].storageKeys[0]"; see WithCollectErrors to get all of them.
+func (b *JsonRpcBlock) ToProto(opts ...ConvertOption) (*Block, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	block := b.toProto(p, cfg)
+	if err := p.err(); err != nil {
 		return nil, err
 	}
+	return block, nil
+}
+
+func (b *JsonRpcBlock) toProto(p *fieldParser, cfg *convertConfig) *Block {
+	cfg.checkFields(p, "block", b, b.Extensions, standardBlockFields, cfg.dialect.BlockFields)
+
+	number := p.uint64("number", b.Number)
+	hash := p.bytes("hash", b.Hash)
+	parentHash := p.bytes("parentHash", b.ParentHash)
+	timestamp := p.uint64("timestamp", b.Timestamp)
+	gasLimit := p.uint64("gasLimit", b.GasLimit)
+	gasUsed := p.uint64("gasUsed", b.GasUsed)
 	var size uint64
 	if b.Size != "" {
-		size, err = NumberishToUint64(b.Size)
-		if err != nil {
-			return nil, err
-		}
-	}
-	logsBloom, err := HexToBytes(b.LogsBloom)
-	if err != nil {
-		return nil, err
-	}
-	transactionsRoot, err := HexToBytes(b.TransactionsRoot)
-	if err != nil {
-		return nil, err
-	}
-	stateRoot, err := HexToBytes(b.StateRoot)
-	if err != nil {
-		return nil, err
-	}
-	receiptsRoot, err := HexToBytes(b.ReceiptsRoot)
-	if err != nil {
-		return nil, err
-	}
-	sha3Uncles, err := HexToBytes(b.Sha3Uncles)
-	if err != nil {
-		return nil, err
-	}
-	miner, err := HexToBytes(b.Miner)
-	if err != nil {
-		return nil, err
-	}
-	extraData, err := HexToBytes(b.ExtraData)
-	if err != nil {
-		return nil, err
-	}
+		size = p.uint64("size", b.Size)
+	} else if cfg.strict && !cfg.dialect.SizeOptional {
+		p.fail("size", fmt.Errorf("required by the %s dialect", cfg.dialect.Name))
+	}
+	logsBloom := p.bytes("logsBloom", b.LogsBloom)
+	transactionsRoot := p.bytes("transactionsRoot", b.TransactionsRoot)
+	stateRoot := p.bytes("stateRoot", b.StateRoot)
+	receiptsRoot := p.bytes("receiptsRoot", b.ReceiptsRoot)
+	sha3Uncles := p.bytes("sha3Uncles", b.Sha3Uncles)
+	miner := p.bytes("miner", b.Miner)
+	extraData := p.bytes("extraData", b.ExtraData)
 
 	// Handle optional fields
 	var nonce *uint64
 	if b.Nonce != "" {
-		n, err := NumberishToUint64(b.Nonce)
-		if err != nil {
-			return nil, err
-		}
-		nonce = &n
-	}
-
-	var mixHash []byte
-	if b.MixHash != "" {
-		mixHash, err = HexToBytes(b.MixHash)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	var withdrawalsRoot []byte
-	if b.WithdrawalsRoot != "" {
-		withdrawalsRoot, err = HexToBytes(b.WithdrawalsRoot)
-		if err != nil {
-			return nil, err
+		if err := cfg.checkBlockNonce(b.Nonce); err != nil {
+			p.fail("nonce", err)
 		}
+		nonce = p.optUint64("nonce", b.Nonce)
 	}
 
-	var requestsHash []byte
-	if b.RequestsHash != "" {
-		requestsHash, err = HexToBytes(b.RequestsHash)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	var blobGasUsed *uint64
-	if b.BlobGasUsed != "" {
-		bgu, err := NumberishToUint64(b.BlobGasUsed)
-		if err != nil {
-			return nil, err
-		}
-		blobGasUsed = &bgu
-	}
-
-	var excessBlobGas *uint64
-	if b.ExcessBlobGas != "" {
-		ebg, err := NumberishToUint64(b.ExcessBlobGas)
-		if err != nil {
-			return nil, err
-		}
-		excessBlobGas = &ebg
-	}
-
-	var parentBeaconBlockRoot []byte
-	if b.ParentBeaconBlockRoot != "" {
-		parentBeaconBlockRoot, err = HexToBytes(b.ParentBeaconBlockRoot)
-		if err != nil {
-			return nil, err
-		}
-	}
+	mixHash := p.optBytes("mixHash", b.MixHash)
+	withdrawalsRoot := p.optBytes("withdrawalsRoot", b.WithdrawalsRoot)
+	requestsHash := p.optBytes("requestsHash", b.RequestsHash)
+	blobGasUsed := p.optUint64("blobGasUsed", b.BlobGasUsed)
+	excessBlobGas := p.optUint64("excessBlobGas", b.ExcessBlobGas)
+	parentBeaconBlockRoot := p.optBytes("parentBeaconBlockRoot", b.ParentBeaconBlockRoot)
 
 	// Optional L2-specific fields
-	var l1BlockNumber *uint64
-	if b.L1BlockNumber != "" {
-		n, err := NumberishToUint64(b.L1BlockNumber)
-		if err != nil {
-			return nil, err
-		}
-		l1BlockNumber = &n
-	}
-
-	var sendCount *uint64
-	if b.SendCount != "" {
-		sc, err := NumberishToUint64(b.SendCount)
-		if err != nil {
-			return nil, err
-		}
-		sendCount = &sc
-	}
-
-	var sendRoot []byte
-	if b.SendRoot != "" {
-		sendRoot, err = HexToBytes(b.SendRoot)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	uncles := make([][]byte, 0, len(b.Uncles))
-	for _, uncle := range b.Uncles {
-		uncleBytes, err := HexToBytes(uncle)
-		if err != nil {
-			return nil, err
-		}
-		uncles = append(uncles, uncleBytes)
-	}
+	l1BlockNumber := p.optUint64("l1BlockNumber", b.L1BlockNumber)
+	sendCount := p.optUint64("sendCount", b.SendCount)
+	sendRoot := p.optBytes("sendRoot", b.SendRoot)
 
-	var epoch *uint64
-	if b.Epoch != "" {
-		e, err := NumberishToUint64(b.Epoch)
-		if err != nil {
-			return nil, err
-		}
-		epoch = &e
-	}
-
-	var slot *uint64
-	if b.Slot != "" {
-		sl, err := NumberishToUint64(b.Slot)
-		if err != nil {
-			return nil, err
-		}
-		slot = &sl
+	uncles := p.bytesList("uncles", b.Uncles)
+	if uncles == nil {
+		uncles = [][]byte{}
 	}
 
-	var proposerIndex *uint64
-	if b.ProposerIndex != "" {
-		pi, err := NumberishToUint64(b.ProposerIndex)
-		if err != nil {
-			return nil, err
-		}
-		proposerIndex = &pi
-	}
-
-	var transactionCount *uint32
-	if b.TransactionCount != "" {
-		tc, err := NumberishToUint32(b.TransactionCount)
-		if err != nil {
-			return nil, err
-		}
-		transactionCount = &tc
-	}
+	epoch := p.optUint64("epoch", b.Epoch)
+	slot := p.optUint64("slot", b.Slot)
+	proposerIndex := p.optUint64("proposerIndex", b.ProposerIndex)
+	transactionCount := p.optUint32("transactionCount", b.TransactionCount)
 
 	var proposerPublicKey *string
 	if b.ProposerPublicKey != "" {
@@ -284,13 +151,7 @@ func (b *JsonRpcBlock) ToProto() (*Block, error) {
 	// BlockHeader only contains withdrawalsRoot
 	// The full withdrawals data would be in the Block message
 
-	var canonicalRlp []byte
-	if b.CanonicalRlp != "" {
-		canonicalRlp, err = HexToBytes(b.CanonicalRlp)
-		if err != nil {
-			return nil, err
-		}
-	}
+	canonicalRlp := p.optBytes("canonicalRlp", b.CanonicalRlp)
 
 	header := &BlockHeader{
 		Number:                number,
@@ -327,48 +188,51 @@ func (b *JsonRpcBlock) ToProto() (*Block, error) {
 		Uncles:                uncles,
 		ProposerPublicKey:     proposerPublicKey,
 		CanonicalRlp:          canonicalRlp,
+		Extensions:            b.Extensions,
 	}
 
-	hashes, txs, err := ParseJsonRpcTransactions(b.Transactions, header)
-	if err != nil {
-		return nil, err
-	}
+	setBlockHeaderU256Fields(p, header)
 
-	withdrawals, err := ParseJsonRpcWithdrawals(b.Withdrawals)
-	if err != nil {
-		return nil, err
-	}
+	hashes, txs := b.Transactions.toProto(p, header, cfg)
 
 	return &Block{
 		Header:            header,
 		FullTransactions:  txs,
 		TransactionHashes: hashes,
-		Withdrawals:       withdrawals,
-	}, nil
+		Withdrawals:       parseJsonRpcWithdrawals(p, b.Withdrawals),
+	}
 }
 
-func ParseJsonRpcTransactions(transactions []interface{}, header *BlockHeader) ([][]byte, []*Transaction, error) {
+// ParseJsonRpcTransactions parses the transactions member of a block decoded by json.Unmarshal,
+// holding either transaction hashes or full transaction objects. Errors are reported under
+// paths such as "transactions[3].nonce".
+func ParseJsonRpcTransactions(transactions []interface{}, header *BlockHeader, opts ...ConvertOption) ([][]byte, []*Transaction, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
 	hashes := make([][]byte, 0, len(transactions))
 	txs := make([]*Transaction, 0, len(transactions))
-	for _, tx := range transactions {
+	for i, tx := range transactions {
+		tp := p.item("transactions", i)
 		switch v := tx.(type) {
 		case string:
 			// Transaction hash only
-			if hashBytes, err := HexToBytes(v); err == nil {
-				hashes = append(hashes, hashBytes)
-			} else {
-				return nil, nil, fmt.Errorf("failed to parse transaction hash: %w", err)
-			}
+			hashes = append(hashes, tp.bytes("", v))
 		case map[string]interface{}:
 			// Full transaction object
-			tx, err := ParseJsonRpcTransaction(v, header)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to parse transaction: %w", err)
+			if tx := parseJsonRpcTransactionMap(tp, v, header, cfg); tx != nil {
+				txs = append(txs, tx)
+				hashes = append(hashes, tx.Hash)
 			}
-			txs = append(txs, tx)
-			hashes = append(hashes, tx.Hash)
+		default:
+			tp.fail("", mapTypeError("a transaction hash or object", v))
+		}
+		if p.done() {
+			break
 		}
 	}
+	if err := p.err(); err != nil {
+		return nil, nil, err
+	}
 	return hashes, txs, nil
 }
 
@@ -404,122 +268,71 @@ type JsonRpcReceipt struct {
 	DepositNonce          string        `json:"depositNonce"`
 	DepositReceiptVersion string        `json:"depositReceiptVersion"`
 	Timeboosted           *bool         `json:"timeboosted"`
+	// Extensions holds undeclared fields captured by UnmarshalJSON as raw JSON text.
+	Extensions map[string]string `json:"-"`
 }
 
-func (r *JsonRpcReceipt) ToProto() (*Receipt, error) {
-	blockNumber, err := NumberishToUint64(r.BlockNumber)
-	if err != nil {
-		return nil, err
-	}
-	transactionIndex, err := NumberishToUint32(r.TransactionIndex)
-	if err != nil {
-		return nil, err
-	}
-	gasUsed, err := NumberishToUint64(r.GasUsed)
-	if err != nil {
-		return nil, err
-	}
-	cumulativeGasUsed, err := NumberishToUint64(r.CumulativeGasUsed)
-	if err != nil {
-		return nil, err
-	}
-	logsBloom, err := HexToBytes(r.LogsBloom)
-	if err != nil {
-		return nil, err
-	}
-	blockHash, err := HexToBytes(r.BlockHash)
-	if err != nil {
-		return nil, err
-	}
-	transactionHash, err := HexToBytes(r.TransactionHash)
-	if err != nil {
+func (r *JsonRpcReceipt) ToProto(opts ...ConvertOption) (*Receipt, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	receipt := r.toProto(p, cfg)
+	if err := p.err(); err != nil {
 		return nil, err
 	}
+	return receipt, nil
+}
+
+func (r *JsonRpcReceipt) toProto(p *fieldParser, cfg *convertConfig) *Receipt {
+	cfg.checkFields(p, "receipt", r, r.Extensions, standardReceiptFields, cfg.dialect.ReceiptFields)
+
+	blockNumber := p.uint64("blockNumber", r.BlockNumber)
+	transactionIndex := p.uint32("transactionIndex", r.TransactionIndex)
+	gasUsed := p.uint64("gasUsed", r.GasUsed)
+	cumulativeGasUsed := p.uint64("cumulativeGasUsed", r.CumulativeGasUsed)
+	logsBloom := p.bytes("logsBloom", r.LogsBloom)
+	blockHash := p.bytes("blockHash", r.BlockHash)
+	transactionHash := p.bytes("transactionHash", r.TransactionHash)
 
 	// Parse from address
-	from, err := HexToBytes(r.From)
-	if err != nil {
-		return nil, err
-	}
+	from := p.bytes("from", r.From)
 
 	// Parse to address (optional - can be null for contract creation)
 	var to []byte
-	if r.To != "" && r.To != "0x" {
-		to, err = HexToBytes(r.To)
-		if err != nil {
-			return nil, err
-		}
+	if r.To != "0x" {
+		to = p.optBytes("to", r.To)
 	}
 
 	// Handle optional fields
 	var typ uint32
 	if r.Type != "" {
-		t, err := NumberishToUint32(r.Type)
-		if err != nil {
-			return nil, err
-		}
-		typ = t
+		typ = p.uint32("type", r.Type)
 	}
 
-	var status *uint32
-	if r.Status != "" {
-		s, err := NumberishToUint32(r.Status)
-		if err != nil {
-			return nil, err
-		}
-		status = &s
-	}
+	status := p.optUint32("status", r.Status)
 
 	var contractAddress []byte
-	if r.ContractAddress != "" && r.ContractAddress != "0x" {
-		contractAddress, err = HexToBytes(r.ContractAddress)
-		if err != nil {
-			return nil, err
-		}
+	if r.ContractAddress != "0x" {
+		contractAddress = p.optBytes("contractAddress", r.ContractAddress)
 	}
 
-	var root []byte
-	if r.Root != "" {
-		root, err = HexToBytes(r.Root)
-		if err != nil {
-			return nil, err
-		}
-	}
+	root := p.optBytes("root", r.Root)
 
 	logs := make([]*Log, 0, len(r.Logs))
-	for _, log := range r.Logs {
-		protoLog, err := log.ToProto()
-		if err != nil {
-			return nil, err
+	for i, log := range r.Logs {
+		if log == nil {
+			p.item("logs", i).fail("", errors.New("log is null"))
+			continue
+		}
+		logs = append(logs, log.toProto(p.item("logs", i)))
+		if p.done() {
+			break
 		}
-		logs = append(logs, protoLog)
 	}
 
 	// Optional blob & L2 fee fields
-	var blobGasUsed *uint64
-	if r.BlobGasUsed != "" {
-		bg, err := NumberishToUint64(r.BlobGasUsed)
-		if err != nil {
-			return nil, err
-		}
-		blobGasUsed = &bg
-	}
-	var gasUsedForL1 *uint64
-	if r.GasUsedForL1 != "" {
-		gu, err := NumberishToUint64(r.GasUsedForL1)
-		if err != nil {
-			return nil, err
-		}
-		gasUsedForL1 = &gu
-	}
-	var l1BlockNumber *uint64
-	if r.L1BlockNumber != "" {
-		bn, err := NumberishToUint64(r.L1BlockNumber)
-		if err != nil {
-			return nil, err
-		}
-		l1BlockNumber = &bn
-	}
+	blobGasUsed := p.optUint64("blobGasUsed", r.BlobGasUsed)
+	gasUsedForL1 := p.optUint64("gasUsedForL1", r.GasUsedForL1)
+	l1BlockNumber := p.optUint64("l1BlockNumber", r.L1BlockNumber)
 
 	// Scalars & decimal strings remain as-is (string pointers)
 	var l1Fee *string
@@ -536,32 +349,19 @@ func (r *JsonRpcReceipt) ToProto() (*Receipt, error) {
 	}
 	var l1FeeScalar *float64
 	if r.L1FeeScalar != "" {
-		f, err := strconv.ParseFloat(r.L1FeeScalar, 64)
-		if err != nil {
-			return nil, err
-		}
-		l1FeeScalar = &f
-	}
-	var l1BaseFeeScalar *uint64
-	if r.L1BaseFeeScalar != "" {
-		scl, err := NumberishToUint64(r.L1BaseFeeScalar)
-		if err != nil {
-			return nil, err
+		f, ok := cfg.parseL1FeeScalar(r.L1FeeScalar)
+		if ok {
+			l1FeeScalar = &f
+		} else {
+			p.fail("l1FeeScalar", fmt.Errorf("%q does not match the %s dialect", r.L1FeeScalar, cfg.dialect.Name))
 		}
-		l1BaseFeeScalar = &scl
 	}
+	l1BaseFeeScalar := p.optUint64("l1BaseFeeScalar", r.L1BaseFeeScalar)
 	var l1BlobBaseFee *string
 	if r.L1BlobBaseFee != "" {
 		l1BlobBaseFee = &r.L1BlobBaseFee
 	}
-	var l1BlobBaseFeeScalar *uint64
-	if r.L1BlobBaseFeeScalar != "" {
-		scl, err := NumberishToUint64(r.L1BlobBaseFeeScalar)
-		if err != nil {
-			return nil, err
-		}
-		l1BlobBaseFeeScalar = &scl
-	}
+	l1BlobBaseFeeScalar := p.optUint64("l1BlobBaseFeeScalar", r.L1BlobBaseFeeScalar)
 	var gatewayFee *string
 	if r.GatewayFee != "" {
 		gatewayFee = &r.GatewayFee
@@ -581,20 +381,14 @@ func (r *JsonRpcReceipt) ToProto() (*Receipt, error) {
 		blobGasPrice = &bp
 	}
 
-	var blockTimestamp *uint64
-	if r.BlockTimestamp != "" {
-		bt, err := NumberishToUint64(r.BlockTimestamp)
-		if err == nil {
-			blockTimestamp = &bt
-		}
-	}
+	blockTimestamp := p.optUint64("blockTimestamp", r.BlockTimestamp)
 
 	var timeboosted *bool
 	if r.Timeboosted != nil {
 		timeboosted = r.Timeboosted
 	}
 
-	return &Receipt{
+	receipt := &Receipt{
 		TransactionHash:       transactionHash,
 		BlockNumber:           blockNumber,
 		BlockHash:             blockHash,
@@ -626,7 +420,13 @@ func (r *JsonRpcReceipt) ToProto() (*Receipt, error) {
 		DepositNonce:          depositNonce,
 		DepositReceiptVersion: depositReceiptVersion,
 		Timeboosted:           timeboosted,
-	}, nil
+		Extensions:            r.Extensions,
+	}
+
+	// Attach chain extensions, keeping the flat fields above for consumers not yet migrated
+	PopulateReceiptExtensions(receipt, cfg.dialect.Ecosystem)
+	setReceiptU256Fields(p, receipt)
+	return receipt
 }
 
 type JsonRpcLog struct {
@@ -639,64 +439,38 @@ type JsonRpcLog struct {
 	Topics           []string `json:"topics"`
 	TransactionHash  string   `json:"transactionHash"`
 	TransactionIndex string   `json:"transactionIndex"`
+	Removed          bool     `json:"removed"`
+	// Extensions holds undeclared fields captured by UnmarshalJSON as raw JSON text.
+	Extensions map[string]string `json:"-"`
 }
 
 func (l *JsonRpcLog) ToProto() (*Log, error) {
-	address, err := HexToBytes(l.Address)
-	if err != nil {
+	p := newFieldParser(false)
+	log := l.toProto(p)
+	if err := p.err(); err != nil {
 		return nil, err
 	}
-	blockNumber, err := NumberishToUint64(l.BlockNumber)
-	if err != nil {
-		return nil, err
-	}
-	var blockTimestamp *uint64
-	if l.BlockTimestamp != "" {
-		u, err := NumberishToUint64(l.BlockTimestamp)
-		if err != nil {
-			return nil, err
-		}
-		blockTimestamp = &u
-	}
-	data, err := HexToBytes(l.Data)
-	if err != nil {
-		return nil, err
-	}
-	logIndex, err := NumberishToUint32(l.LogIndex)
-	if err != nil {
-		return nil, err
-	}
-	transactionIndex, err := NumberishToUint32(l.TransactionIndex)
-	if err != nil {
-		return nil, err
-	}
-	transactionHash, err := HexToBytes(l.TransactionHash)
-	if err != nil {
-		return nil, err
-	}
-	blockHash, err := HexToBytes(l.BlockHash)
-	if err != nil {
-		return nil, err
-	}
-	topics := make([][]byte, 0, len(l.Topics))
-	for _, topic := range l.Topics {
-		topicBytes, err := HexToBytes(topic)
-		if err != nil {
-			return nil, err
-		}
-		topics = append(topics, topicBytes)
+	return log, nil
+}
+
+func (l *JsonRpcLog) toProto(p *fieldParser) *Log {
+	topics := p.bytesList("topics", l.Topics)
+	if topics == nil {
+		topics = [][]byte{}
 	}
 	return &Log{
-		Address:          address,
-		BlockHash:        blockHash,
-		BlockNumber:      blockNumber,
-		BlockTimestamp:   blockTimestamp,
-		Data:             data,
-		LogIndex:         logIndex,
+		Address:          p.bytes("address", l.Address),
+		BlockHash:        p.bytes("blockHash", l.BlockHash),
+		BlockNumber:      p.uint64("blockNumber", l.BlockNumber),
+		BlockTimestamp:   p.optUint64("blockTimestamp", l.BlockTimestamp),
+		Data:             p.bytes("data", l.Data),
+		LogIndex:         p.uint32("logIndex", l.LogIndex),
 		Topics:           topics,
-		TransactionHash:  transactionHash,
-		TransactionIndex: transactionIndex,
-	}, nil
+		TransactionHash:  p.bytes("transactionHash", l.TransactionHash),
+		TransactionIndex: p.uint32("transactionIndex", l.TransactionIndex),
+		Removed:          l.Removed,
+		Extensions:       l.Extensions,
+	}
 }
 
 // LogToJsonRpc serialises a *Log into a JSON-RPC compatible map[string]interface{}.
@@ -718,13 +492,14 @@ func LogToJsonRpc(l *Log) map[string]interface{} {
 		"transactionIndex": fmt.Sprintf("0x%x", l.TransactionIndex),
 		"blockHash":        BytesToHex(l.BlockHash),
 		"logIndex":         fmt.Sprintf("0x%x", l.LogIndex),
-		"removed":          false,
+		"removed":          l.Removed,
 	}
 
 	// Include optional block timestamp when present
 	if l.BlockTimestamp != nil {
 		result["blockTimestamp"] = fmt.Sprintf("0x%x", *l.BlockTimestamp)
 	}
+	addExtensions(result, l.Extensions)
 
 	return result
 }
@@ -739,10 +514,12 @@ func LogsToJsonRpc(logs []*Log) []interface{} {
 }
 
 // TransactionToJsonRpc converts a *Transaction into JSON-RPC representation.
-func TransactionToJsonRpc(tx *Transaction) map[string]interface{} {
+func TransactionToJsonRpc(tx *Transaction, opts ...ConvertOption) map[string]interface{} {
 	if tx == nil {
 		return nil
 	}
+	cfg := newConvertConfig(opts)
+	tx = transactionWithFlatFields(withU256Strings(tx))
 
 	o := map[string]interface{}{
 		"hash":  BytesToHex(tx.Hash),
@@ -826,11 +603,13 @@ func TransactionToJsonRpc(tx *Transaction) map[string]interface{} {
 		o["chainId"] = nil
 	}
 
-	// yParity (optional)
-	if tx.YParity != nil {
-		o["yParity"] = fmt.Sprintf("0x%x", *tx.YParity)
-	} else {
-		o["yParity"] = nil
+	// yParity (optional, not emitted by v-only dialects)
+	if cfg.dialect.Signature != SignatureVOnly {
+		if tx.YParity != nil {
+			o["yParity"] = fmt.Sprintf("0x%x", *tx.YParity)
+		} else {
+			o["yParity"] = nil
+		}
 	}
 
 	// Access list (EIP-2930)
@@ -922,7 +701,7 @@ func TransactionToJsonRpc(tx *Transaction) map[string]interface{} {
 		}
 	}
 	if tx.L1FeeScalar != nil {
-		o["l1FeeScalar"] = *tx.L1FeeScalar
+		o["l1FeeScalar"] = cfg.formatL1FeeScalar(*tx.L1FeeScalar)
 	}
 	if tx.L1BlobBaseFee != nil {
 		if hex, err := DecimalStringToHex(*tx.L1BlobBaseFee); err == nil {
@@ -1004,23 +783,47 @@ func TransactionToJsonRpc(tx *Transaction) map[string]interface{} {
 		}
 	}
 
+	// OP stack deposit fields
+	if tx.Optimism != nil {
+		if len(tx.Optimism.SourceHash) > 0 {
+			o["sourceHash"] = BytesToHex(tx.Optimism.SourceHash)
+		}
+		if tx.Optimism.Mint != nil {
+			if hex, err := DecimalStringToHex(*tx.Optimism.Mint); err == nil {
+				o["mint"] = hex
+			}
+		}
+	}
+
+	// zkSync batch fields
+	if tx.ZkSync != nil {
+		if tx.ZkSync.L1BatchNumber != nil {
+			o["l1BatchNumber"] = fmt.Sprintf("0x%x", *tx.ZkSync.L1BatchNumber)
+		}
+		if tx.ZkSync.L1BatchTxIndex != nil {
+			o["l1BatchTxIndex"] = fmt.Sprintf("0x%x", *tx.ZkSync.L1BatchTxIndex)
+		}
+	}
+
 	return o
 }
 
 // ReceiptsToJsonRpc converts a slice of *Receipt to []interface{} for JSON-RPC responses.
-func ReceiptsToJsonRpc(receipts []*Receipt) []interface{} {
+func ReceiptsToJsonRpc(receipts []*Receipt, opts ...ConvertOption) []interface{} {
 	out := make([]interface{}, len(receipts))
 	for i, r := range receipts {
-		out[i] = ReceiptToJsonRpc(r)
+		out[i] = ReceiptToJsonRpc(r, opts...)
 	}
 	return out
 }
 
 // ReceiptToJsonRpc converts a *Receipt into JSON-RPC representation.
-func ReceiptToJsonRpc(r *Receipt) map[string]interface{} {
+func ReceiptToJsonRpc(r *Receipt, opts ...ConvertOption) map[string]interface{} {
 	if r == nil {
 		return nil
 	}
+	cfg := newConvertConfig(opts)
+	r = receiptWithFlatFields(withU256Strings(r))
 
 	out := map[string]interface{}{
 		"transactionHash":   BytesToHex(r.TransactionHash),
@@ -1111,7 +914,7 @@ func ReceiptToJsonRpc(r *Receipt) map[string]interface{} {
 		}
 	}
 	if r.L1FeeScalar != nil {
-		out["l1FeeScalar"] = *r.L1FeeScalar
+		out["l1FeeScalar"] = cfg.formatL1FeeScalar(*r.L1FeeScalar)
 	}
 	if r.L1BaseFeeScalar != nil {
 		out["l1BaseFeeScalar"] = fmt.Sprintf("0x%x", *r.L1BaseFeeScalar)
@@ -1137,6 +940,7 @@ func ReceiptToJsonRpc(r *Receipt) map[string]interface{} {
 	if r.Timeboosted != nil {
 		out["timeboosted"] = *r.Timeboosted
 	}
+	addExtensions(out, r.Extensions)
 
 	return out
 }
@@ -1167,10 +971,12 @@ func WithdrawalsToJsonRpc(withdrawals []*Withdrawal) []interface{} {
 // BlockToJsonRpc converts an EVM BlockHeader plus optional transactions to JSON-RPC format.
 // If fullTxs is supplied it will be used; otherwise transaction hashes are used.
 // If withdrawals are supplied, they will be included in the response.
-func BlockToJsonRpc(header *BlockHeader, txHashes [][]byte, fullTxs []*Transaction, withdrawals []*Withdrawal) map[string]interface{} {
+func BlockToJsonRpc(header *BlockHeader, txHashes [][]byte, fullTxs []*Transaction, withdrawals []*Withdrawal, opts ...ConvertOption) map[string]interface{} {
 	if header == nil {
 		return nil
 	}
+	header = withU256Strings(header)
+	cfg := newConvertConfig(opts)
 
 	res := map[string]interface{}{
 		"number":           fmt.Sprintf("0x%x", header.Number),
@@ -1183,14 +989,16 @@ func BlockToJsonRpc(header *BlockHeader, txHashes [][]byte, fullTxs []*Transacti
 		"receiptsRoot":     BytesToHex(header.ReceiptsRoot),
 		"miner":            BytesToHex(header.Miner),
 		"extraData":        BytesToHex(header.ExtraData),
-		"size":             fmt.Sprintf("0x%x", header.Size),
 		"gasLimit":         fmt.Sprintf("0x%x", header.GasLimit),
 		"gasUsed":          fmt.Sprintf("0x%x", header.GasUsed),
 		"timestamp":        fmt.Sprintf("0x%x", header.Timestamp),
 	}
 
+	if header.Size != 0 || !cfg.dialect.SizeOptional {
+		res["size"] = fmt.Sprintf("0x%x", header.Size)
+	}
 	if header.Nonce != nil {
-		res["nonce"] = fmt.Sprintf("0x%016x", *header.Nonce)
+		res["nonce"] = cfg.formatBlockNonce(*header.Nonce)
 	}
 	if header.BaseFeePerGas != nil {
 		if hex, err := DecimalStringToHex(*header.BaseFeePerGas); err == nil {
@@ -1273,7 +1081,7 @@ func BlockToJsonRpc(header *BlockHeader, txHashes [][]byte, fullTxs []*Transacti
 	case len(fullTxs) > 0:
 		txs := make([]interface{}, len(fullTxs))
 		for i, t := range fullTxs {
-			txs[i] = TransactionToJsonRpc(t)
+			txs[i] = TransactionToJsonRpc(t, opts...)
 		}
 		res["transactions"] = txs
 	case len(txHashes) > 0:
@@ -1285,228 +1093,210 @@ func BlockToJsonRpc(header *BlockHeader, txHashes [][]byte, fullTxs []*Transacti
 	default:
 		res["transactions"] = []interface{}{}
 	}
+	addExtensions(res, header.Extensions)
 
 	return res
 }
 
-// ParseJsonRpcTransaction parses a JSON-RPC transaction into a proto Transaction.
-// This handles all transaction types including legacy, EIP-1559, EIP-2930, EIP-4844, and EIP-7702.
-func ParseJsonRpcTransaction(txMap map[string]interface{}, header *BlockHeader) (*Transaction, error) {
-	// Helper function to get string from map
-	getString := func(key string) string {
-		if v, ok := txMap[key]; ok {
-			if s, ok := v.(string); ok {
-				return s
-			}
-		}
-		return ""
-	}
+type JsonRpcAccessListItem struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
 
-	// Helper function to get optional string from map
-	getOptionalString := func(key string) *string {
-		if v, ok := txMap[key]; ok {
-			if s, ok := v.(string); ok && s != "" {
-				return &s
-			}
-		}
-		return nil
+func (a *JsonRpcAccessListItem) ToProto() (*AccessListItem, error) {
+	p := newFieldParser(false)
+	item := a.toProto(p)
+	if err := p.err(); err != nil {
+		return nil, err
 	}
+	return item, nil
+}
 
-	// Parse required fields
-	hash, err := HexToBytes(getString("hash"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse hash: %w", err)
+func (a *JsonRpcAccessListItem) toProto(p *fieldParser) *AccessListItem {
+	var storageKeys [][]byte
+	if len(a.StorageKeys) > 0 {
+		storageKeys = p.bytesList("storageKeys", a.StorageKeys)
 	}
-
-	nonce, err := NumberishToUint64(getString("nonce"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse nonce: %w", err)
+	return &AccessListItem{
+		Address:     p.bytes("address", a.Address),
+		StorageKeys: storageKeys,
 	}
+}
 
-	from, err := HexToBytes(getString("from"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse from: %w", err)
-	}
+type JsonRpcAuthorization struct {
+	ChainId   string `json:"chainId"`
+	Address   string `json:"address"`
+	Nonce     string `json:"nonce"`
+	R         string `json:"r"`
+	S         string `json:"s"`
+	YParity   string `json:"yParity"`
+	Authority string `json:"authority"`
+}
 
-	var to []byte
-	toStr := getString("to")
-	if toStr != "" && toStr != "0x" {
-		to, err = HexToBytes(toStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse to: %w", err)
-		}
+func (a *JsonRpcAuthorization) ToProto() (*AuthorizationListItem, error) {
+	p := newFieldParser(false)
+	auth := a.toProto(p)
+	if err := p.err(); err != nil {
+		return nil, err
 	}
+	return auth, nil
+}
 
-	value := getString("value")
-	if value == "" {
-		value = "0"
+func (a *JsonRpcAuthorization) toProto(p *fieldParser) *AuthorizationListItem {
+	return &AuthorizationListItem{
+		ChainId: p.uint64("chainId", a.ChainId),
+		Address: p.bytes("address", a.Address),
+		Nonce:   p.uint64("nonce", a.Nonce),
+		R:       p.bytes("r", a.R),
+		S:       p.bytes("s", a.S),
+		YParity: p.uint32("yParity", a.YParity),
+		// Optional authority field
+		Authority: p.optBytes("authority", a.Authority),
 	}
+}
 
-	input, err := HexToBytes(getString("input"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse input: %w", err)
-	}
+// JsonRpcTransaction is a transaction object as returned by eth_getTransactionByHash,
+// eth_getBlockByNumber (with full transactions) and similar methods.
+// It covers all transaction types including legacy, EIP-2930, EIP-1559, EIP-4844 and EIP-7702,
+// plus the L2-specific fields of Optimism/Base, Arbitrum and Celo.
+type JsonRpcTransaction struct {
+	Hash                  string                   `json:"hash"`
+	Nonce                 string                   `json:"nonce"`
+	From                  string                   `json:"from"`
+	To                    string                   `json:"to"`
+	Value                 string                   `json:"value"`
+	Input                 string                   `json:"input"`
+	Gas                   string                   `json:"gas"`
+	GasPrice              string                   `json:"gasPrice"`
+	MaxFeePerGas          string                   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas  string                   `json:"maxPriorityFeePerGas"`
+	Type                  string                   `json:"type"`
+	ChainId               string                   `json:"chainId"`
+	R                     string                   `json:"r"`
+	S                     string                   `json:"s"`
+	V                     string                   `json:"v"`
+	YParity               string                   `json:"yParity"`
+	BlockNumber           string                   `json:"blockNumber"`
+	BlockHash             string                   `json:"blockHash"`
+	TransactionIndex      string                   `json:"transactionIndex"`
+	BlockTimestamp        string                   `json:"blockTimestamp"`
+	AccessList            []*JsonRpcAccessListItem `json:"accessList"`
+	MaxFeePerBlobGas      string                   `json:"maxFeePerBlobGas"`
+	BlobVersionedHashes   []string                 `json:"blobVersionedHashes"`
+	AuthorizationList     []*JsonRpcAuthorization  `json:"authorizationList"`
+	GasUsed               string                   `json:"gasUsed"`
+	EffectiveGasPrice     string                   `json:"effectiveGasPrice"`
+	BlobGasUsed           string                   `json:"blobGasUsed"`
+	BlobGasPrice          string                   `json:"blobGasPrice"`
+	L1Fee                 string                   `json:"l1Fee"`
+	L1GasPrice            string                   `json:"l1GasPrice"`
+	L1GasUsed             string                   `json:"l1GasUsed"`
+	L1FeeScalar           string                   `json:"l1FeeScalar"`
+	L1BlobBaseFee         string                   `json:"l1BlobBaseFee"`
+	L1BlobBaseFeeScalar   string                   `json:"l1BlobBaseFeeScalar"`
+	GatewayFee            string                   `json:"gatewayFee"`
+	FeeCurrency           string                   `json:"feeCurrency"`
+	GatewayFeeRecipient   string                   `json:"gatewayFeeRecipient"`
+	Beneficiary           string                   `json:"beneficiary"`
+	DepositValue          string                   `json:"depositValue"`
+	L1BaseFee             string                   `json:"l1BaseFee"`
+	MaxSubmissionFee      string                   `json:"maxSubmissionFee"`
+	RefundTo              string                   `json:"refundTo"`
+	RequestId             string                   `json:"requestId"`
+	RetryData             string                   `json:"retryData"`
+	RetryTo               string                   `json:"retryTo"`
+	RetryValue            string                   `json:"retryValue"`
+	MaxRefund             string                   `json:"maxRefund"`
+	SubmissionFeeRefund   string                   `json:"submissionFeeRefund"`
+	TicketId              string                   `json:"ticketId"`
+	IsSystemTx            *bool                    `json:"isSystemTx"`
+	DepositReceiptVersion string                   `json:"depositReceiptVersion"`
+	L1BatchNumber         string                   `json:"l1BatchNumber"`
+	L1BatchTxIndex        string                   `json:"l1BatchTxIndex"`
+	SourceHash            string                   `json:"sourceHash"`
+	Mint                  string                   `json:"mint"`
+}
 
-	gasLimit, err := NumberishToUint64(getString("gas"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse gas: %w", err)
+// ToProto converts the JSON-RPC transaction into a proto Transaction.
+// Block context (number, hash, timestamp) is taken from the transaction object itself;
+// use JsonRpcBlock.ToProto to inherit it from the enclosing block.
+func (t *JsonRpcTransaction) ToProto(opts ...ConvertOption) (*Transaction, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	tx := t.toProto(p, nil, cfg)
+	if err := p.err(); err != nil {
+		return nil, err
 	}
+	return tx, nil
+}
 
-	r, err := HexToBytes(getString("r"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse r: %w", err)
-	}
+func (t *JsonRpcTransaction) toProto(p *fieldParser, header *BlockHeader, cfg *convertConfig) *Transaction {
+	cfg.checkFields(p, "transaction", t, nil, standardTransactionFields, cfg.dialect.TransactionFields)
 
-	sSig, err := HexToBytes(getString("s"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse s: %w", err)
-	}
+	// Parse required fields
+	hash := p.bytes("hash", t.Hash)
+	nonce := p.uint64("nonce", t.Nonce)
+	from := p.bytes("from", t.From)
 
-	var v []byte
-	vStr := getString("v")
-	if vStr != "" {
-		v, err = HexToBytes(vStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse v: %w", err)
-		}
+	var to []byte
+	if t.To != "0x" {
+		to = p.optBytes("to", t.To)
 	}
 
-	typ, err := NumberishToUint32(getString("type"))
-	if err != nil {
-		// Default to legacy type 0 if not specified
-		typ = 0
+	value := t.Value
+	if value == "" {
+		value = "0"
 	}
 
-	var chainId *uint64
-	chainIdStr := getString("chainId")
-	if chainIdStr != "" {
-		cid, err := NumberishToUint64(chainIdStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse chainId: %w", err)
-		}
-		chainId = &cid
+	input := p.bytes("input", t.Input)
+	gasLimit := p.uint64("gas", t.Gas)
+	r := p.bytes("r", t.R)
+	sSig := p.bytes("s", t.S)
+	v := p.optBytes("v", t.V)
+
+	// Default to legacy type 0 if not specified
+	var typ uint32
+	if t.Type != "" {
+		typ = p.uint32("type", t.Type)
 	}
 
+	chainId := p.optUint64("chainId", t.ChainId)
+
 	var yParity *uint32
-	yParityStr := getString("yParity")
-	if yParityStr != "" {
-		yp, err := NumberishToUint32(yParityStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse yParity: %w", err)
+	if t.YParity != "" {
+		yParity = p.optUint32("yParity", t.YParity)
+	} else if typ != 0 && t.V != "" && len(v) <= 1 {
+		// Clients that only emit v (see SignatureVOnly) carry the parity in v for typed transactions
+		var yp uint32
+		if len(v) == 1 {
+			yp = uint32(v[0])
+		}
+		if yp <= 1 {
+			yParity = &yp
 		}
-		yParity = &yp
 	}
 
-	// Parse access list
+	// Parse access list (EIP-2930)
 	var accessList []*AccessListItem
-	if alRaw, ok := txMap["accessList"]; ok {
-		if alArray, ok := alRaw.([]interface{}); ok {
-			for _, alItem := range alArray {
-				if alMap, ok := alItem.(map[string]interface{}); ok {
-					// Get address from access list item map
-					alAddr := ""
-					if addrVal, ok := alMap["address"]; ok {
-						if addrStr, ok := addrVal.(string); ok {
-							alAddr = addrStr
-						}
-					}
-
-					addr, err := HexToBytes(alAddr)
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse access list address: %w", err)
-					}
-
-					var storageKeys [][]byte
-					if skRaw, ok := alMap["storageKeys"]; ok {
-						if skArray, ok := skRaw.([]interface{}); ok {
-							for _, sk := range skArray {
-								if skStr, ok := sk.(string); ok {
-									skBytes, err := HexToBytes(skStr)
-									if err != nil {
-										return nil, fmt.Errorf("failed to parse storage key: %w", err)
-									}
-									storageKeys = append(storageKeys, skBytes)
-								}
-							}
-						}
-					}
-
-					accessList = append(accessList, &AccessListItem{
-						Address:     addr,
-						StorageKeys: storageKeys,
-					})
-				}
-			}
+	for i, item := range t.AccessList {
+		if item == nil {
+			continue
+		}
+		accessList = append(accessList, item.toProto(p.item("accessList", i)))
+		if p.done() {
+			break
 		}
 	}
 
 	// Parse authorization list (EIP-7702)
 	var authorizationList []*AuthorizationListItem
-	if authRaw, ok := txMap["authorizationList"]; ok {
-		if authArray, ok := authRaw.([]interface{}); ok {
-			for _, authItem := range authArray {
-				if authMap, ok := authItem.(map[string]interface{}); ok {
-					// Helper to get string from auth map
-					getAuthString := func(key string) string {
-						if v, ok := authMap[key]; ok {
-							if s, ok := v.(string); ok {
-								return s
-							}
-						}
-						return ""
-					}
-
-					authChainId, err := NumberishToUint64(getAuthString("chainId"))
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse authorization chainId: %w", err)
-					}
-
-					authAddress, err := HexToBytes(getAuthString("address"))
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse authorization address: %w", err)
-					}
-
-					authNonce, err := NumberishToUint64(getAuthString("nonce"))
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse authorization nonce: %w", err)
-					}
-
-					authR, err := HexToBytes(getAuthString("r"))
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse authorization r: %w", err)
-					}
-
-					authS, err := HexToBytes(getAuthString("s"))
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse authorization s: %w", err)
-					}
-
-					authYParity, err := NumberishToUint32(getAuthString("yParity"))
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse authorization yParity: %w", err)
-					}
-
-					// Optional authority field
-					var authAuthority []byte
-					if authAuthorityStr := getAuthString("authority"); authAuthorityStr != "" {
-						if b, err := HexToBytes(authAuthorityStr); err == nil {
-							authAuthority = b
-						} else {
-							return nil, fmt.Errorf("failed to parse authorization authority: %w", err)
-						}
-					}
-
-					authorizationList = append(authorizationList, &AuthorizationListItem{
-						ChainId: authChainId,
-						Address: authAddress,
-						Nonce:   authNonce,
-						R:       authR,
-						S:       authS,
-						YParity: authYParity,
-						Authority: authAuthority,
-					})
-				}
-			}
+	for i, auth := range t.AuthorizationList {
+		if auth == nil {
+			continue
+		}
+		authorizationList = append(authorizationList, auth.toProto(p.item("authorizationList", i)))
+		if p.done() {
+			break
 		}
 	}
 
@@ -1523,40 +1313,21 @@ func ParseJsonRpcTransaction(txMap map[string]interface{}, header *BlockHeader)
 	}
 
 	// Override with explicit block info if present in transaction
-	if blockNumStr := getString("blockNumber"); blockNumStr != "" {
-		bn, err := NumberishToUint64(blockNumStr)
-		if err == nil {
-			blockNumber = &bn
-		}
+	if bn := p.optUint64("blockNumber", t.BlockNumber); bn != nil {
+		blockNumber = bn
 	}
-
-	if blockHashStr := getString("blockHash"); blockHashStr != "" {
-		if bh, err := HexToBytes(blockHashStr); err == nil {
-			blockHash = bh
-		}
+	if bh := p.optBytes("blockHash", t.BlockHash); bh != nil {
+		blockHash = bh
 	}
-
-	if txIndexStr := getString("transactionIndex"); txIndexStr != "" {
-		ti, err := NumberishToUint32(txIndexStr)
-		if err == nil {
-			transactionIndex = &ti
-		}
+	transactionIndex = p.optUint32("transactionIndex", t.TransactionIndex)
+	if bt := p.optUint64("blockTimestamp", t.BlockTimestamp); bt != nil {
+		blockTimestamp = bt
 	}
 
 	// Parse blob fields
 	var blobVersionedHashes [][]byte
-	if bvhRaw, ok := txMap["blobVersionedHashes"]; ok {
-		if bvhArray, ok := bvhRaw.([]interface{}); ok {
-			for _, bvh := range bvhArray {
-				if bvhStr, ok := bvh.(string); ok {
-					bvhBytes, err := HexToBytes(bvhStr)
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse blob versioned hash: %w", err)
-					}
-					blobVersionedHashes = append(blobVersionedHashes, bvhBytes)
-				}
-			}
-		}
+	if len(t.BlobVersionedHashes) > 0 {
+		blobVersionedHashes = p.bytesList("blobVersionedHashes", t.BlobVersionedHashes)
 	}
 
 	// Build transaction
@@ -1569,9 +1340,9 @@ func ParseJsonRpcTransaction(txMap map[string]interface{}, header *BlockHeader)
 		Input:                input,
 		Type:                 typ,
 		GasLimit:             gasLimit,
-		GasPrice:             getOptionalString("gasPrice"),
-		MaxFeePerGas:         getOptionalString("maxFeePerGas"),
-		MaxPriorityFeePerGas: getOptionalString("maxPriorityFeePerGas"),
+		GasPrice:             optionalString(t.GasPrice),
+		MaxFeePerGas:         optionalString(t.MaxFeePerGas),
+		MaxPriorityFeePerGas: optionalString(t.MaxPriorityFeePerGas),
 		R:                    r,
 		S:                    sSig,
 		V:                    v,
@@ -1584,140 +1355,388 @@ func ParseJsonRpcTransaction(txMap map[string]interface{}, header *BlockHeader)
 		AccessList:           accessList,
 		BlobVersionedHashes:  blobVersionedHashes,
 		AuthorizationList:    authorizationList,
-		MaxFeePerBlobGas:     getOptionalString("maxFeePerBlobGas"),
+		MaxFeePerBlobGas:     optionalString(t.MaxFeePerBlobGas),
 	}
 
 	// Add L2 fee fields
-	tx.L1Fee = getOptionalString("l1Fee")
-	tx.L1GasPrice = getOptionalString("l1GasPrice")
-	tx.L1GasUsed = getOptionalString("l1GasUsed")
+	tx.L1Fee = optionalString(t.L1Fee)
+	tx.L1GasPrice = optionalString(t.L1GasPrice)
+	tx.L1GasUsed = optionalString(t.L1GasUsed)
 
-	if l1FeeScalarStr := getString("l1FeeScalar"); l1FeeScalarStr != "" {
-		scl, err := strconv.ParseFloat(l1FeeScalarStr, 64)
-		if err == nil {
+	if t.L1FeeScalar != "" {
+		scl, ok := cfg.parseL1FeeScalar(t.L1FeeScalar)
+		if ok {
 			tx.L1FeeScalar = &scl
+		} else {
+			p.fail("l1FeeScalar", fmt.Errorf("%q does not match the %s dialect", t.L1FeeScalar, cfg.dialect.Name))
 		}
 	}
 
-	tx.L1BlobBaseFee = getOptionalString("l1BlobBaseFee")
+	tx.L1BlobBaseFee = optionalString(t.L1BlobBaseFee)
 
-	if l1BlobBaseFeeScalarStr := getString("l1BlobBaseFeeScalar"); l1BlobBaseFeeScalarStr != "" {
-		bscl, err := NumberishToUint64(l1BlobBaseFeeScalarStr)
-		if err == nil {
-			tx.L1BlobBaseFeeScalar = &bscl
-		}
-	}
+	tx.L1BlobBaseFeeScalar = p.optUint64("l1BlobBaseFeeScalar", t.L1BlobBaseFeeScalar)
 
 	// Add gateway fee fields
-	tx.GatewayFee = getOptionalString("gatewayFee")
+	tx.GatewayFee = optionalString(t.GatewayFee)
+	tx.FeeCurrency = p.optBytes("feeCurrency", t.FeeCurrency)
+	tx.GatewayFeeRecipient = p.optBytes("gatewayFeeRecipient", t.GatewayFeeRecipient)
 
-	if fcStr := getString("feeCurrency"); fcStr != "" {
-		fc, err := HexToBytes(fcStr)
-		if err == nil {
-			tx.FeeCurrency = fc
-		}
-	}
+	// Add Arbitrum retryable ticket fields
+	tx.Beneficiary = p.optBytes("beneficiary", t.Beneficiary)
+	tx.DepositValue = optionalString(t.DepositValue)
+	tx.L1BaseFee = optionalString(t.L1BaseFee)
+	tx.MaxSubmissionFee = optionalString(t.MaxSubmissionFee)
+	tx.RefundTo = p.optBytes("refundTo", t.RefundTo)
+	tx.RequestId = p.optBytes("requestId", t.RequestId)
+	tx.RetryData = p.optBytes("retryData", t.RetryData)
+	tx.RetryTo = p.optBytes("retryTo", t.RetryTo)
+	tx.RetryValue = optionalString(t.RetryValue)
+	tx.MaxRefund = optionalString(t.MaxRefund)
+	tx.SubmissionFeeRefund = optionalString(t.SubmissionFeeRefund)
+	tx.TicketId = p.optBytes("ticketId", t.TicketId)
 
-	if gfrStr := getString("gatewayFeeRecipient"); gfrStr != "" {
-		gfr, err := HexToBytes(gfrStr)
-		if err == nil {
-			tx.GatewayFeeRecipient = gfr
+	// Parse execution result fields (only available for mined transactions)
+	tx.GasUsed = p.optUint64("gasUsed", t.GasUsed)
+
+	tx.EffectiveGasPrice = optionalString(t.EffectiveGasPrice)
+
+	// Parse blob fields
+	tx.BlobGasUsed = p.optUint64("blobGasUsed", t.BlobGasUsed)
+
+	tx.BlobGasPrice = optionalString(t.BlobGasPrice)
+
+	// Parse Base-specific fields
+	tx.IsSystemTx = t.IsSystemTx
+	tx.DepositReceiptVersion = optionalString(t.DepositReceiptVersion)
+
+	// Attach chain extensions, keeping the flat fields above for consumers not yet migrated
+	PopulateTransactionExtensions(tx, cfg.dialect.Ecosystem)
+	sourceHash := p.optBytes("sourceHash", t.SourceHash)
+	if cfg.dialect.Ecosystem.includes(EcosystemOptimism) && (len(sourceHash) > 0 || t.Mint != "") {
+		if tx.Optimism == nil {
+			tx.Optimism = &OptimismTransactionExtension{}
 		}
+		tx.Optimism.SourceHash = sourceHash
+		tx.Optimism.Mint = optionalString(t.Mint)
 	}
-
-	// Add Arbitrum retryable ticket fields
-	if benStr := getString("beneficiary"); benStr != "" {
-		beneficiary, err := HexToBytes(benStr)
-		if err == nil {
-			tx.Beneficiary = beneficiary
+	l1BatchNumber := p.optUint64("l1BatchNumber", t.L1BatchNumber)
+	l1BatchTxIndex := p.optUint64("l1BatchTxIndex", t.L1BatchTxIndex)
+	if cfg.dialect.Ecosystem.includes(EcosystemZkSync) && (l1BatchNumber != nil || l1BatchTxIndex != nil) {
+		if tx.ZkSync == nil {
+			tx.ZkSync = &ZkSyncTransactionExtension{}
 		}
+		tx.ZkSync.L1BatchNumber = l1BatchNumber
+		tx.ZkSync.L1BatchTxIndex = l1BatchTxIndex
 	}
 
-	tx.DepositValue = getOptionalString("depositValue")
-	tx.L1BaseFee = getOptionalString("l1BaseFee")
-	tx.MaxSubmissionFee = getOptionalString("maxSubmissionFee")
+	setTransactionU256Fields(p, tx)
+	return tx
+}
 
-	if refundToStr := getString("refundTo"); refundToStr != "" {
-		refundTo, err := HexToBytes(refundToStr)
-		if err == nil {
-			tx.RefundTo = refundTo
-		}
+// optionalString returns nil for empty strings so absent JSON-RPC fields stay unset in proto.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
 	}
+	return &s
+}
 
-	if requestIdStr := getString("requestId"); requestIdStr != "" {
-		requestId, err := HexToBytes(requestIdStr)
-		if err == nil {
-			tx.RequestId = requestId
-		}
+// JsonRpcBlockTransactions holds the "transactions" array of a JSON-RPC block, which is
+// either a list of transaction hashes or a list of full transaction objects depending on
+// the includeTransactions flag of the request.
+type JsonRpcBlockTransactions struct {
+	Hashes []string
+	Full   []*JsonRpcTransaction
+}
+
+func (t *JsonRpcBlockTransactions) UnmarshalJSON(data []byte) error {
+	t.Hashes = nil
+	t.Full = nil
+	// Peek at the first element to decide between hashes and full objects
+	for _, c := range data {
+		switch c {
+		case ' ', '\t', '\r', '\n', '[':
+			continue
+		case ']':
+			return nil
+		case '"':
+			return json.Unmarshal(data, &t.Hashes)
+		case '{':
+			return json.Unmarshal(data, &t.Full)
+		case 'n':
+			// null
+			return nil
+		default:
+			return fmt.Errorf("unexpected transactions element starting with %q", c)
+		}
+	}
+	return nil
+}
+
+func (t JsonRpcBlockTransactions) MarshalJSON() ([]byte, error) {
+	switch {
+	case len(t.Full) > 0:
+		return json.Marshal(t.Full)
+	case len(t.Hashes) > 0:
+		return json.Marshal(t.Hashes)
+	default:
+		return []byte("[]"), nil
 	}
+}
 
-	if retryDataStr := getString("retryData"); retryDataStr != "" {
-		retryData, err := HexToBytes(retryDataStr)
-		if err == nil {
-			tx.RetryData = retryData
-		}
+// ToProto converts the transactions array into transaction hashes and (when full objects
+// are present) proto transactions, inheriting block context from the header.
+func (t *JsonRpcBlockTransactions) ToProto(header *BlockHeader, opts ...ConvertOption) ([][]byte, []*Transaction, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	hashes, txs := t.toProto(p, header, cfg)
+	if err := p.err(); err != nil {
+		return nil, nil, err
 	}
+	return hashes, txs, nil
+}
 
-	if retryToStr := getString("retryTo"); retryToStr != "" {
-		retryTo, err := HexToBytes(retryToStr)
-		if err == nil {
-			tx.RetryTo = retryTo
+func (t *JsonRpcBlockTransactions) toProto(p *fieldParser, header *BlockHeader, cfg *convertConfig) ([][]byte, []*Transaction) {
+	if len(t.Full) > 0 {
+		hashes := make([][]byte, 0, len(t.Full))
+		txs := make([]*Transaction, 0, len(t.Full))
+		for i, jtx := range t.Full {
+			if jtx == nil {
+				continue
+			}
+			tx := jtx.toProto(p.item("transactions", i), header, cfg)
+			if p.done() {
+				break
+			}
+			txs = append(txs, tx)
+			hashes = append(hashes, tx.Hash)
 		}
+		return hashes, txs
 	}
 
-	tx.RetryValue = getOptionalString("retryValue")
-	tx.MaxRefund = getOptionalString("maxRefund")
-	tx.SubmissionFeeRefund = getOptionalString("submissionFeeRefund")
+	hashes := p.bytesList("transactions", t.Hashes)
+	if hashes == nil {
+		hashes = [][]byte{}
+	}
+	return hashes, []*Transaction{}
+}
 
-	if ticketIdStr := getString("ticketId"); ticketIdStr != "" {
-		ticketId, err := HexToBytes(ticketIdStr)
-		if err == nil {
-			tx.TicketId = ticketId
-		}
+// ParseJsonRpcTransaction parses a JSON-RPC transaction into a proto Transaction.
+// This handles all transaction types including legacy, EIP-1559, EIP-2930, EIP-4844, and EIP-7702.
+// It is kept for callers that already hold a map decoded by json.Unmarshal; prefer decoding
+// directly into JsonRpcTransaction and calling ToProto. Members of the wrong JSON type are
+// reported like invalid values, under their path; when there are any, the values themselves
+// are not checked.
+func ParseJsonRpcTransaction(txMap map[string]interface{}, header *BlockHeader, opts ...ConvertOption) (*Transaction, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	tx := parseJsonRpcTransactionMap(p, txMap, header, cfg)
+	if err := p.err(); err != nil {
+		return nil, err
 	}
+	return tx, nil
+}
 
-	// Parse execution result fields (only available for mined transactions)
-	if gasUsedStr := getString("gasUsed"); gasUsedStr != "" {
-		gasUsed, err := NumberishToUint64(gasUsedStr)
-		if err == nil {
-			tx.GasUsed = &gasUsed
-		}
+// parseJsonRpcTransactionMap returns nil when a member has the wrong JSON type, as the
+// mistyped members would be reported again as missing.
+func parseJsonRpcTransactionMap(p *fieldParser, txMap map[string]interface{}, header *BlockHeader, cfg *convertConfig) *Transaction {
+	n := len(p.errs.list)
+	jtx := jsonRpcTransactionFromMap(p, txMap)
+	if len(p.errs.list) > n {
+		return nil
 	}
+	return jtx.toProto(p, header, cfg)
+}
 
-	tx.EffectiveGasPrice = getOptionalString("effectiveGasPrice")
+// jsonRpcTransactionStringFields lists the string members of a JSON-RPC transaction with the
+// JsonRpcTransaction field holding each, in declaration order.
+var jsonRpcTransactionStringFields = []struct {
+	name  string
+	field func(t *JsonRpcTransaction) *string
+}{
+	{"hash", func(t *JsonRpcTransaction) *string { return &t.Hash }},
+	{"nonce", func(t *JsonRpcTransaction) *string { return &t.Nonce }},
+	{"from", func(t *JsonRpcTransaction) *string { return &t.From }},
+	{"to", func(t *JsonRpcTransaction) *string { return &t.To }},
+	{"value", func(t *JsonRpcTransaction) *string { return &t.Value }},
+	{"input", func(t *JsonRpcTransaction) *string { return &t.Input }},
+	{"gas", func(t *JsonRpcTransaction) *string { return &t.Gas }},
+	{"gasPrice", func(t *JsonRpcTransaction) *string { return &t.GasPrice }},
+	{"maxFeePerGas", func(t *JsonRpcTransaction) *string { return &t.MaxFeePerGas }},
+	{"maxPriorityFeePerGas", func(t *JsonRpcTransaction) *string { return &t.MaxPriorityFeePerGas }},
+	{"type", func(t *JsonRpcTransaction) *string { return &t.Type }},
+	{"chainId", func(t *JsonRpcTransaction) *string { return &t.ChainId }},
+	{"r", func(t *JsonRpcTransaction) *string { return &t.R }},
+	{"s", func(t *JsonRpcTransaction) *string { return &t.S }},
+	{"v", func(t *JsonRpcTransaction) *string { return &t.V }},
+	{"yParity", func(t *JsonRpcTransaction) *string { return &t.YParity }},
+	{"blockNumber", func(t *JsonRpcTransaction) *string { return &t.BlockNumber }},
+	{"blockHash", func(t *JsonRpcTransaction) *string { return &t.BlockHash }},
+	{"transactionIndex", func(t *JsonRpcTransaction) *string { return &t.TransactionIndex }},
+	{"blockTimestamp", func(t *JsonRpcTransaction) *string { return &t.BlockTimestamp }},
+	{"maxFeePerBlobGas", func(t *JsonRpcTransaction) *string { return &t.MaxFeePerBlobGas }},
+	{"gasUsed", func(t *JsonRpcTransaction) *string { return &t.GasUsed }},
+	{"effectiveGasPrice", func(t *JsonRpcTransaction) *string { return &t.EffectiveGasPrice }},
+	{"blobGasUsed", func(t *JsonRpcTransaction) *string { return &t.BlobGasUsed }},
+	{"blobGasPrice", func(t *JsonRpcTransaction) *string { return &t.BlobGasPrice }},
+	{"l1Fee", func(t *JsonRpcTransaction) *string { return &t.L1Fee }},
+	{"l1GasPrice", func(t *JsonRpcTransaction) *string { return &t.L1GasPrice }},
+	{"l1GasUsed", func(t *JsonRpcTransaction) *string { return &t.L1GasUsed }},
+	{"l1FeeScalar", func(t *JsonRpcTransaction) *string { return &t.L1FeeScalar }},
+	{"l1BlobBaseFee", func(t *JsonRpcTransaction) *string { return &t.L1BlobBaseFee }},
+	{"l1BlobBaseFeeScalar", func(t *JsonRpcTransaction) *string { return &t.L1BlobBaseFeeScalar }},
+	{"gatewayFee", func(t *JsonRpcTransaction) *string { return &t.GatewayFee }},
+	{"feeCurrency", func(t *JsonRpcTransaction) *string { return &t.FeeCurrency }},
+	{"gatewayFeeRecipient", func(t *JsonRpcTransaction) *string { return &t.GatewayFeeRecipient }},
+	{"beneficiary", func(t *JsonRpcTransaction) *string { return &t.Beneficiary }},
+	{"depositValue", func(t *JsonRpcTransaction) *string { return &t.DepositValue }},
+	{"l1BaseFee", func(t *JsonRpcTransaction) *string { return &t.L1BaseFee }},
+	{"maxSubmissionFee", func(t *JsonRpcTransaction) *string { return &t.MaxSubmissionFee }},
+	{"refundTo", func(t *JsonRpcTransaction) *string { return &t.RefundTo }},
+	{"requestId", func(t *JsonRpcTransaction) *string { return &t.RequestId }},
+	{"retryData", func(t *JsonRpcTransaction) *string { return &t.RetryData }},
+	{"retryTo", func(t *JsonRpcTransaction) *string { return &t.RetryTo }},
+	{"retryValue", func(t *JsonRpcTransaction) *string { return &t.RetryValue }},
+	{"maxRefund", func(t *JsonRpcTransaction) *string { return &t.MaxRefund }},
+	{"submissionFeeRefund", func(t *JsonRpcTransaction) *string { return &t.SubmissionFeeRefund }},
+	{"ticketId", func(t *JsonRpcTransaction) *string { return &t.TicketId }},
+	{"depositReceiptVersion", func(t *JsonRpcTransaction) *string { return &t.DepositReceiptVersion }},
+	{"l1BatchNumber", func(t *JsonRpcTransaction) *string { return &t.L1BatchNumber }},
+	{"l1BatchTxIndex", func(t *JsonRpcTransaction) *string { return &t.L1BatchTxIndex }},
+	{"sourceHash", func(t *JsonRpcTransaction) *string { return &t.SourceHash }},
+	{"mint", func(t *JsonRpcTransaction) *string { return &t.Mint }},
+}
 
-	// Parse blob fields
-	if blobGasUsedStr := getString("blobGasUsed"); blobGasUsedStr != "" {
-		blobGasUsed, err := NumberishToUint64(blobGasUsedStr)
-		if err == nil {
-			tx.BlobGasUsed = &blobGasUsed
+// jsonRpcTransactionFromMap copies a transaction object decoded by json.Unmarshal into a
+// JsonRpcTransaction without re-encoding it. Undeclared members are ignored, as they are by
+// json.Unmarshal; null members are left unset.
+func jsonRpcTransactionFromMap(p *fieldParser, m map[string]interface{}) *JsonRpcTransaction {
+	t := &JsonRpcTransaction{}
+	for _, f := range jsonRpcTransactionStringFields {
+		if v, ok := m[f.name]; ok {
+			*f.field(t) = mapString(p, f.name, v)
+		}
+	}
+	if v := m["isSystemTx"]; v != nil {
+		if b, ok := v.(bool); ok {
+			t.IsSystemTx = &b
+		} else {
+			p.fail("isSystemTx", mapTypeError("a boolean", v))
 		}
 	}
+	t.BlobVersionedHashes = mapStringList(p, "blobVersionedHashes", m["blobVersionedHashes"])
 
-	tx.BlobGasPrice = getOptionalString("blobGasPrice")
+	if v := m["accessList"]; v != nil {
+		items, ok := v.([]interface{})
+		if !ok {
+			p.fail("accessList", mapTypeError("an array", v))
+		}
+		for i, item := range items {
+			ip := p.item("accessList", i)
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				ip.fail("", mapTypeError("an object", item))
+				continue
+			}
+			t.AccessList = append(t.AccessList, &JsonRpcAccessListItem{
+				Address:     mapString(ip, "address", entry["address"]),
+				StorageKeys: mapStringList(ip, "storageKeys", entry["storageKeys"]),
+			})
+		}
+	}
 
-	// Parse Base-specific fields
-	if isSystemTxRaw, ok := txMap["isSystemTx"]; ok {
-		if isSystemTx, ok := isSystemTxRaw.(bool); ok {
-			tx.IsSystemTx = &isSystemTx
+	if v := m["authorizationList"]; v != nil {
+		items, ok := v.([]interface{})
+		if !ok {
+			p.fail("authorizationList", mapTypeError("an array", v))
 		}
+		for i, item := range items {
+			ip := p.item("authorizationList", i)
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				ip.fail("", mapTypeError("an object", item))
+				continue
+			}
+			t.AuthorizationList = append(t.AuthorizationList, &JsonRpcAuthorization{
+				ChainId:   mapString(ip, "chainId", entry["chainId"]),
+				Address:   mapString(ip, "address", entry["address"]),
+				Nonce:     mapString(ip, "nonce", entry["nonce"]),
+				R:         mapString(ip, "r", entry["r"]),
+				S:         mapString(ip, "s", entry["s"]),
+				YParity:   mapString(ip, "yParity", entry["yParity"]),
+				Authority: mapString(ip, "authority", entry["authority"]),
+			})
+		}
+	}
+	return t
+}
+
+// mapString returns a string member of a decoded JSON object, or "" when it is null.
+func mapString(p *fieldParser, name string, v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
 	}
+	p.fail(name, mapTypeError("a string", v))
+	return ""
+}
 
-	tx.DepositReceiptVersion = getOptionalString("depositReceiptVersion")
+// mapStringList returns an array of strings member of a decoded JSON object, or nil when it is
+// null.
+func mapStringList(p *fieldParser, name string, v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		p.fail(name, mapTypeError("an array", v))
+		return nil
+	}
+	list := make([]string, len(items))
+	for i, item := range items {
+		list[i] = mapString(p, name+"["+strconv.Itoa(i)+"]", item)
+	}
+	return list
+}
 
-	return tx, nil
+// mapTypeError describes a decoded JSON value that is not of the expected kind.
+func mapTypeError(want string, v interface{}) error {
+	got := "a value of type " + fmt.Sprintf("%T", v)
+	switch v.(type) {
+	case string:
+		got = "a string"
+	case float64, json.Number:
+		got = "a number"
+	case bool:
+		got = "a boolean"
+	case []interface{}:
+		got = "an array"
+	case map[string]interface{}:
+		got = "an object"
+	}
+	return fmt.Errorf("expected %s, got %s", want, got)
 }
 
 // ParseJsonRpcWithdrawals parses a list of JSON-RPC withdrawals into a list of proto withdrawals.
 // This is useful when constructing a evm.Block with withdrawals.
 func ParseJsonRpcWithdrawals(withdrawals []*JsonRpcWithdrawal) ([]*Withdrawal, error) {
+	p := newFieldParser(false)
+	protoWithdrawals := parseJsonRpcWithdrawals(p, withdrawals)
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return protoWithdrawals, nil
+}
+
+func parseJsonRpcWithdrawals(p *fieldParser, withdrawals []*JsonRpcWithdrawal) []*Withdrawal {
 	protoWithdrawals := make([]*Withdrawal, 0, len(withdrawals))
-	for _, withdrawal := range withdrawals {
-		protoWithdrawal, err := withdrawal.ToProto()
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse withdrawal: %w", err)
+	for i, withdrawal := range withdrawals {
+		protoWithdrawals = append(protoWithdrawals, withdrawal.toProto(p.item("withdrawals", i)))
+		if p.done() {
+			break
 		}
-		protoWithdrawals = append(protoWithdrawals, protoWithdrawal)
 	}
-	return protoWithdrawals, nil
+	return protoWithdrawals
 }
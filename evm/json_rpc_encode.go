@@ -0,0 +1,822 @@
+package evm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Streaming JSON-RPC encoders.
+//
+// The Append* functions write the canonical JSON-RPC encoding of the proto types directly
+// into a byte slice, without building intermediate maps. The output is byte-for-byte identical
+// to json.Marshal of the corresponding ...ToJsonRpc map: keys are emitted in sorted order and
+// values use the same hex formatting, so every encoder below lists its keys alphabetically.
+
+// writeChunkSize is the buffered size after which Write* functions flush to the underlying writer.
+const writeChunkSize = 64 * 1024
+
+// AppendLogJsonRpc appends the JSON-RPC encoding of a *Log to dst.
+func AppendLogJsonRpc(dst []byte, l *Log) []byte {
+	if l == nil {
+		return append(dst, "null"...)
+	}
+	o := beginJsonObject(dst)
+	o.extensions(l.Extensions)
+	o.hex("address", l.Address)
+	o.hex("blockHash", l.BlockHash)
+	o.quantity("blockNumber", l.BlockNumber)
+	if l.BlockTimestamp != nil {
+		o.quantity("blockTimestamp", *l.BlockTimestamp)
+	}
+	o.hex("data", l.Data)
+	o.quantity("logIndex", uint64(l.LogIndex))
+	o.boolean("removed", l.Removed)
+	o.key("topics")
+	o.buf = appendHexArray(o.buf, l.Topics)
+	o.hex("transactionHash", l.TransactionHash)
+	o.quantity("transactionIndex", uint64(l.TransactionIndex))
+	return o.end()
+}
+
+// AppendLogsJsonRpc appends a JSON array of logs to dst.
+func AppendLogsJsonRpc(dst []byte, logs []*Log) []byte {
+	dst = append(dst, '[')
+	for i, l := range logs {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = AppendLogJsonRpc(dst, l)
+	}
+	return append(dst, ']')
+}
+
+// WriteLogsJsonRpc streams a JSON array of logs to w, flushing in chunks so that
+// large eth_getLogs responses are never buffered in full.
+func WriteLogsJsonRpc(w io.Writer, logs []*Log) error {
+	return writeJsonRpcArray(w, len(logs), func(dst []byte, i int) []byte {
+		return AppendLogJsonRpc(dst, logs[i])
+	})
+}
+
+// AppendTransactionJsonRpc appends the JSON-RPC encoding of a *Transaction to dst.
+func AppendTransactionJsonRpc(dst []byte, tx *Transaction, opts ...ConvertOption) []byte {
+	return appendTransactionJsonRpc(dst, tx, newConvertConfig(opts))
+}
+
+func appendTransactionJsonRpc(dst []byte, tx *Transaction, cfg *convertConfig) []byte {
+	if tx == nil {
+		return append(dst, "null"...)
+	}
+	l2 := flatTransactionL2Fields(tx)
+	o := beginJsonObject(dst)
+
+	o.key("accessList")
+	o.buf = append(o.buf, '[')
+	n := 0
+	for _, item := range tx.AccessList {
+		if item == nil {
+			continue
+		}
+		if n > 0 {
+			o.buf = append(o.buf, ',')
+		}
+		n++
+		lo := beginJsonObject(o.buf)
+		lo.hex("address", item.Address)
+		lo.key("storageKeys")
+		lo.buf = appendHexArray(lo.buf, item.StorageKeys)
+		o.buf = lo.end()
+	}
+	o.buf = append(o.buf, ']')
+
+	if len(tx.AuthorizationList) > 0 {
+		o.key("authorizationList")
+		o.buf = append(o.buf, '[')
+		n := 0
+		for _, auth := range tx.AuthorizationList {
+			if auth == nil {
+				continue
+			}
+			if n > 0 {
+				o.buf = append(o.buf, ',')
+			}
+			n++
+			ao := beginJsonObject(o.buf)
+			ao.hex("address", auth.Address)
+			if len(auth.Authority) > 0 {
+				ao.hex("authority", auth.Authority)
+			}
+			ao.quantity("chainId", auth.ChainId)
+			ao.quantity("nonce", auth.Nonce)
+			ao.hexFixed("r", auth.R, 32)
+			ao.hexFixed("s", auth.S, 32)
+			ao.quantity("yParity", uint64(auth.YParity))
+			o.buf = ao.end()
+		}
+		o.buf = append(o.buf, ']')
+	}
+
+	if len(l2.beneficiary) > 0 {
+		o.hex("beneficiary", l2.beneficiary)
+	}
+	if tx.BlobGasPrice != nil {
+		o.numberish("blobGasPrice", *tx.BlobGasPrice)
+	}
+	if tx.BlobGasUsed != nil {
+		o.quantity("blobGasUsed", *tx.BlobGasUsed)
+	}
+	if len(tx.BlobVersionedHashes) > 0 {
+		o.key("blobVersionedHashes")
+		o.buf = appendHexArray(o.buf, tx.BlobVersionedHashes)
+	}
+	if tx.BlockHash != nil {
+		o.hex("blockHash", tx.BlockHash)
+	}
+	if tx.BlockNumber != nil {
+		o.quantity("blockNumber", *tx.BlockNumber)
+	}
+	if tx.BlockTimestamp != nil {
+		o.quantity("blockTimestamp", *tx.BlockTimestamp)
+	}
+	if tx.ChainId != nil {
+		o.quantity("chainId", *tx.ChainId)
+	} else {
+		o.null("chainId")
+	}
+	if l2.depositReceiptVersion != nil {
+		o.numberish("depositReceiptVersion", *l2.depositReceiptVersion)
+	}
+	o.wei("depositValue", l2.depositValue)
+	o.wei("effectiveGasPrice", weiField{tx.EffectiveGasPrice, tx.EffectiveGasPriceU256})
+	if len(l2.feeCurrency) > 0 {
+		o.hex("feeCurrency", l2.feeCurrency)
+	}
+	o.hex("from", tx.From)
+	o.quantity("gas", tx.GasLimit)
+	o.wei("gasPrice", weiField{tx.GasPrice, tx.GasPriceU256})
+	if tx.GasUsed != nil {
+		o.quantity("gasUsed", *tx.GasUsed)
+	}
+	o.wei("gatewayFee", l2.gatewayFee)
+	if len(l2.gatewayFeeRecipient) > 0 {
+		o.hex("gatewayFeeRecipient", l2.gatewayFeeRecipient)
+	}
+	o.hex("hash", tx.Hash)
+	o.hex("input", tx.Input)
+	if l2.isSystemTx != nil {
+		o.boolean("isSystemTx", *l2.isSystemTx)
+	}
+	o.wei("l1BaseFee", l2.l1BaseFee)
+	if tx.ZkSync != nil && tx.ZkSync.L1BatchNumber != nil {
+		o.quantity("l1BatchNumber", *tx.ZkSync.L1BatchNumber)
+	}
+	if tx.ZkSync != nil && tx.ZkSync.L1BatchTxIndex != nil {
+		o.quantity("l1BatchTxIndex", *tx.ZkSync.L1BatchTxIndex)
+	}
+	o.wei("l1BlobBaseFee", l2.l1BlobBaseFee)
+	if l2.l1BlobBaseFeeScalar != nil {
+		o.quantity("l1BlobBaseFeeScalar", *l2.l1BlobBaseFeeScalar)
+	}
+	if !o.wei("l1Fee", l2.l1Fee) {
+		o.null("l1Fee")
+	}
+	if l2.l1FeeScalar != nil {
+		o.l1FeeScalar(cfg, *l2.l1FeeScalar)
+	}
+	o.wei("l1GasPrice", l2.l1GasPrice)
+	o.wei("l1GasUsed", l2.l1GasUsed)
+	o.wei("maxFeePerBlobGas", weiField{tx.MaxFeePerBlobGas, tx.MaxFeePerBlobGasU256})
+	o.wei("maxFeePerGas", weiField{tx.MaxFeePerGas, tx.MaxFeePerGasU256})
+	o.wei("maxPriorityFeePerGas", weiField{tx.MaxPriorityFeePerGas, tx.MaxPriorityFeePerGasU256})
+	o.wei("maxRefund", l2.maxRefund)
+	o.wei("maxSubmissionFee", l2.maxSubmissionFee)
+	if ext := tx.Optimism; ext != nil {
+		o.wei("mint", weiField{ext.Mint, ext.MintU256})
+	}
+	o.quantity("nonce", tx.Nonce)
+	if tx.R != nil {
+		o.hexFixed("r", tx.R, 32)
+	}
+	if len(l2.refundTo) > 0 {
+		o.hex("refundTo", l2.refundTo)
+	}
+	if len(l2.requestId) > 0 {
+		o.hex("requestId", l2.requestId)
+	}
+	if len(l2.retryData) > 0 {
+		o.hex("retryData", l2.retryData)
+	}
+	if len(l2.retryTo) > 0 {
+		o.hex("retryTo", l2.retryTo)
+	}
+	o.wei("retryValue", l2.retryValue)
+	if tx.S != nil {
+		o.hexFixed("s", tx.S, 32)
+	}
+	if tx.Optimism != nil && len(tx.Optimism.SourceHash) > 0 {
+		o.hex("sourceHash", tx.Optimism.SourceHash)
+	}
+	o.wei("submissionFeeRefund", l2.submissionFeeRefund)
+	if len(l2.ticket) > 0 {
+		o.hex("ticketId", l2.ticket)
+	}
+	if len(tx.To) > 0 {
+		o.hex("to", tx.To)
+	} else {
+		o.null("to")
+	}
+	if tx.TransactionIndex != nil {
+		o.quantity("transactionIndex", uint64(*tx.TransactionIndex))
+	}
+	o.quantity("type", uint64(tx.Type))
+	if tx.V != nil {
+		o.key("v")
+		o.buf = appendQuotedBytesQuantity(o.buf, tx.V)
+	}
+	o.wei("value", weiField{nonEmpty(&tx.Value), tx.ValueU256})
+	if cfg.dialect.Signature != SignatureVOnly {
+		if tx.YParity != nil {
+			o.quantity("yParity", uint64(*tx.YParity))
+		} else {
+			o.null("yParity")
+		}
+	}
+
+	return o.end()
+}
+
+// AppendReceiptJsonRpc appends the JSON-RPC encoding of a *Receipt to dst.
+func AppendReceiptJsonRpc(dst []byte, r *Receipt, opts ...ConvertOption) []byte {
+	return appendReceiptJsonRpc(dst, r, newConvertConfig(opts))
+}
+
+func appendReceiptJsonRpc(dst []byte, r *Receipt, cfg *convertConfig) []byte {
+	if r == nil {
+		return append(dst, "null"...)
+	}
+	l2 := flatReceiptL2Fields(r)
+	o := beginJsonObject(dst)
+	o.extensions(r.Extensions)
+
+	o.wei("blobGasPrice", weiField{r.BlobGasPrice, r.BlobGasPriceU256})
+	if r.BlobGasUsed != nil {
+		o.quantity("blobGasUsed", *r.BlobGasUsed)
+	}
+	o.hex("blockHash", r.BlockHash)
+	o.quantity("blockNumber", r.BlockNumber)
+	if r.BlockTimestamp != nil {
+		o.quantity("blockTimestamp", *r.BlockTimestamp)
+	}
+	if len(r.ContractAddress) > 0 {
+		o.hex("contractAddress", r.ContractAddress)
+	} else {
+		o.null("contractAddress")
+	}
+	o.quantity("cumulativeGasUsed", r.CumulativeGasUsed)
+	if l2.depositNonce != nil {
+		o.numberish("depositNonce", *l2.depositNonce)
+	}
+	if l2.depositReceiptVersion != nil {
+		o.numberish("depositReceiptVersion", *l2.depositReceiptVersion)
+	}
+	o.wei("effectiveGasPrice", weiField{nonEmpty(&r.EffectiveGasPrice), r.EffectiveGasPriceU256})
+	o.hex("from", r.From)
+	o.quantity("gasUsed", r.GasUsed)
+	if l2.gasUsedForL1 != nil {
+		o.quantity("gasUsedForL1", *l2.gasUsedForL1)
+	}
+	o.wei("gatewayFee", l2.gatewayFee)
+	if l2.l1BaseFeeScalar != nil {
+		o.quantity("l1BaseFeeScalar", *l2.l1BaseFeeScalar)
+	}
+	if l2.zkSyncL1BatchNumber != nil {
+		o.quantity("l1BatchNumber", *l2.zkSyncL1BatchNumber)
+	}
+	if l2.zkSyncL1BatchTxIndex != nil {
+		o.quantity("l1BatchTxIndex", *l2.zkSyncL1BatchTxIndex)
+	}
+	o.wei("l1BlobBaseFee", l2.l1BlobBaseFee)
+	if l2.l1BlobBaseFeeScalar != nil {
+		o.quantity("l1BlobBaseFeeScalar", *l2.l1BlobBaseFeeScalar)
+	}
+	if l2.l1BlockNumber != nil {
+		o.quantity("l1BlockNumber", *l2.l1BlockNumber)
+	}
+	if !o.wei("l1Fee", l2.l1Fee) && l2.l1Fee.str == nil {
+		o.null("l1Fee")
+	}
+	if l2.l1FeeScalar != nil {
+		o.l1FeeScalar(cfg, *l2.l1FeeScalar)
+	}
+	if !o.wei("l1GasPrice", l2.l1GasPrice) && l2.l1GasPrice.str == nil {
+		o.null("l1GasPrice")
+	}
+	if l2.l1GasUsed != nil {
+		o.numberish("l1GasUsed", *l2.l1GasUsed)
+	} else {
+		o.null("l1GasUsed")
+	}
+	o.key("logs")
+	o.buf = AppendLogsJsonRpc(o.buf, r.Logs)
+	o.hex("logsBloom", r.LogsBloom)
+	if len(r.Root) > 0 {
+		o.hex("root", r.Root)
+	}
+	if r.Status != nil {
+		o.quantity("status", uint64(*r.Status))
+	}
+	if l2.timeboosted != nil {
+		o.boolean("timeboosted", *l2.timeboosted)
+	}
+	if len(r.To) > 0 {
+		o.hex("to", r.To)
+	} else {
+		o.null("to")
+	}
+	o.hex("transactionHash", r.TransactionHash)
+	o.quantity("transactionIndex", uint64(r.TransactionIndex))
+	o.quantity("type", uint64(r.Type))
+
+	return o.end()
+}
+
+// AppendReceiptsJsonRpc appends a JSON array of receipts to dst.
+func AppendReceiptsJsonRpc(dst []byte, receipts []*Receipt, opts ...ConvertOption) []byte {
+	cfg := newConvertConfig(opts)
+	dst = append(dst, '[')
+	for i, r := range receipts {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendReceiptJsonRpc(dst, r, cfg)
+	}
+	return append(dst, ']')
+}
+
+// WriteReceiptsJsonRpc streams a JSON array of receipts to w, flushing in chunks.
+func WriteReceiptsJsonRpc(w io.Writer, receipts []*Receipt, opts ...ConvertOption) error {
+	cfg := newConvertConfig(opts)
+	return writeJsonRpcArray(w, len(receipts), func(dst []byte, i int) []byte {
+		return appendReceiptJsonRpc(dst, receipts[i], cfg)
+	})
+}
+
+// AppendWithdrawalJsonRpc appends the JSON-RPC encoding of a *Withdrawal to dst.
+func AppendWithdrawalJsonRpc(dst []byte, w *Withdrawal) []byte {
+	if w == nil {
+		return append(dst, "null"...)
+	}
+	o := beginJsonObject(dst)
+	o.hex("address", w.Address)
+	o.quantity("amount", w.Amount)
+	o.quantity("index", w.Index)
+	o.quantity("validatorIndex", w.ValidatorIndex)
+	return o.end()
+}
+
+// AppendBlockJsonRpc appends the JSON-RPC encoding of a block to dst. Arguments follow
+// BlockToJsonRpc: fullTxs takes precedence over txHashes, and withdrawals are only
+// emitted when non-nil.
+func AppendBlockJsonRpc(dst []byte, header *BlockHeader, txHashes [][]byte, fullTxs []*Transaction, withdrawals []*Withdrawal, opts ...ConvertOption) []byte {
+	if header == nil {
+		return append(dst, "null"...)
+	}
+	cfg := newConvertConfig(opts)
+	o := beginJsonObject(dst)
+	o.extensions(header.Extensions)
+
+	o.wei("baseFeePerGas", weiField{header.BaseFeePerGas, header.BaseFeePerGasU256})
+	if header.BlobGasUsed != nil {
+		o.quantity("blobGasUsed", *header.BlobGasUsed)
+	}
+	if header.CanonicalRlp != nil {
+		o.hex("canonicalRlp", header.CanonicalRlp)
+	}
+	o.wei("difficulty", weiField{header.Difficulty, header.DifficultyU256})
+	if header.Epoch != nil {
+		o.quantity("epoch", *header.Epoch)
+	}
+	if header.ExcessBlobGas != nil {
+		o.quantity("excessBlobGas", *header.ExcessBlobGas)
+	}
+	o.hex("extraData", header.ExtraData)
+	o.quantity("gasLimit", header.GasLimit)
+	o.quantity("gasUsed", header.GasUsed)
+	o.hex("hash", header.Hash)
+	if header.L1BlockNumber != nil {
+		o.quantity("l1BlockNumber", *header.L1BlockNumber)
+	}
+	o.hex("logsBloom", header.LogsBloom)
+	o.hex("miner", header.Miner)
+	if header.MixHash != nil {
+		o.hex("mixHash", header.MixHash)
+	}
+	if header.Nonce != nil {
+		if cfg.dialect.BlockNonce == BlockNonceQuantity {
+			o.quantity("nonce", *header.Nonce)
+		} else {
+			o.key("nonce")
+			o.buf = appendPaddedNonce(o.buf, *header.Nonce)
+		}
+	}
+	o.quantity("number", header.Number)
+	if header.ParentBeaconBlockRoot != nil {
+		o.hex("parentBeaconBlockRoot", header.ParentBeaconBlockRoot)
+	}
+	o.hex("parentHash", header.ParentHash)
+	if header.ProposerIndex != nil {
+		o.quantity("proposerIndex", *header.ProposerIndex)
+	}
+	if header.ProposerPublicKey != nil {
+		o.str("proposerPublicKey", *header.ProposerPublicKey)
+	}
+	o.hex("receiptsRoot", header.ReceiptsRoot)
+	if header.RequestsHash != nil {
+		o.hex("requestsHash", header.RequestsHash)
+	}
+	if header.SendCount != nil {
+		o.quantity("sendCount", *header.SendCount)
+	}
+	if header.SendRoot != nil {
+		o.hex("sendRoot", header.SendRoot)
+	}
+	o.hex("sha3Uncles", header.Sha3Uncles)
+	if header.Size != 0 || !cfg.dialect.SizeOptional {
+		o.quantity("size", header.Size)
+	}
+	if header.Slot != nil {
+		o.quantity("slot", *header.Slot)
+	}
+	o.hex("stateRoot", header.StateRoot)
+	o.quantity("timestamp", header.Timestamp)
+	o.wei("totalDifficulty", weiField{header.TotalDifficulty, header.TotalDifficultyU256})
+	if header.TransactionCount != nil {
+		o.quantity("transactionCount", uint64(*header.TransactionCount))
+	}
+
+	o.key("transactions")
+	switch {
+	case len(fullTxs) > 0:
+		o.buf = append(o.buf, '[')
+		for i, t := range fullTxs {
+			if i > 0 {
+				o.buf = append(o.buf, ',')
+			}
+			o.buf = appendTransactionJsonRpc(o.buf, t, cfg)
+		}
+		o.buf = append(o.buf, ']')
+	default:
+		o.buf = appendHexArray(o.buf, txHashes)
+	}
+
+	o.hex("transactionsRoot", header.TransactionsRoot)
+	o.key("uncles")
+	o.buf = appendHexArray(o.buf, header.Uncles)
+	if withdrawals != nil {
+		o.key("withdrawals")
+		o.buf = append(o.buf, '[')
+		for i, w := range withdrawals {
+			if i > 0 {
+				o.buf = append(o.buf, ',')
+			}
+			o.buf = AppendWithdrawalJsonRpc(o.buf, w)
+		}
+		o.buf = append(o.buf, ']')
+	}
+	if header.WithdrawalsRoot != nil {
+		o.hex("withdrawalsRoot", header.WithdrawalsRoot)
+	}
+
+	return o.end()
+}
+
+// writeJsonRpcArray streams a JSON array of n elements produced by appendElem to w.
+func writeJsonRpcArray(w io.Writer, n int, appendElem func(dst []byte, i int) []byte) error {
+	buf := make([]byte, 0, writeChunkSize+4096)
+	buf = append(buf, '[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendElem(buf, i)
+		if len(buf) >= writeChunkSize {
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+	buf = append(buf, ']')
+	_, err := w.Write(buf)
+	return err
+}
+
+// jsonObject appends the members of a JSON object to buf. Callers are responsible for
+// emitting keys in sorted order; extensions are merged into that order as keys are written.
+type jsonObject struct {
+	buf     []byte
+	n       int
+	ext     map[string]string
+	extKeys []string
+}
+
+func beginJsonObject(dst []byte) jsonObject {
+	return jsonObject{buf: append(dst, '{')}
+}
+
+// extensions registers proto extensions to be interleaved with the object's own keys.
+// Extensions named like a key the object writes are dropped, matching addExtensions.
+func (o *jsonObject) extensions(ext map[string]string) {
+	o.ext = ext
+	o.extKeys = sortedExtensionKeys(ext)
+}
+
+func (o *jsonObject) end() []byte {
+	o.flushExtensions("")
+	return append(o.buf, '}')
+}
+
+// flushExtensions writes the pending extensions sorting before k, or all of them when k is "".
+func (o *jsonObject) flushExtensions(k string) {
+	for len(o.extKeys) > 0 && (k == "" || o.extKeys[0] <= k) {
+		name := o.extKeys[0]
+		o.extKeys = o.extKeys[1:]
+		if name == k {
+			continue
+		}
+		if o.n > 0 {
+			o.buf = append(o.buf, ',')
+		}
+		o.n++
+		o.buf = appendJsonString(o.buf, name)
+		o.buf = append(o.buf, ':')
+		o.buf = appendExtensionValue(o.buf, o.ext[name])
+	}
+}
+
+// appendExtensionValue writes an extension's raw JSON text as json.Marshal would write the
+// corresponding extensionValue.
+func appendExtensionValue(dst []byte, v string) []byte {
+	if json.Valid([]byte(v)) {
+		if b, err := json.Marshal(json.RawMessage(v)); err == nil {
+			return append(dst, b...)
+		}
+	}
+	return appendJsonString(dst, v)
+}
+
+func (o *jsonObject) key(k string) {
+	if len(o.extKeys) > 0 {
+		o.flushExtensions(k)
+	}
+	if o.n > 0 {
+		o.buf = append(o.buf, ',')
+	}
+	o.n++
+	o.buf = append(o.buf, '"')
+	o.buf = append(o.buf, k...)
+	o.buf = append(o.buf, '"', ':')
+}
+
+// quantity writes an unsigned integer as a JSON-RPC QUANTITY, matching fmt.Sprintf("0x%x").
+func (o *jsonObject) quantity(k string, v uint64) {
+	o.key(k)
+	o.buf = append(o.buf, '"')
+	o.buf = AppendQuantity(o.buf, v)
+	o.buf = append(o.buf, '"')
+}
+
+// hex writes bytes as 0x-prefixed DATA, matching BytesToHex.
+func (o *jsonObject) hex(k string, b []byte) {
+	o.key(k)
+	o.buf = appendQuotedHex(o.buf, b)
+}
+
+// hexFixed writes bytes left-padded to size, matching BytesToHexFixed.
+func (o *jsonObject) hexFixed(k string, b []byte, size int) {
+	o.key(k)
+	o.buf = append(o.buf, '"', '0', 'x')
+	if b != nil {
+		for i := len(b); i < size; i++ {
+			o.buf = append(o.buf, '0', '0')
+		}
+		o.buf = hex.AppendEncode(o.buf, b)
+	}
+	o.buf = append(o.buf, '"')
+}
+
+// numberish writes a decimal or hex numeric string as a QUANTITY, matching DecimalStringToHex.
+// When the value cannot be parsed nothing is written and false is returned, mirroring the
+// maps which omit such keys.
+func (o *jsonObject) numberish(k string, s string) bool {
+	if u, ok := parseUint64Fast(s); ok {
+		o.quantity(k, u)
+		return true
+	}
+	h, err := DecimalStringToHex(s)
+	if err != nil {
+		return false
+	}
+	o.key(k)
+	o.buf = appendJsonString(o.buf, h)
+	return true
+}
+
+// weiField is a string quantity and its U256 counterpart (see u256_fields.go).
+type weiField struct {
+	str  *string
+	u256 []byte
+}
+
+// nonEmpty returns s, or nil when *s is "", for string quantities that are not optional.
+func nonEmpty(s *string) *string {
+	if *s == "" {
+		return nil
+	}
+	return s
+}
+
+// wei writes w's string, or its U256 field when the string is unset or empty, matching the maps
+// built from withU256Strings. It returns false when nothing is written.
+func (o *jsonObject) wei(k string, w weiField) bool {
+	if (w.str == nil || *w.str == "") && w.u256 != nil && len(w.u256) <= 32 {
+		o.key(k)
+		o.buf = appendQuotedBytesQuantity(o.buf, w.u256)
+		return true
+	}
+	if w.str == nil {
+		return false
+	}
+	return o.numberish(k, *w.str)
+}
+
+func (o *jsonObject) str(k string, s string) {
+	o.key(k)
+	o.buf = appendJsonString(o.buf, s)
+}
+
+func (o *jsonObject) null(k string) {
+	o.key(k)
+	o.buf = append(o.buf, "null"...)
+}
+
+func (o *jsonObject) boolean(k string, v bool) {
+	o.key(k)
+	o.buf = strconv.AppendBool(o.buf, v)
+}
+
+// float writes a float64 using the same formatting rules as encoding/json.
+// NaN and infinities, which encoding/json refuses to encode, are written as null.
+func (o *jsonObject) float(k string, f float64) {
+	o.key(k)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		o.buf = append(o.buf, "null"...)
+		return
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	o.buf = strconv.AppendFloat(o.buf, f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(o.buf)
+		if n >= 4 && o.buf[n-4] == 'e' && o.buf[n-3] == '-' && o.buf[n-2] == '0' {
+			o.buf[n-2] = o.buf[n-1]
+			o.buf = o.buf[:n-1]
+		}
+	}
+}
+
+// l1FeeScalar writes l1FeeScalar in the dialect's encoding, matching convertConfig.formatL1FeeScalar.
+func (o *jsonObject) l1FeeScalar(cfg *convertConfig, f float64) {
+	switch cfg.dialect.L1FeeScalar {
+	case L1FeeScalarDecimalString:
+		o.str("l1FeeScalar", strconv.FormatFloat(f, 'f', -1, 64))
+	case L1FeeScalarQuantity:
+		o.quantity("l1FeeScalar", uint64(f))
+	default:
+		o.float("l1FeeScalar", f)
+	}
+}
+
+// parseUint64Fast parses plain decimal or 0x-prefixed hex strings that fit in a uint64.
+// Anything else (whitespace, signs, big values) is left to DecimalStringToHex.
+func parseUint64Fast(s string) (uint64, bool) {
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		u, err := decodeQuantity(s, false, 64)
+		return u, err == nil
+	}
+	if s == "" {
+		return 0, true
+	}
+	u, err := strconv.ParseUint(s, 10, 64)
+	return u, err == nil
+}
+
+func appendQuotedHex(dst []byte, b []byte) []byte {
+	dst = append(dst, '"')
+	dst = AppendHex(dst, b)
+	return append(dst, '"')
+}
+
+func appendHexArray(dst []byte, items [][]byte) []byte {
+	dst = append(dst, '[')
+	for i, item := range items {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendQuotedHex(dst, item)
+	}
+	return append(dst, ']')
+}
+
+// appendQuotedBytesQuantity writes big-endian bytes as a QUANTITY, matching BytesToQuantityHex.
+func appendQuotedBytesQuantity(dst []byte, b []byte) []byte {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	dst = append(dst, '"', '0', 'x')
+	if len(b) == 0 {
+		dst = append(dst, '0')
+	} else {
+		start := len(dst)
+		dst = hex.AppendEncode(dst, b)
+		if dst[start] == '0' {
+			// drop the leading nibble of the first byte
+			copy(dst[start:], dst[start+1:])
+			dst = dst[:len(dst)-1]
+		}
+	}
+	return append(dst, '"')
+}
+
+// appendPaddedNonce writes the 8-byte block nonce, matching fmt.Sprintf("0x%016x").
+func appendPaddedNonce(dst []byte, nonce uint64) []byte {
+	dst = append(dst, '"', '0', 'x')
+	var b [8]byte
+	for i := 7; i >= 0; i-- {
+		b[i] = byte(nonce)
+		nonce >>= 8
+	}
+	dst = hex.AppendEncode(dst, b[:])
+	return append(dst, '"')
+}
+
+// appendJsonString writes s as a JSON string with the same escaping as encoding/json,
+// including HTML-safe escaping of <, > and &.
+func appendJsonString(dst []byte, s string) []byte {
+	const hexDigits = "0123456789abcdef"
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '\\', '"':
+				dst = append(dst, '\\', c)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,252 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func fullEncodeTestTransaction() *Transaction {
+	return &Transaction{
+		Hash:                 MustHexToBytes("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"),
+		Nonce:                7,
+		From:                 MustHexToBytes("0x742d35cc6634c0532925a3b844bc9e7595f0beb7"),
+		To:                   MustHexToBytes("0x4200000000000000000000000000000000000015"),
+		Value:                "1000000000000000000000000000",
+		Input:                MustHexToBytes("0xabcdef"),
+		Type:                 4,
+		GasLimit:             21000,
+		GasPrice:             StringPtr("0x3b9aca00"),
+		MaxFeePerGas:         StringPtr("2000000000"),
+		MaxPriorityFeePerGas: StringPtr("not-a-number"),
+		GasUsed:              Uint64Ptr(21000),
+		EffectiveGasPrice:    StringPtr("1500000000"),
+		R:                    MustHexToBytes("0x01"),
+		S:                    MustHexToBytes("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"),
+		V:                    MustHexToBytes("0x0025"),
+		YParity:              Uint32Ptr(1),
+		ChainId:              Uint64Ptr(1),
+		BlockNumber:          Uint64Ptr(19000000),
+		BlockHash:            MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+		TransactionIndex:     Uint32Ptr(17),
+		BlockTimestamp:       Uint64Ptr(1700000000),
+		AccessList: []*AccessListItem{
+			{Address: MustHexToBytes("0x4200000000000000000000000000000000000016"), StorageKeys: [][]byte{MustHexToBytes("0x01"), MustHexToBytes("0x02")}},
+			nil,
+			{Address: MustHexToBytes("0x4200000000000000000000000000000000000017")},
+		},
+		MaxFeePerBlobGas:    StringPtr("0x10"),
+		BlobVersionedHashes: [][]byte{MustHexToBytes("0x01ab")},
+		BlobGasUsed:         Uint64Ptr(131072),
+		BlobGasPrice:        StringPtr("1"),
+		AuthorizationList: []*AuthorizationListItem{
+			{ChainId: 1, Address: MustHexToBytes("0x4200000000000000000000000000000000000018"), Nonce: 3, R: MustHexToBytes("0x05"), YParity: 1, Authority: MustHexToBytes("0x4200000000000000000000000000000000000019")},
+		},
+		L1Fee:                 StringPtr("invalid"),
+		L1GasPrice:            StringPtr("0x1"),
+		L1GasUsed:             StringPtr("1600"),
+		L1FeeScalar:           func() *float64 { f := 0.0000001; return &f }(),
+		L1BlobBaseFee:         StringPtr("0x1234"),
+		L1BlobBaseFeeScalar:   Uint64Ptr(0x101c12),
+		GatewayFee:            StringPtr("0"),
+		FeeCurrency:           MustHexToBytes("0x765de816845861e75a25fca122bb6898b8b1282a"),
+		GatewayFeeRecipient:   MustHexToBytes("0x765de816845861e75a25fca122bb6898b8b1282b"),
+		Beneficiary:           MustHexToBytes("0x01"),
+		DepositValue:          StringPtr("0x0"),
+		L1BaseFee:             StringPtr("30000000000"),
+		MaxSubmissionFee:      StringPtr("0x100"),
+		RefundTo:              MustHexToBytes("0x02"),
+		RequestId:             MustHexToBytes("0x03"),
+		RetryData:             MustHexToBytes("0x04"),
+		RetryTo:               MustHexToBytes("0x05"),
+		RetryValue:            StringPtr("5"),
+		MaxRefund:             StringPtr("6"),
+		SubmissionFeeRefund:   StringPtr("7"),
+		TicketId:              MustHexToBytes("0x06"),
+		IsSystemTx:            BoolPtr(false),
+		DepositReceiptVersion: StringPtr("0x1"),
+	}
+}
+
+func fullEncodeTestReceipt() *Receipt {
+	scalar := 1.5
+	return &Receipt{
+		TransactionHash:       MustHexToBytes("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"),
+		BlockNumber:           19000000,
+		BlockHash:             MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+		TransactionIndex:      17,
+		Type:                  2,
+		From:                  MustHexToBytes("0x742d35cc6634c0532925a3b844bc9e7595f0beb7"),
+		Status:                Uint32Ptr(1),
+		GasUsed:               21000,
+		CumulativeGasUsed:     42000,
+		EffectiveGasPrice:     "1500000000",
+		LogsBloom:             make([]byte, BloomLength),
+		Logs:                  []*Log{encodeTestLog(), nil},
+		ContractAddress:       MustHexToBytes("0x4200000000000000000000000000000000000015"),
+		Root:                  MustHexToBytes("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
+		BlockTimestamp:        Uint64Ptr(1700000000),
+		BlobGasUsed:           Uint64Ptr(131072),
+		BlobGasPrice:          StringPtr("0x1"),
+		Timeboosted:           BoolPtr(true),
+		L1Fee:                 StringPtr("123456789012345678901234567890"),
+		L1GasUsed:             StringPtr("bad"),
+		L1FeeScalar:           &scalar,
+		L1BaseFeeScalar:       Uint64Ptr(0x8dd),
+		GasUsedForL1:          Uint64Ptr(100),
+		L1BlockNumber:         Uint64Ptr(200),
+		GatewayFee:            StringPtr("1"),
+		DepositNonce:          StringPtr("0x211c31f"),
+		DepositReceiptVersion: StringPtr("1"),
+		L1BlobBaseFee:         StringPtr("0x1"),
+		L1BlobBaseFeeScalar:   Uint64Ptr(0x101c12),
+	}
+}
+
+func encodeTestLog() *Log {
+	return &Log{
+		Address: MustHexToBytes("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+		Topics: [][]byte{
+			MustHexToBytes(TransferEventSignature),
+			MustHexToBytes("0x0000000000000000000000001234567890123456789012345678901234567890"),
+		},
+		Data:             MustHexToBytes("0x00000000000000000000000000000000000000000000000000000000000003e8"),
+		BlockNumber:      19000000,
+		BlockHash:        MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+		TransactionHash:  MustHexToBytes("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"),
+		TransactionIndex: 17,
+		LogIndex:         250,
+		BlockTimestamp:   Uint64Ptr(1700000000),
+	}
+}
+
+func fullEncodeTestHeader() *BlockHeader {
+	return &BlockHeader{
+		Number:                19000000,
+		Timestamp:             1700000000,
+		GasLimit:              30000000,
+		GasUsed:               15000000,
+		Size:                  1024,
+		Hash:                  MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+		ParentHash:            MustHexToBytes("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"),
+		StateRoot:             MustHexToBytes("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
+		TransactionsRoot:      MustHexToBytes("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
+		ReceiptsRoot:          MustHexToBytes("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
+		Sha3Uncles:            MustHexToBytes("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"),
+		Miner:                 MustHexToBytes("0x0000000000000000000000000000000000000000"),
+		LogsBloom:             make([]byte, BloomLength),
+		ExtraData:             []byte{},
+		Nonce:                 Uint64Ptr(0x42),
+		BlobGasUsed:           Uint64Ptr(0),
+		ExcessBlobGas:         Uint64Ptr(393216),
+		L1BlockNumber:         Uint64Ptr(18000000),
+		Epoch:                 Uint64Ptr(1),
+		Slot:                  Uint64Ptr(2),
+		ProposerIndex:         Uint64Ptr(3),
+		SendCount:             Uint64Ptr(4),
+		TransactionCount:      Uint32Ptr(2),
+		MixHash:               MustHexToBytes("0x01"),
+		ParentBeaconBlockRoot: MustHexToBytes("0x02"),
+		WithdrawalsRoot:       MustHexToBytes("0x03"),
+		SendRoot:              MustHexToBytes("0x04"),
+		BaseFeePerGas:         StringPtr("7"),
+		Difficulty:            StringPtr("0"),
+		TotalDifficulty:       StringPtr("58750003716598352816469"),
+		ProposerPublicKey:     StringPtr("0xa1<b>&\"quoted\" \xff"),
+		CanonicalRlp:          MustHexToBytes("0xf9"),
+		Uncles:                [][]byte{MustHexToBytes("0x05")},
+		RequestsHash:          MustHexToBytes("0x06"),
+	}
+}
+
+func assertSameAsMap(t *testing.T, expected interface{}, actual []byte) {
+	t.Helper()
+	want, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatalf("Failed to marshal map output: %v", err)
+	}
+	if !bytes.Equal(want, actual) {
+		t.Errorf("Encoder output differs from map output\nwant: %s\ngot:  %s", want, actual)
+	}
+}
+
+func TestAppendJsonRpcMatchesMapOutput(t *testing.T) {
+	t.Run("Log", func(t *testing.T) {
+		assertSameAsMap(t, LogToJsonRpc(encodeTestLog()), AppendLogJsonRpc(nil, encodeTestLog()))
+		assertSameAsMap(t, LogToJsonRpc(&Log{}), AppendLogJsonRpc(nil, &Log{}))
+		assertSameAsMap(t, LogsToJsonRpc([]*Log{encodeTestLog(), nil}), AppendLogsJsonRpc(nil, []*Log{encodeTestLog(), nil}))
+		assertSameAsMap(t, LogsToJsonRpc(nil), AppendLogsJsonRpc(nil, nil))
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		tx := fullEncodeTestTransaction()
+		assertSameAsMap(t, TransactionToJsonRpc(tx), AppendTransactionJsonRpc(nil, tx))
+
+		minimal := &Transaction{Hash: MustHexToBytes("0x01"), L1Fee: StringPtr("0x5")}
+		assertSameAsMap(t, TransactionToJsonRpc(minimal), AppendTransactionJsonRpc(nil, minimal))
+
+		bigScalar := 1e21
+		tx.L1FeeScalar = &bigScalar
+		tx.AuthorizationList = []*AuthorizationListItem{nil}
+		assertSameAsMap(t, TransactionToJsonRpc(tx), AppendTransactionJsonRpc(nil, tx))
+	})
+
+	t.Run("Receipt", func(t *testing.T) {
+		r := fullEncodeTestReceipt()
+		assertSameAsMap(t, ReceiptToJsonRpc(r), AppendReceiptJsonRpc(nil, r))
+
+		minimal := &Receipt{}
+		assertSameAsMap(t, ReceiptToJsonRpc(minimal), AppendReceiptJsonRpc(nil, minimal))
+		assertSameAsMap(t, ReceiptsToJsonRpc([]*Receipt{r, minimal}), AppendReceiptsJsonRpc(nil, []*Receipt{r, minimal}))
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		header := fullEncodeTestHeader()
+		txs := []*Transaction{fullEncodeTestTransaction(), nil}
+		hashes := [][]byte{MustHexToBytes("0x01"), MustHexToBytes("0x02")}
+		withdrawals := []*Withdrawal{{Index: 1, ValidatorIndex: 2, Address: MustHexToBytes("0x03"), Amount: 4}, nil}
+
+		assertSameAsMap(t, BlockToJsonRpc(header, hashes, txs, withdrawals), AppendBlockJsonRpc(nil, header, hashes, txs, withdrawals))
+		assertSameAsMap(t, BlockToJsonRpc(header, hashes, nil, []*Withdrawal{}), AppendBlockJsonRpc(nil, header, hashes, nil, []*Withdrawal{}))
+		assertSameAsMap(t, BlockToJsonRpc(&BlockHeader{}, nil, nil, nil), AppendBlockJsonRpc(nil, &BlockHeader{}, nil, nil, nil))
+	})
+}
+
+func TestWriteLogsJsonRpc(t *testing.T) {
+	logs := make([]*Log, 2000)
+	for i := range logs {
+		logs[i] = encodeTestLog()
+		logs[i].LogIndex = uint32(i)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteLogsJsonRpc(&buf, logs); err != nil {
+		t.Fatalf("Failed to write logs: %v", err)
+	}
+	assertSameAsMap(t, LogsToJsonRpc(logs), buf.Bytes())
+}
+
+func BenchmarkLogsToJsonRpcMap(b *testing.B) {
+	logs := make([]*Log, 1000)
+	for i := range logs {
+		logs[i] = encodeTestLog()
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(LogsToJsonRpc(logs)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendLogsJsonRpc(b *testing.B) {
+	logs := make([]*Log, 1000)
+	for i := range logs {
+		logs[i] = encodeTestLog()
+	}
+	b.ReportAllocs()
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		buf = AppendLogsJsonRpc(buf[:0], logs)
+	}
+}
@@ -0,0 +1,340 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// JsonRpcErrorCodeDetail is the BaseError detail key holding the numeric code of a JSON-RPC error.
+const JsonRpcErrorCodeDetail = "jsonRpcCode"
+
+// JsonRpcErrorDataDetail is the BaseError detail key holding the raw JSON data member of a
+// JSON-RPC error, such as revert data or a provider's suggested block range.
+const JsonRpcErrorDataDetail = "jsonRpcData"
+
+// JsonRpcRequest is a JSON-RPC 2.0 request object.
+type JsonRpcRequest struct {
+	JsonRpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// JsonRpcError is the error member of a JSON-RPC 2.0 response.
+type JsonRpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// JsonRpcResponse is a JSON-RPC 2.0 response object.
+type JsonRpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JsonRpcError   `json:"error,omitempty"`
+}
+
+// JsonRpcResult is one demultiplexed response: the converted result of the request's method,
+// or the error the node returned for it.
+type JsonRpcResult struct {
+	Request *JsonRpcRequest
+	// Value is the converted result, e.g. *Block for eth_getBlockByNumber; nil when the node
+	// returned null, and the raw json.RawMessage for methods without a registered decoder
+	Value interface{}
+	// Err is a *common.BaseError when the node returned an error or the result failed to convert
+	Err error
+}
+
+// JsonRpcResultDecoder converts the result member of a response to req into a BDS value.
+type JsonRpcResultDecoder func(req *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error)
+
+var (
+	jsonRpcDecodersMu sync.RWMutex
+	jsonRpcDecoders   = map[string]JsonRpcResultDecoder{
+		"eth_chainId":                             decodeJsonRpcQuantity,
+		"eth_blockNumber":                         decodeJsonRpcQuantity,
+		"eth_getBlockByNumber":                    decodeJsonRpcBlock,
+		"eth_getBlockByHash":                      decodeJsonRpcBlock,
+		"eth_getTransactionByHash":                decodeJsonRpcTransaction,
+		"eth_getTransactionByBlockHashAndIndex":   decodeJsonRpcTransaction,
+		"eth_getTransactionByBlockNumberAndIndex": decodeJsonRpcTransaction,
+		"eth_getTransactionReceipt":               decodeJsonRpcReceipt,
+		"eth_getBlockReceipts":                    decodeJsonRpcReceipts,
+		"eth_getLogs":                             decodeJsonRpcLogs,
+		"eth_getProof":                            decodeJsonRpcProof,
+		"eth_feeHistory":                          decodeJsonRpcFeeHistory,
+		"txpool_content":                          decodeJsonRpcTxPoolContent,
+		"txpool_inspect":                          decodeJsonRpcTxPoolInspect,
+		"trace_transaction":                       decodeJsonRpcParityTraces,
+		"trace_block":                             decodeJsonRpcParityTraces,
+	}
+)
+
+// RegisterJsonRpcResultDecoder sets the decoder used for results of method, replacing any
+// existing one. It allows chain-specific methods to be demultiplexed like the built-in ones.
+func RegisterJsonRpcResultDecoder(method string, decoder JsonRpcResultDecoder) {
+	jsonRpcDecodersMu.Lock()
+	defer jsonRpcDecodersMu.Unlock()
+	jsonRpcDecoders[method] = decoder
+}
+
+func jsonRpcDecoder(method string) JsonRpcResultDecoder {
+	jsonRpcDecodersMu.RLock()
+	defer jsonRpcDecodersMu.RUnlock()
+	return jsonRpcDecoders[method]
+}
+
+// ParseJsonRpcResponses parses a single JSON-RPC response object or a batch array of them.
+func ParseJsonRpcResponses(data []byte) ([]*JsonRpcResponse, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var batch []*JsonRpcResponse
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON-RPC batch response: %w", err)
+		}
+		return batch, nil
+	}
+	var single JsonRpcResponse
+	if err := json.Unmarshal(data, &single); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON-RPC response: %w", err)
+	}
+	return []*JsonRpcResponse{&single}, nil
+}
+
+// DecodeJsonRpcResponses matches the responses in data (a single object or a batch array, in
+// any order) to requests by id and converts each result with the decoder registered for the
+// request's method. Results are returned in request order; a request without a response gets
+// an INTERNAL_ERROR. A response that matches no request, such as a batch-level parse error with
+// a null id, fails the whole call, as do two requests with the same id.
+func DecodeJsonRpcResponses(requests []*JsonRpcRequest, data []byte, opts ...ConvertOption) ([]*JsonRpcResult, error) {
+	keys := make([]string, len(requests))
+	requestIndex := make(map[string]int, len(requests))
+	for i, req := range requests {
+		if len(req.ID) == 0 {
+			// Notifications have no id and get no response
+			continue
+		}
+		keys[i] = jsonRpcIDKey(req.ID)
+		if j, ok := requestIndex[keys[i]]; ok {
+			return nil, fmt.Errorf("JSON-RPC requests %d and %d have the same id %s", j, i, string(req.ID))
+		}
+		requestIndex[keys[i]] = i
+	}
+
+	responses, err := ParseJsonRpcResponses(data)
+	if err != nil {
+		return nil, err
+	}
+	byIndex := make(map[int]*JsonRpcResponse, len(responses))
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		i, ok := requestIndex[jsonRpcIDKey(resp.ID)]
+		if !ok {
+			if resp.Error != nil {
+				return nil, resp.Error.ToBaseError()
+			}
+			return nil, fmt.Errorf("JSON-RPC response with unknown id %s", string(resp.ID))
+		}
+		byIndex[i] = resp
+	}
+
+	results := make([]*JsonRpcResult, len(requests))
+	for i, req := range requests {
+		resp, ok := byIndex[i]
+		if !ok {
+			results[i] = &JsonRpcResult{
+				Request: req,
+				Err:     common.NewError(common.ErrorCode_INTERNAL_ERROR, fmt.Sprintf("no response for %s request %s", req.Method, string(req.ID))),
+			}
+			continue
+		}
+		results[i] = DecodeJsonRpcResponse(req, resp, opts...)
+	}
+	return results, nil
+}
+
+// DecodeJsonRpcResponse converts one response to req, mapping its error member to a BaseError.
+func DecodeJsonRpcResponse(req *JsonRpcRequest, resp *JsonRpcResponse, opts ...ConvertOption) *JsonRpcResult {
+	result := &JsonRpcResult{Request: req}
+	if resp.Error != nil {
+		result.Err = resp.Error.ToBaseError()
+		return result
+	}
+	if isJsonNull(resp.Result) {
+		return result
+	}
+	decoder := jsonRpcDecoder(req.Method)
+	if decoder == nil {
+		result.Value = resp.Result
+		return result
+	}
+	value, err := decoder(req, resp.Result, opts...)
+	if err != nil {
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			baseErr = common.NewError(common.ErrorCode_INVALID_PARAMETER, err.Error()).WithCause(err)
+		}
+		result.Err = baseErr
+		return result
+	}
+	result.Value = value
+	return result
+}
+
+// ToBaseError converts a JSON-RPC error into a BaseError classified by DefaultErrorClassifier.
+// The original code and data are kept under the JsonRpcErrorCodeDetail and
+// JsonRpcErrorDataDetail keys, and the JsonRpcError itself is the cause.
+func (e *JsonRpcError) ToBaseError() *common.BaseError {
+	return DefaultErrorClassifier.Classify(0, e)
+}
+
+func (e *JsonRpcError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// jsonRpcIDKey canonicalises an id so that 1 and 1.0 or differently spaced strings match.
+func jsonRpcIDKey(id json.RawMessage) string {
+	var v interface{}
+	if err := json.Unmarshal(id, &v); err != nil {
+		return string(id)
+	}
+	key, _ := json.Marshal(v)
+	return string(key)
+}
+
+func isJsonNull(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || bytes.Equal(raw, []byte("null"))
+}
+
+func decodeJsonRpcQuantity(_ *JsonRpcRequest, result json.RawMessage, _ ...ConvertOption) (interface{}, error) {
+	var s string
+	if err := json.Unmarshal(result, &s); err != nil {
+		return nil, err
+	}
+	return NumberishToUint64(s)
+}
+
+func decodeJsonRpcBlock(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var b JsonRpcBlock
+	if err := json.Unmarshal(result, &b); err != nil {
+		return nil, err
+	}
+	return b.ToProto(opts...)
+}
+
+func decodeJsonRpcTransaction(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var t JsonRpcTransaction
+	if err := json.Unmarshal(result, &t); err != nil {
+		return nil, err
+	}
+	return t.ToProto(opts...)
+}
+
+func decodeJsonRpcReceipt(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var r JsonRpcReceipt
+	if err := json.Unmarshal(result, &r); err != nil {
+		return nil, err
+	}
+	return r.ToProto(opts...)
+}
+
+func decodeJsonRpcReceipts(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var list []*JsonRpcReceipt
+	if err := json.Unmarshal(result, &list); err != nil {
+		return nil, err
+	}
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	receipts := make([]*Receipt, 0, len(list))
+	for i, r := range list {
+		rp := p.item("receipts", i)
+		if r == nil {
+			rp.fail("", errors.New("receipt is null"))
+			continue
+		}
+		receipt := r.toProto(rp, cfg)
+		if p.done() {
+			break
+		}
+		receipts = append(receipts, receipt)
+	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+func decodeJsonRpcLogs(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var list []*JsonRpcLog
+	if err := json.Unmarshal(result, &list); err != nil {
+		return nil, err
+	}
+	p := newFieldParser(newConvertConfig(opts).collectErrors)
+	logs := make([]*Log, 0, len(list))
+	for i, l := range list {
+		lp := p.item("logs", i)
+		if l == nil {
+			lp.fail("", errors.New("log is null"))
+			continue
+		}
+		log := l.toProto(lp)
+		if p.done() {
+			break
+		}
+		logs = append(logs, log)
+	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func decodeJsonRpcProof(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var p JsonRpcAccountProof
+	if err := json.Unmarshal(result, &p); err != nil {
+		return nil, err
+	}
+	return p.ToProto(nil, opts...)
+}
+
+func decodeJsonRpcFeeHistory(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var f JsonRpcFeeHistory
+	if err := json.Unmarshal(result, &f); err != nil {
+		return nil, err
+	}
+	return f.ToProto(opts...)
+}
+
+func decodeJsonRpcTxPoolContent(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var c JsonRpcTxPoolContent
+	if err := json.Unmarshal(result, &c); err != nil {
+		return nil, err
+	}
+	return c.ToProto(time.Now(), opts...)
+}
+
+func decodeJsonRpcTxPoolInspect(_ *JsonRpcRequest, result json.RawMessage, _ ...ConvertOption) (interface{}, error) {
+	var c JsonRpcTxPoolInspect
+	if err := json.Unmarshal(result, &c); err != nil {
+		return nil, err
+	}
+	return c.ToProto(time.Now())
+}
+
+func decodeJsonRpcParityTraces(_ *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+	var entries []*JsonRpcParityTrace
+	if err := json.Unmarshal(result, &entries); err != nil {
+		return nil, err
+	}
+	return ParseJsonRpcParityTraces(entries, opts...)
+}
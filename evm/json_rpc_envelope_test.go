@@ -0,0 +1,203 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+func envelopeTestRequests() []*JsonRpcRequest {
+	return []*JsonRpcRequest{
+		{JsonRpc: "2.0", ID: json.RawMessage(`1`), Method: "eth_chainId"},
+		{JsonRpc: "2.0", ID: json.RawMessage(`2`), Method: "eth_getTransactionReceipt", Params: json.RawMessage(`["0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"]`)},
+		{JsonRpc: "2.0", ID: json.RawMessage(`"logs"`), Method: "eth_getLogs", Params: json.RawMessage(`[{"fromBlock":"0x0","toBlock":"0x1000000"}]`)},
+		{JsonRpc: "2.0", ID: json.RawMessage(`4`), Method: "eth_getBlockByNumber", Params: json.RawMessage(`["0xffffffff",false]`)},
+		{JsonRpc: "2.0", ID: json.RawMessage(`5`), Method: "net_version"},
+	}
+}
+
+func TestDecodeJsonRpcResponses(t *testing.T) {
+	receipt, _ := json.Marshal(dialectTestReceipt())
+	logs := AppendLogsJsonRpc(nil, []*Log{encodeTestLog()})
+	// Responses arrive out of order, as batch responses may
+	batch := `[
+		{"jsonrpc":"2.0","id":5,"result":"1"},
+		{"jsonrpc":"2.0","id":"logs","result":` + string(logs) + `},
+		{"jsonrpc":"2.0","id":4,"result":null},
+		{"jsonrpc":"2.0","id":1,"result":"0x1"},
+		{"jsonrpc":"2.0","id":2,"result":` + string(receipt) + `}
+	]`
+
+	results, err := DecodeJsonRpcResponses(envelopeTestRequests(), []byte(batch))
+	if err != nil {
+		t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("results[%d] error = %v", i, r.Err)
+		}
+	}
+	if results[0].Value != uint64(1) {
+		t.Errorf("eth_chainId = %#v, want 1", results[0].Value)
+	}
+	if r, ok := results[1].Value.(*Receipt); !ok || r.GasUsed != 21000 {
+		t.Errorf("eth_getTransactionReceipt = %#v", results[1].Value)
+	}
+	if l, ok := results[2].Value.([]*Log); !ok || len(l) != 1 || l[0].LogIndex != 250 {
+		t.Errorf("eth_getLogs = %#v", results[2].Value)
+	}
+	if results[3].Value != nil {
+		t.Errorf("Expected nil for a null block, got %#v", results[3].Value)
+	}
+	if raw, ok := results[4].Value.(json.RawMessage); !ok || string(raw) != `"1"` {
+		t.Errorf("Expected raw result for net_version, got %#v", results[4].Value)
+	}
+}
+
+func TestJsonRpcEnvelopeErrors(t *testing.T) {
+	t.Run("ErrorMember", func(t *testing.T) {
+		resp := `{"jsonrpc":"2.0","id":"logs","error":{"code":-32005,"message":"query returned more than 10000 results","data":{"from":"0x0","to":"0x1f4"}}}`
+		requests := envelopeTestRequests()[2:3]
+		results, err := DecodeJsonRpcResponses(requests, []byte(resp))
+		if err != nil {
+			t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+		}
+		var baseErr *common.BaseError
+		if !errors.As(results[0].Err, &baseErr) {
+			t.Fatalf("Expected BaseError, got %v", results[0].Err)
+		}
+		if baseErr.Message != "query returned more than 10000 results" || baseErr.Details[JsonRpcErrorCodeDetail] != -32005 {
+			t.Errorf("Unexpected error %v with details %v", baseErr, baseErr.Details)
+		}
+		if data, ok := baseErr.Details[JsonRpcErrorDataDetail].(json.RawMessage); !ok || string(data) != `{"from":"0x0","to":"0x1f4"}` {
+			t.Errorf("Expected original error data, got %#v", baseErr.Details[JsonRpcErrorDataDetail])
+		}
+		var rpcErr *JsonRpcError
+		if !errors.As(results[0].Err, &rpcErr) || rpcErr.Code != -32005 {
+			t.Error("Expected the JsonRpcError as cause")
+		}
+	})
+
+	t.Run("ReservedCodes", func(t *testing.T) {
+		tests := map[int]common.ErrorCode{
+			-32700: common.ErrorCode_INVALID_REQUEST,
+			-32601: common.ErrorCode_UNSUPPORTED_METHOD,
+			-32602: common.ErrorCode_INVALID_PARAMETER,
+			-32603: common.ErrorCode_INTERNAL_ERROR,
+		}
+		for code, want := range tests {
+			if got := (&JsonRpcError{Code: code}).ToBaseError().Code; got != want {
+				t.Errorf("code %d mapped to %v, want %v", code, got, want)
+			}
+		}
+	})
+
+	t.Run("ConversionError", func(t *testing.T) {
+		resp := `{"jsonrpc":"2.0","id":2,"result":{"transactionHash":"0x12","gasUsed":"0xzz"}}`
+		results, err := DecodeJsonRpcResponses(envelopeTestRequests()[1:2], []byte(resp))
+		if err != nil {
+			t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+		}
+		var baseErr *common.BaseError
+		if !errors.As(results[0].Err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+			t.Errorf("Expected INVALID_PARAMETER conversion error, got %v", results[0].Err)
+		}
+	})
+
+	t.Run("NullElements", func(t *testing.T) {
+		receipt, _ := json.Marshal(dialectTestReceipt())
+		log := string(AppendLogJsonRpc(nil, encodeTestLog()))
+		requests := []*JsonRpcRequest{
+			{JsonRpc: "2.0", ID: json.RawMessage(`1`), Method: "eth_getBlockReceipts", Params: json.RawMessage(`["latest"]`)},
+			{JsonRpc: "2.0", ID: json.RawMessage(`2`), Method: "eth_getLogs", Params: json.RawMessage(`[{}]`)},
+			{JsonRpc: "2.0", ID: json.RawMessage(`3`), Method: "eth_getBlockReceipts", Params: json.RawMessage(`["latest"]`)},
+		}
+		batch := `[
+			{"jsonrpc":"2.0","id":1,"result":[` + string(receipt) + `,null]},
+			{"jsonrpc":"2.0","id":2,"result":[` + log + `,null]},
+			{"jsonrpc":"2.0","id":3,"result":[{"logs":[null]}]}
+		]`
+		results, err := DecodeJsonRpcResponses(requests, []byte(batch))
+		if err != nil {
+			t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+		}
+		for i, want := range []string{"receipts[1]", "logs[1]", "receipts[0].blockNumber"} {
+			var baseErr *common.BaseError
+			if !errors.As(results[i].Err, &baseErr) || baseErr.Details[ConvertErrorPathDetail] != want {
+				t.Errorf("results[%d]: expected an error at %s, got %v", i, want, results[i].Err)
+			}
+		}
+	})
+
+	t.Run("CollectErrors", func(t *testing.T) {
+		requests := []*JsonRpcRequest{
+			{JsonRpc: "2.0", ID: json.RawMessage(`1`), Method: "eth_getLogs", Params: json.RawMessage(`[{}]`)},
+		}
+		log := strings.Replace(string(AppendLogJsonRpc(nil, encodeTestLog())), `"logIndex":"0xfa"`, `"logIndex":"0xzz"`, 1)
+		resp := `{"jsonrpc":"2.0","id":1,"result":[` + log + `,null]}`
+		results, err := DecodeJsonRpcResponses(requests, []byte(resp), WithCollectErrors(true))
+		if err != nil {
+			t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+		}
+		var baseErr *common.BaseError
+		if !errors.As(results[0].Err, &baseErr) {
+			t.Fatalf("Expected BaseError, got %v", results[0].Err)
+		}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, []string{"logs[0].logIndex", "logs[1]"}) {
+			t.Errorf("Expected every invalid log to be reported, got %v", paths)
+		}
+	})
+
+	t.Run("MissingResponse", func(t *testing.T) {
+		results, err := DecodeJsonRpcResponses(envelopeTestRequests()[:2], []byte(`[{"jsonrpc":"2.0","id":1,"result":"0x1"}]`))
+		if err != nil {
+			t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+		}
+		if results[1].Err == nil {
+			t.Error("Expected an error for the request without a response")
+		}
+	})
+
+	t.Run("BatchLevelError", func(t *testing.T) {
+		_, err := DecodeJsonRpcResponses(envelopeTestRequests(), []byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_REQUEST {
+			t.Errorf("Expected INVALID_REQUEST for the whole batch, got %v", err)
+		}
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		requests := envelopeTestRequests()
+		requests[4].ID = json.RawMessage(`1.0`)
+		if _, err := DecodeJsonRpcResponses(requests, []byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`)); err == nil {
+			t.Error("Expected an error for requests with the same id")
+		}
+	})
+}
+
+func TestRegisterJsonRpcResultDecoder(t *testing.T) {
+	RegisterJsonRpcResultDecoder("test_echo", func(req *JsonRpcRequest, result json.RawMessage, opts ...ConvertOption) (interface{}, error) {
+		return req.Method + ":" + string(result), nil
+	})
+	defer func() {
+		jsonRpcDecodersMu.Lock()
+		delete(jsonRpcDecoders, "test_echo")
+		jsonRpcDecodersMu.Unlock()
+	}()
+
+	req := &JsonRpcRequest{JsonRpc: "2.0", ID: json.RawMessage(`7`), Method: "test_echo"}
+	results, err := DecodeJsonRpcResponses([]*JsonRpcRequest{req}, []byte(`{"jsonrpc":"2.0","id":7,"result":true}`))
+	if err != nil {
+		t.Fatalf("DecodeJsonRpcResponses() error = %v", err)
+	}
+	if results[0].Value != "test_echo:true" {
+		t.Errorf("Expected registered decoder to run, got %#v", results[0].Value)
+	}
+}
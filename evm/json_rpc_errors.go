@@ -0,0 +1,264 @@
+package evm
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// BaseError detail keys holding hints extracted from upstream errors.
+const (
+	// ErrorHTTPStatusDetail holds the HTTP status of the upstream response, when it was not 200
+	ErrorHTTPStatusDetail = "httpStatus"
+	// ErrorSuggestedFromBlockDetail holds the first block of a range the provider suggested retrying with
+	ErrorSuggestedFromBlockDetail = "suggestedFromBlock"
+	// ErrorSuggestedToBlockDetail holds the last block of a range the provider suggested retrying with
+	ErrorSuggestedToBlockDetail = "suggestedToBlock"
+	// ErrorMaxBlockRangeDetail holds the largest block range the provider accepts
+	ErrorMaxBlockRangeDetail = "maxBlockRange"
+	// ErrorMaxResultsDetail holds the largest number of results the provider returns
+	ErrorMaxResultsDetail = "maxResults"
+)
+
+// ErrorPattern maps upstream errors to an ErrorCode. A pattern matches when every criterion it
+// sets matches: one of Codes, one of HTTPStatuses and Message.
+type ErrorPattern struct {
+	// Codes are the JSON-RPC error codes the pattern applies to; empty matches any code
+	Codes []int
+	// HTTPStatuses are the HTTP statuses the pattern applies to; empty matches any status
+	HTTPStatuses []int
+	// Message is matched against the error message; nil matches any message
+	Message *regexp.Regexp
+	// Code is the ErrorCode of matching errors
+	Code common.ErrorCode
+	// Details optionally extracts hints from the Message submatches and the JSON-RPC error,
+	// which is nil for HTTP-level failures
+	Details func(match []string, e *JsonRpcError) map[string]interface{}
+}
+
+func (p *ErrorPattern) match(httpStatus int, e *JsonRpcError) ([]string, bool) {
+	if len(p.Codes) == 0 && len(p.HTTPStatuses) == 0 && p.Message == nil {
+		return nil, false
+	}
+	if len(p.Codes) > 0 && (e == nil || !containsInt(p.Codes, e.Code)) {
+		return nil, false
+	}
+	if len(p.HTTPStatuses) > 0 && !containsInt(p.HTTPStatuses, httpStatus) {
+		return nil, false
+	}
+	if p.Message == nil {
+		return nil, true
+	}
+	if e == nil {
+		return nil, false
+	}
+	match := p.Message.FindStringSubmatch(e.Message)
+	return match, match != nil
+}
+
+// ErrorClassifier translates the errors of nodes and RPC providers, which word the same
+// condition differently, into common.ErrorCode values using an ordered pattern table.
+type ErrorClassifier struct {
+	mu       sync.RWMutex
+	patterns []*ErrorPattern
+}
+
+// NewErrorClassifier returns a classifier trying patterns in order.
+func NewErrorClassifier(patterns ...*ErrorPattern) *ErrorClassifier {
+	return &ErrorClassifier{patterns: append([]*ErrorPattern{}, patterns...)}
+}
+
+// Add inserts patterns ahead of the existing ones, so they take precedence over the defaults.
+func (c *ErrorClassifier) Add(patterns ...*ErrorPattern) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.patterns = append(append([]*ErrorPattern{}, patterns...), c.patterns...)
+}
+
+// Classify converts an upstream error into a BaseError. httpStatus is the HTTP status of the
+// response (0 or 200 when not applicable) and e its JSON-RPC error member, which may be nil
+// for HTTP-level failures. The JSON-RPC code and data are kept as details alongside any hints
+// the matching pattern extracts; errors matching no pattern get ERROR_CODE_UNSPECIFIED.
+func (c *ErrorClassifier) Classify(httpStatus int, e *JsonRpcError) *common.BaseError {
+	message := http.StatusText(httpStatus)
+	if e != nil {
+		message = e.Message
+	}
+	baseErr := common.NewError(common.ErrorCode_ERROR_CODE_UNSPECIFIED, message)
+	if e != nil {
+		baseErr.WithCause(e).WithDetail(JsonRpcErrorCodeDetail, e.Code)
+		if !isJsonNull(e.Data) {
+			baseErr.WithDetail(JsonRpcErrorDataDetail, e.Data)
+		}
+	}
+	if httpStatus != 0 && httpStatus != http.StatusOK {
+		baseErr.WithDetail(ErrorHTTPStatusDetail, httpStatus)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, p := range c.patterns {
+		match, ok := p.match(httpStatus, e)
+		if !ok {
+			continue
+		}
+		baseErr.Code = p.Code
+		if p.Details != nil {
+			baseErr.WithDetails(p.Details(match, e))
+		}
+		break
+	}
+	return baseErr
+}
+
+// DefaultErrorClassifier classifies errors with DefaultErrorPatterns. It is used by
+// JsonRpcError.ToBaseError; call Add on it to teach it provider-specific errors.
+var DefaultErrorClassifier = NewErrorClassifier(DefaultErrorPatterns...)
+
+// DefaultErrorPatterns covers the error codes reserved by JSON-RPC 2.0 and EIP-1474 and the
+// messages of common node clients (geth, Erigon, Nethermind, Reth) and RPC providers.
+var DefaultErrorPatterns = []*ErrorPattern{
+	// Malformed ranges, such as fromBlock after toBlock, which no smaller range fixes
+	{
+		Message: regexp.MustCompile(`(?i)(?:invalid block range|invalid (?:from|to)Block|fromBlock (?:is )?(?:after|greater than|higher than) toBlock)`),
+		Code:    common.ErrorCode_INVALID_PARAMETER,
+	},
+
+	// Log and trace queries spanning too many blocks or returning too many results
+	{
+		Message: regexp.MustCompile(`(?i)this block range should work: \[(0x[0-9a-f]+),\s*(0x[0-9a-f]+)\]`),
+		Code:    common.ErrorCode_RANGE_TOO_LARGE,
+		Details: suggestedRangeDetails,
+	},
+	{
+		Message: regexp.MustCompile(`(?i)(?:query returned|returned|response has|exceeds?) more than ([\d,]+) (?:results|logs)`),
+		Code:    common.ErrorCode_RANGE_TOO_LARGE,
+		Details: maxResultsDetails,
+	},
+	{
+		Message: regexp.MustCompile(`(?i)(?:(?:block range|range of blocks) (?:is )?too (?:large|wide|big)|(?:block range|range of blocks) (?:limit )?exceed|exceeds? (?:the )?(?:allowed )?(?:block range|range of blocks)|blocks? distance (?:is )?(?:greater|more|larger) than|max(?:imum)? (?:block )?range|block range (?:limit|cannot exceed|can ?not exceed)|limited to (?:a )?[\d,]+ (?:blocks? )?range)`),
+		Code:    common.ErrorCode_RANGE_TOO_LARGE,
+		Details: maxBlockRangeDetails,
+	},
+	{
+		Message: regexp.MustCompile(`(?i)(?:log response size exceeded|response size (?:is )?too (?:large|big)|query timeout exceeded|too many (?:logs|results))`),
+		Code:    common.ErrorCode_RANGE_TOO_LARGE,
+	},
+
+	// Rate limits and quota
+	{HTTPStatuses: []int{http.StatusTooManyRequests}, Code: common.ErrorCode_RATE_LIMITED},
+	{Codes: []int{429}, Code: common.ErrorCode_RATE_LIMITED},
+	{
+		Message: regexp.MustCompile(`(?i)(?:rate.?limit|too many requests|request rate exceeded|exceeded .*(?:capacity|quota|compute units)|throughput|daily request count|credits? (?:limit|exceeded))`),
+		Code:    common.ErrorCode_RATE_LIMITED,
+	},
+
+	// Methods the node or provider does not serve
+	{Codes: []int{-32601, -32004}, Code: common.ErrorCode_UNSUPPORTED_METHOD},
+	{
+		Message: regexp.MustCompile(`(?i)(?:method .*(?:not (?:found|supported|available|allowed|enabled)|does not exist|is not available)|unsupported method|unknown method|not whitelisted)`),
+		Code:    common.ErrorCode_UNSUPPORTED_METHOD,
+	},
+
+	// Block tags the node does not support
+	{
+		Message: regexp.MustCompile(`(?i)(?:(?:unsupported|invalid|unknown) block tag|(?:safe|finalized|pending) (?:block|tag) (?:is )?not (?:found|supported|available)|tag .* not supported)`),
+		Code:    common.ErrorCode_UNSUPPORTED_BLOCK_TAG,
+	},
+
+	// History that the node has pruned or not yet synced
+	{
+		Message: regexp.MustCompile(`(?i)(?:missing trie node|pruned|historical state .* (?:is )?not available|state (?:is )?not available|state histories haven't been fully indexed|requested block is (?:too old|in the future)|beyond (?:the )?current head|block .* is not available)`),
+		Code:    common.ErrorCode_RANGE_OUTSIDE_AVAILABLE,
+	},
+
+	// Missing blocks, transactions and other resources
+	{
+		Message: regexp.MustCompile(`(?i)(?:header not found|block not found|unknown block|transaction not found|receipt not found|not found)`),
+		Code:    common.ErrorCode_DATA_NOT_FOUND,
+	},
+	{Codes: []int{-32001}, Code: common.ErrorCode_DATA_NOT_FOUND},
+
+	// Timeouts
+	{HTTPStatuses: []int{http.StatusRequestTimeout, http.StatusGatewayTimeout}, Code: common.ErrorCode_TIMEOUT_ERROR},
+	{
+		Message: regexp.MustCompile(`(?i)(?:timeout|timed out|deadline exceeded)`),
+		Code:    common.ErrorCode_TIMEOUT_ERROR,
+	},
+
+	// EIP-1474 "limit exceeded" without a more specific message
+	{Codes: []int{-32005}, Code: common.ErrorCode_RATE_LIMITED},
+
+	// Codes reserved by JSON-RPC 2.0
+	{Codes: []int{-32700, -32600}, Code: common.ErrorCode_INVALID_REQUEST},
+	{Codes: []int{-32602}, Code: common.ErrorCode_INVALID_PARAMETER},
+	{Codes: []int{-32603}, Code: common.ErrorCode_INTERNAL_ERROR},
+	{HTTPStatuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}, Code: common.ErrorCode_INTERNAL_ERROR},
+}
+
+// suggestedRangeDetails extracts a retry range such as "[0x1, 0x2a]" from the message.
+func suggestedRangeDetails(match []string, _ *JsonRpcError) map[string]interface{} {
+	details := map[string]interface{}{}
+	if from, err := HexToUint64(match[1]); err == nil {
+		details[ErrorSuggestedFromBlockDetail] = from
+	}
+	if to, err := HexToUint64(match[2]); err == nil {
+		details[ErrorSuggestedToBlockDetail] = to
+	}
+	return details
+}
+
+// maxResultsDetails extracts the result cap from the message and the retry range that some
+// providers put in the error data as {"from": "0x…", "to": "0x…"}.
+func maxResultsDetails(match []string, e *JsonRpcError) map[string]interface{} {
+	details := map[string]interface{}{}
+	if n, ok := parseGroupedInt(match[1]); ok {
+		details[ErrorMaxResultsDetail] = n
+	}
+	var hint struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if e != nil && !isJsonNull(e.Data) && json.Unmarshal(e.Data, &hint) == nil && hint.From != "" && hint.To != "" {
+		from, errFrom := NumberishToUint64(hint.From)
+		to, errTo := NumberishToUint64(hint.To)
+		if errFrom == nil && errTo == nil {
+			details[ErrorSuggestedFromBlockDetail] = from
+			details[ErrorSuggestedToBlockDetail] = to
+		}
+	}
+	return details
+}
+
+var blockRangeLimit = regexp.MustCompile(`\d[\d,]*\d`)
+
+// maxBlockRangeDetails extracts the block range limit, the first number of at least two
+// digits in the message, e.g. "exceed maximum block range: 5000".
+func maxBlockRangeDetails(_ []string, e *JsonRpcError) map[string]interface{} {
+	details := map[string]interface{}{}
+	if m := blockRangeLimit.FindString(e.Message); m != "" {
+		if n, ok := parseGroupedInt(m); ok {
+			details[ErrorMaxBlockRangeDetail] = n
+		}
+	}
+	return details
+}
+
+func parseGroupedInt(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(strings.ReplaceAll(s, ",", ""), 10, 64)
+	return n, err == nil
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package evm
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+func TestErrorClassifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpStatus int
+		err        *JsonRpcError
+		want       common.ErrorCode
+		details    map[string]interface{}
+	}{
+		{
+			name: "InfuraTooManyResults",
+			err:  &JsonRpcError{Code: -32005, Message: "query returned more than 10000 results", Data: json.RawMessage(`{"from":"0x1312d00","to":"0x1312f5a"}`)},
+			want: common.ErrorCode_RANGE_TOO_LARGE,
+			details: map[string]interface{}{
+				ErrorMaxResultsDetail:         uint64(10000),
+				ErrorSuggestedFromBlockDetail: uint64(20000000),
+				ErrorSuggestedToBlockDetail:   uint64(20000602),
+			},
+		},
+		{
+			name: "AlchemySuggestedRange",
+			err:  &JsonRpcError{Code: -32602, Message: "Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range and no limit on the response size, or you can request any block range with a cap of 10K logs in the response. Based on your parameters, this block range should work: [0x1312d00, 0x1312d7f]"},
+			want: common.ErrorCode_RANGE_TOO_LARGE,
+			details: map[string]interface{}{
+				ErrorSuggestedFromBlockDetail: uint64(20000000),
+				ErrorSuggestedToBlockDetail:   uint64(20000127),
+			},
+		},
+		{
+			name:    "MaxBlockRange",
+			err:     &JsonRpcError{Code: -32000, Message: "exceed maximum block range: 5000"},
+			want:    common.ErrorCode_RANGE_TOO_LARGE,
+			details: map[string]interface{}{ErrorMaxBlockRangeDetail: uint64(5000)},
+		},
+		{
+			name:    "LimitedRange",
+			err:     &JsonRpcError{Code: -32614, Message: "eth_getLogs is limited to a 10,000 range"},
+			want:    common.ErrorCode_RANGE_TOO_LARGE,
+			details: map[string]interface{}{ErrorMaxBlockRangeDetail: uint64(10000)},
+		},
+		{
+			name: "BlockRangeTooLarge",
+			err:  &JsonRpcError{Code: -32000, Message: "block range too large"},
+			want: common.ErrorCode_RANGE_TOO_LARGE,
+		},
+		{
+			name: "BlockRangeLimitExceeded",
+			err:  &JsonRpcError{Code: -32000, Message: "Block range limit exceeded."},
+			want: common.ErrorCode_RANGE_TOO_LARGE,
+		},
+		{
+			name: "InvalidBlockRange",
+			err:  &JsonRpcError{Code: -32000, Message: "invalid block range params"},
+			want: common.ErrorCode_INVALID_PARAMETER,
+		},
+		{
+			name:       "HTTP429",
+			httpStatus: http.StatusTooManyRequests,
+			want:       common.ErrorCode_RATE_LIMITED,
+			details:    map[string]interface{}{ErrorHTTPStatusDetail: http.StatusTooManyRequests},
+		},
+		{
+			name: "RateLimitMessage",
+			err:  &JsonRpcError{Code: -32005, Message: "daily request count exceeded, request rate limited"},
+			want: common.ErrorCode_RATE_LIMITED,
+		},
+		{
+			name: "BareLimitExceeded",
+			err:  &JsonRpcError{Code: -32005, Message: "limit exceeded"},
+			want: common.ErrorCode_RATE_LIMITED,
+		},
+		{
+			name: "MethodNotFound",
+			err:  &JsonRpcError{Code: -32601, Message: "Method not found"},
+			want: common.ErrorCode_UNSUPPORTED_METHOD,
+		},
+		{
+			name: "GethMethodMissing",
+			err:  &JsonRpcError{Code: -32000, Message: "the method trace_block does not exist/is not available"},
+			want: common.ErrorCode_UNSUPPORTED_METHOD,
+		},
+		{
+			name: "HeaderNotFound",
+			err:  &JsonRpcError{Code: -32000, Message: "header not found"},
+			want: common.ErrorCode_DATA_NOT_FOUND,
+		},
+		{
+			name: "MissingTrieNode",
+			err:  &JsonRpcError{Code: -32000, Message: "missing trie node 5a1e0c1e4e2f (path ) state 0x5a1e0c1e4e2f is not available"},
+			want: common.ErrorCode_RANGE_OUTSIDE_AVAILABLE,
+		},
+		{
+			name: "FinalizedTag",
+			err:  &JsonRpcError{Code: -32000, Message: "finalized block not found"},
+			want: common.ErrorCode_UNSUPPORTED_BLOCK_TAG,
+		},
+		{
+			name:       "GatewayTimeout",
+			httpStatus: http.StatusGatewayTimeout,
+			want:       common.ErrorCode_TIMEOUT_ERROR,
+		},
+		{
+			name: "InvalidParams",
+			err:  &JsonRpcError{Code: -32602, Message: "invalid argument 0: hex string without 0x prefix"},
+			want: common.ErrorCode_INVALID_PARAMETER,
+		},
+		{
+			name: "Unknown",
+			err:  &JsonRpcError{Code: 3, Message: "execution reverted", Data: json.RawMessage(`"0x08c379a0"`)},
+			want: common.ErrorCode_ERROR_CODE_UNSPECIFIED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultErrorClassifier.Classify(tt.httpStatus, tt.err)
+			if got.Code != tt.want {
+				t.Errorf("Classify() code = %v, want %v (message %q)", got.Code, tt.want, got.Message)
+			}
+			for k, v := range tt.details {
+				if got.Details[k] != v {
+					t.Errorf("detail %s = %#v, want %#v", k, got.Details[k], v)
+				}
+			}
+			if tt.err != nil && got.Details[JsonRpcErrorCodeDetail] != tt.err.Code {
+				t.Errorf("Expected original code %d to be kept, got %#v", tt.err.Code, got.Details[JsonRpcErrorCodeDetail])
+			}
+		})
+	}
+}
+
+func TestErrorClassifierAdd(t *testing.T) {
+	c := NewErrorClassifier(DefaultErrorPatterns...)
+	rpcErr := &JsonRpcError{Code: -32000, Message: "ratelimited: upgrade your plan"}
+	if got := c.Classify(0, rpcErr).Code; got != common.ErrorCode_RATE_LIMITED {
+		t.Fatalf("Expected default RATE_LIMITED, got %v", got)
+	}
+
+	c.Add(&ErrorPattern{
+		Codes:   []int{-32000},
+		Message: regexp.MustCompile(`upgrade your plan`),
+		Code:    common.ErrorCode_RANGE_OUTSIDE_AVAILABLE,
+		Details: func(match []string, e *JsonRpcError) map[string]interface{} {
+			return map[string]interface{}{"plan": "free"}
+		},
+	})
+	got := c.Classify(0, rpcErr)
+	if got.Code != common.ErrorCode_RANGE_OUTSIDE_AVAILABLE || got.Details["plan"] != "free" {
+		t.Errorf("Expected added pattern to take precedence, got %v %v", got.Code, got.Details)
+	}
+	if DefaultErrorClassifier.Classify(0, rpcErr).Code != common.ErrorCode_RATE_LIMITED {
+		t.Error("Adding to a classifier must not change DefaultErrorClassifier")
+	}
+}
@@ -0,0 +1,155 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Extensions preserve JSON-RPC fields that the JsonRpc structs do not declare (new fork fields,
+// chain-specific extras). They are captured while decoding, carried on the proto in the
+// extensions map as raw JSON text, and written back by the ...ToJsonRpc and Append... functions
+// so a JSON-RPC -> proto -> JSON-RPC round trip is lossless.
+
+// jsonRpcFieldIndexes caches, for each JsonRpc struct type, the index of the field declaring
+// each JSON name.
+var jsonRpcFieldIndexes sync.Map // reflect.Type -> map[string]int
+
+func declaredJsonFields(t reflect.Type) map[string]int {
+	if names, ok := jsonRpcFieldIndexes.Load(t); ok {
+		return names.(map[string]int)
+	}
+	names := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = i
+		}
+	}
+	jsonRpcFieldIndexes.Store(t, names)
+	return names
+}
+
+// decodeWithExtensions decodes the JSON object data into the struct v points to in a single
+// pass, returning the members whose keys v does not declare with values compacted, or nil
+// when every key is declared. Unlike json.Unmarshal, keys must match a field's name exactly;
+// differently cased keys are kept as extensions.
+func decodeWithExtensions(data []byte, v interface{}) (map[string]string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		// Let json.Unmarshal handle null and report other values
+		return nil, json.Unmarshal(data, v)
+	}
+	rv := reflect.ValueOf(v).Elem()
+	declared := declaredJsonFields(rv.Type())
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var extensions map[string]string
+	var typeErr error
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		if i, ok := declared[key]; ok {
+			err := dec.Decode(rv.Field(i).Addr().Interface())
+			var ute *json.UnmarshalTypeError
+			if errors.As(err, &ute) {
+				// Like json.Unmarshal, keep decoding and report the first mismatch
+				if ute.Field == "" {
+					ute.Field = key
+				}
+				if typeErr == nil {
+					typeErr = err
+				}
+			} else if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, err
+		}
+		if extensions == nil {
+			extensions = make(map[string]string)
+		}
+		extensions[key] = compact.String()
+	}
+	return extensions, typeErr
+}
+
+// extensionValue returns the value to place in a ...ToJsonRpc map for an extension. Values that
+// are not valid JSON (set by hand on the proto) are emitted as JSON strings.
+func extensionValue(v string) interface{} {
+	if json.Valid([]byte(v)) {
+		return json.RawMessage(v)
+	}
+	return v
+}
+
+// addExtensions copies extensions into a ...ToJsonRpc map without overriding modelled fields.
+func addExtensions(out map[string]interface{}, extensions map[string]string) {
+	for k, v := range extensions {
+		if _, ok := out[k]; !ok {
+			out[k] = extensionValue(v)
+		}
+	}
+}
+
+// sortedExtensionKeys returns the extension keys in the order json.Marshal writes map keys.
+func sortedExtensionKeys(extensions map[string]string) []string {
+	if len(extensions) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(extensions))
+	for k := range extensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UnmarshalJSON decodes the block and captures undeclared fields into Extensions.
+func (b *JsonRpcBlock) UnmarshalJSON(data []byte) error {
+	type plain JsonRpcBlock
+	extensions, err := decodeWithExtensions(data, (*plain)(b))
+	if err != nil {
+		return err
+	}
+	b.Extensions = extensions
+	return nil
+}
+
+// UnmarshalJSON decodes the receipt and captures undeclared fields into Extensions.
+func (r *JsonRpcReceipt) UnmarshalJSON(data []byte) error {
+	type plain JsonRpcReceipt
+	extensions, err := decodeWithExtensions(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+	r.Extensions = extensions
+	return nil
+}
+
+// UnmarshalJSON decodes the log and captures undeclared fields into Extensions.
+func (l *JsonRpcLog) UnmarshalJSON(data []byte) error {
+	type plain JsonRpcLog
+	extensions, err := decodeWithExtensions(data, (*plain)(l))
+	if err != nil {
+		return err
+	}
+	l.Extensions = extensions
+	return nil
+}
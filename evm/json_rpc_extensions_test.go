@@ -0,0 +1,167 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+const extensionsTestBlockJson = `{
+  "number": "0x121eac0",
+  "hash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+  "parentHash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+  "timestamp": "0x6553f100",
+  "gasLimit": "0x1c9c380",
+  "gasUsed": "0xe4e1c0",
+  "size": "0x400",
+  "transactions": [],
+  "l1BatchNumber": "0x5",
+  "l1BatchTimestamp": null,
+  "sealFields": [ "0x01", {"nested": true} ]
+}`
+
+const extensionsTestReceiptJson = `{
+  "transactionHash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+  "blockNumber": "0x121eac0",
+  "transactionIndex": "0x0",
+  "gasUsed": "0x5208",
+  "cumulativeGasUsed": "0x5208",
+  "status": "0x1",
+  "l1BatchTxIndex": "0x2",
+  "logs": [{
+    "address": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+    "blockNumber": "0x121eac0",
+    "logIndex": "0x0",
+    "transactionIndex": "0x0",
+    "topics": [],
+    "l1BatchNumber": "0x5",
+    "logType": "<none>"
+  }]
+}`
+
+func TestJsonRpcExtensions(t *testing.T) {
+	t.Run("BlockRoundTrip", func(t *testing.T) {
+		var b JsonRpcBlock
+		if err := json.Unmarshal([]byte(extensionsTestBlockJson), &b); err != nil {
+			t.Fatalf("Failed to unmarshal block: %v", err)
+		}
+		block, err := b.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert block: %v", err)
+		}
+		want := map[string]string{
+			"l1BatchNumber":    `"0x5"`,
+			"l1BatchTimestamp": `null`,
+			"sealFields":       `["0x01",{"nested":true}]`,
+		}
+		if !reflect.DeepEqual(block.Header.Extensions, want) {
+			t.Errorf("Unexpected extensions\nwant: %v\ngot:  %v", want, block.Header.Extensions)
+		}
+
+		encoded, err := json.Marshal(BlockToJsonRpc(block.Header, nil, nil, nil))
+		if err != nil {
+			t.Fatalf("Failed to marshal block: %v", err)
+		}
+		var again JsonRpcBlock
+		if err := json.Unmarshal(encoded, &again); err != nil {
+			t.Fatalf("Failed to unmarshal round-tripped block: %v", err)
+		}
+		if !reflect.DeepEqual(again.Extensions, want) {
+			t.Errorf("Extensions lost in round trip\nwant: %v\ngot:  %v", want, again.Extensions)
+		}
+	})
+
+	t.Run("ReceiptAndLogs", func(t *testing.T) {
+		var r JsonRpcReceipt
+		if err := json.Unmarshal([]byte(extensionsTestReceiptJson), &r); err != nil {
+			t.Fatalf("Failed to unmarshal receipt: %v", err)
+		}
+		receipt, err := r.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert receipt: %v", err)
+		}
+		if receipt.Extensions["l1BatchTxIndex"] != `"0x2"` {
+			t.Errorf("Receipt extension not captured: %v", receipt.Extensions)
+		}
+		log := receipt.Logs[0]
+		if log.Extensions["l1BatchNumber"] != `"0x5"` || log.Extensions["logType"] != `"<none>"` {
+			t.Errorf("Log extensions not captured: %v", log.Extensions)
+		}
+
+		out := ReceiptToJsonRpc(receipt)
+		if string(out["l1BatchTxIndex"].(json.RawMessage)) != `"0x2"` {
+			t.Errorf("Receipt extension not emitted: %v", out["l1BatchTxIndex"])
+		}
+		logOut := LogToJsonRpc(log)
+		if string(logOut["logType"].(json.RawMessage)) != `"<none>"` {
+			t.Errorf("Log extension not emitted: %v", logOut["logType"])
+		}
+	})
+
+	t.Run("SinglePass", func(t *testing.T) {
+		var l JsonRpcLog
+		err := json.Unmarshal([]byte(`{"address": 5, "logIndex": "0x1", "topics": ["0x02"]}`), &l)
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field != "address" {
+			t.Errorf("Expected a type error for address, got %v", err)
+		}
+		if l.LogIndex != "0x1" || len(l.Topics) != 1 {
+			t.Errorf("Expected the fields after the mismatch to be decoded, got %+v", l)
+		}
+		l = JsonRpcLog{}
+		if err := json.Unmarshal([]byte(`{"logIndex": "0x1", "Data": "0x01"}`), &l); err != nil {
+			t.Fatalf("Failed to unmarshal log: %v", err)
+		}
+		if l.Data != "" || l.Extensions["Data"] != `"0x01"` {
+			t.Errorf("Expected differently cased keys to be kept as extensions, got %q and %v", l.Data, l.Extensions)
+		}
+
+		var null JsonRpcLog
+		if err := null.UnmarshalJSON([]byte("null")); err != nil || null.Extensions != nil {
+			t.Errorf("Expected null to be a no-op, got %v", err)
+		}
+	})
+
+	t.Run("EncodersMatchMapOutput", func(t *testing.T) {
+		ext := map[string]string{
+			"aaa":       `1`,
+			"hash":      `"shadowed"`,
+			"mixHashes": `[ "0x01" ]`,
+			"tag<>":     `{"a": "<b>"}`,
+			"zzz":       `not json`,
+		}
+
+		header := fullEncodeTestHeader()
+		header.Extensions = ext
+		assertSameAsMap(t, BlockToJsonRpc(header, nil, nil, nil), AppendBlockJsonRpc(nil, header, nil, nil, nil))
+
+		r := fullEncodeTestReceipt()
+		r.Extensions = ext
+		r.Logs[0].Extensions = ext
+		assertSameAsMap(t, ReceiptToJsonRpc(r), AppendReceiptJsonRpc(nil, r))
+
+		l := encodeTestLog()
+		l.BlockTimestamp = nil
+		l.Extensions = map[string]string{"blockTimestamp": `"0x1"`}
+		assertSameAsMap(t, LogToJsonRpc(l), AppendLogJsonRpc(nil, l))
+	})
+
+	t.Run("StrictRejectsExtensions", func(t *testing.T) {
+		var b JsonRpcBlock
+		if err := json.Unmarshal([]byte(extensionsTestBlockJson), &b); err != nil {
+			t.Fatalf("Failed to unmarshal block: %v", err)
+		}
+		_, err := b.ToProto(WithDialect(DialectGeth()), WithStrict(true), WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected a BaseError, got %T: %v", err, err)
+		}
+		want := []string{"l1BatchNumber", "l1BatchTimestamp", "sealFields"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package evm
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// JsonRpcFeeHistory is the result of eth_feeHistory.
+type JsonRpcFeeHistory struct {
+	OldestBlock       string     `json:"oldestBlock"`
+	BaseFeePerGas     []string   `json:"baseFeePerGas"`
+	GasUsedRatio      []float64  `json:"gasUsedRatio"`
+	Reward            [][]string `json:"reward,omitempty"`
+	BaseFeePerBlobGas []string   `json:"baseFeePerBlobGas,omitempty"`
+	BlobGasUsedRatio  []float64  `json:"blobGasUsedRatio,omitempty"`
+}
+
+// ToProto converts an eth_feeHistory result into a FeeHistory.
+func (f *JsonRpcFeeHistory) ToProto(opts ...ConvertOption) (*FeeHistory, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+
+	history := &FeeHistory{
+		OldestBlock:       p.uint64("oldestBlock", f.OldestBlock),
+		BaseFeePerGas:     p.weiList("baseFeePerGas", f.BaseFeePerGas),
+		GasUsedRatio:      f.GasUsedRatio,
+		BaseFeePerBlobGas: p.weiList("baseFeePerBlobGas", f.BaseFeePerBlobGas),
+		BlobGasUsedRatio:  f.BlobGasUsedRatio,
+	}
+	for i, values := range f.Reward {
+		history.Reward = append(history.Reward, &FeeHistoryReward{
+			Values: p.weiList("reward["+strconv.Itoa(i)+"]", values),
+		})
+	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// FeeHistoryToJsonRpc converts a FeeHistory into the eth_feeHistory result format. The reward
+// and blob fields are omitted when empty, as nodes do.
+func FeeHistoryToJsonRpc(history *FeeHistory) map[string]interface{} {
+	if history == nil {
+		return nil
+	}
+	o := map[string]interface{}{
+		"oldestBlock":   fmt.Sprintf("0x%x", history.OldestBlock),
+		"baseFeePerGas": weiListToJsonRpc(history.BaseFeePerGas),
+		"gasUsedRatio":  ratiosToJsonRpc(history.GasUsedRatio),
+	}
+	if len(history.Reward) > 0 {
+		reward := make([][]string, len(history.Reward))
+		for i, r := range history.Reward {
+			reward[i] = weiListToJsonRpc(r.GetValues())
+		}
+		o["reward"] = reward
+	}
+	if len(history.BaseFeePerBlobGas) > 0 {
+		o["baseFeePerBlobGas"] = weiListToJsonRpc(history.BaseFeePerBlobGas)
+	}
+	if len(history.BlobGasUsedRatio) > 0 {
+		o["blobGasUsedRatio"] = ratiosToJsonRpc(history.BlobGasUsedRatio)
+	}
+	return o
+}
+
+func weiListToJsonRpc(list []string) []string {
+	out := make([]string, len(list))
+	for i, s := range list {
+		hex, err := DecimalStringToHex(s)
+		if err != nil {
+			hex = s
+		}
+		out[i] = hex
+	}
+	return out
+}
+
+func ratiosToJsonRpc(list []float64) []float64 {
+	if list == nil {
+		return []float64{}
+	}
+	return list
+}
@@ -0,0 +1,103 @@
+package evm
+
+import "fmt"
+
+// JsonRpcStorageProof is one entry of the storageProof array of an eth_getProof result.
+type JsonRpcStorageProof struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// JsonRpcAccountProof is the result of eth_getProof.
+type JsonRpcAccountProof struct {
+	Address      string                 `json:"address"`
+	AccountProof []string               `json:"accountProof"`
+	Balance      string                 `json:"balance"`
+	CodeHash     string                 `json:"codeHash"`
+	Nonce        string                 `json:"nonce"`
+	StorageHash  string                 `json:"storageHash"`
+	StorageProof []*JsonRpcStorageProof `json:"storageProof"`
+}
+
+// ToProto converts an eth_getProof result into an AccountProof. eth_getProof does not echo the
+// block it was answered for, so when a header is given its number and hash are recorded.
+func (a *JsonRpcAccountProof) ToProto(header *BlockHeader, opts ...ConvertOption) (*AccountProof, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+
+	proof := &AccountProof{
+		Address:      p.bytes("address", a.Address),
+		Balance:      a.Balance,
+		CodeHash:     p.bytes("codeHash", a.CodeHash),
+		Nonce:        p.uint64("nonce", a.Nonce),
+		StorageHash:  p.bytes("storageHash", a.StorageHash),
+		AccountProof: p.bytesList("accountProof", a.AccountProof),
+	}
+	if _, ok := parseWei(a.Balance); !ok {
+		p.fail("balance", fmt.Errorf("invalid quantity: %s", a.Balance))
+	}
+	for i, sp := range a.StorageProof {
+		if p.done() {
+			break
+		}
+		if sp == nil {
+			continue
+		}
+		ip := p.item("storageProof", i)
+		value, err := storageWord(sp.Value)
+		if err != nil {
+			ip.fail("value", err)
+		}
+		proof.StorageProof = append(proof.StorageProof, &StorageProof{
+			Key:   ip.bytes("key", sp.Key),
+			Value: value,
+			Proof: ip.bytesList("proof", sp.Proof),
+		})
+	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+
+	if header != nil {
+		proof.BlockNumber = Uint64Ptr(header.Number)
+		proof.BlockHash = header.Hash
+	}
+	return proof, nil
+}
+
+// AccountProofToJsonRpc converts an AccountProof into the eth_getProof result format.
+func AccountProofToJsonRpc(proof *AccountProof) map[string]interface{} {
+	if proof == nil {
+		return nil
+	}
+	balance, err := DecimalStringToHex(proof.Balance)
+	if err != nil {
+		balance = proof.Balance
+	}
+	storageProof := make([]interface{}, 0, len(proof.StorageProof))
+	for _, sp := range proof.StorageProof {
+		storageProof = append(storageProof, map[string]interface{}{
+			"key":   BytesToHex(sp.GetKey()),
+			"value": BytesToQuantityHex(sp.GetValue()),
+			"proof": bytesListToHex(sp.GetProof()),
+		})
+	}
+	return map[string]interface{}{
+		"address":      BytesToHex(proof.Address),
+		"accountProof": bytesListToHex(proof.AccountProof),
+		"balance":      balance,
+		"codeHash":     BytesToHex(proof.CodeHash),
+		"nonce":        fmt.Sprintf("0x%x", proof.Nonce),
+		"storageHash":  BytesToHex(proof.StorageHash),
+		"storageProof": storageProof,
+	}
+}
+
+func bytesListToHex(list [][]byte) []string {
+	out := make([]string, len(list))
+	for i, b := range list {
+		out[i] = BytesToHex(b)
+	}
+	return out
+}
@@ -0,0 +1,578 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JsonRpcPrestateAccount is the per-account state reported by geth's prestateTracer.
+// In diff mode "pre" carries the original values and "post" only the values that changed.
+type JsonRpcPrestateAccount struct {
+	Balance string            `json:"balance,omitempty"`
+	Nonce   *jsonRpcNumberish `json:"nonce,omitempty"`
+	Code    string            `json:"code,omitempty"`
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// JsonRpcPrestateDiff is the result of geth's prestateTracer with {"diffMode": true}.
+type JsonRpcPrestateDiff struct {
+	Pre  map[string]*JsonRpcPrestateAccount `json:"pre"`
+	Post map[string]*JsonRpcPrestateAccount `json:"post"`
+}
+
+// ToProto converts a prestateTracer diff into a StateDiff. Accounts only present in "post", or
+// present in "pre" with no balance, nonce, code or storage, are reported as created and accounts
+// only present in "pre" as deleted. Balances are stored as canonical hex quantities.
+func (d *JsonRpcPrestateDiff) ToProto() (*StateDiff, error) {
+	addresses := make(map[string]struct{}, len(d.Pre)+len(d.Post))
+	for addr := range d.Pre {
+		addresses[addr] = struct{}{}
+	}
+	for addr := range d.Post {
+		addresses[addr] = struct{}{}
+	}
+
+	diff := &StateDiff{}
+	for addr := range addresses {
+		pre, inPre := d.Pre[addr]
+		post, inPost := d.Post[addr]
+		if pre == nil {
+			pre = &JsonRpcPrestateAccount{}
+		}
+		if post == nil {
+			post = &JsonRpcPrestateAccount{}
+		}
+
+		address, err := HexToBytes(addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state diff address: %w", err)
+		}
+
+		preBalance, err := DecimalStringToHex(pre.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pre balance of %s: %w", addr, err)
+		}
+		postBalance, err := DecimalStringToHex(post.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse post balance of %s: %w", addr, err)
+		}
+		preNonce, err := pre.nonce()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pre nonce of %s: %w", addr, err)
+		}
+		postNonce, err := post.nonce()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse post nonce of %s: %w", addr, err)
+		}
+		preCode, err := HexToBytes(pre.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pre code of %s: %w", addr, err)
+		}
+		postCode, err := HexToBytes(post.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse post code of %s: %w", addr, err)
+		}
+
+		// Geth lists accounts created by the transaction in "pre" with a zero balance, where
+		// parity marks them as added, so an empty pre account counts as created.
+		preEmpty := preBalance == "0x0" && preNonce == 0 && len(preCode) == 0 && len(pre.Storage) == 0
+		account := &AccountDiff{
+			Address: address,
+			Created: inPost && (!inPre || preEmpty),
+			Deleted: inPre && !inPost,
+		}
+		switch {
+		case account.Created:
+			account.BalanceAfter = StringPtr(postBalance)
+			account.NonceAfter = Uint64Ptr(postNonce)
+			account.CodeAfter = postCode
+		case account.Deleted:
+			account.BalanceBefore = StringPtr(preBalance)
+			account.NonceBefore = Uint64Ptr(preNonce)
+			account.CodeBefore = preCode
+		default:
+			if post.Balance != "" && preBalance != postBalance {
+				account.BalanceBefore = StringPtr(preBalance)
+				account.BalanceAfter = StringPtr(postBalance)
+			}
+			if post.Nonce != nil && preNonce != postNonce {
+				account.NonceBefore = Uint64Ptr(preNonce)
+				account.NonceAfter = Uint64Ptr(postNonce)
+			}
+			if post.Code != "" && !bytes.Equal(preCode, postCode) {
+				account.CodeBefore = preCode
+				account.CodeAfter = postCode
+			}
+		}
+
+		slots := make(map[string]struct{}, len(pre.Storage)+len(post.Storage))
+		for slot := range pre.Storage {
+			slots[slot] = struct{}{}
+		}
+		for slot := range post.Storage {
+			slots[slot] = struct{}{}
+		}
+		for slot := range slots {
+			change, err := newStorageChange(slot, pre.Storage[slot], post.Storage[slot])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse storage of %s: %w", addr, err)
+			}
+			if change != nil {
+				account.Storage = append(account.Storage, change)
+			}
+		}
+		sortStorageChanges(account.Storage)
+
+		diff.Accounts = append(diff.Accounts, account)
+	}
+	sortAccountDiffs(diff.Accounts)
+	return diff, nil
+}
+
+func (a *JsonRpcPrestateAccount) nonce() (uint64, error) {
+	if a.Nonce == nil || *a.Nonce == "" {
+		return 0, nil
+	}
+	return NumberishToUint64(string(*a.Nonce))
+}
+
+// JsonRpcPrestateDiffTrace is one entry of the debug_traceBlockByNumber / debug_traceBlockByHash
+// result array when the prestateTracer is used in diff mode.
+type JsonRpcPrestateDiffTrace struct {
+	TxHash string               `json:"txHash"`
+	Result *JsonRpcPrestateDiff `json:"result"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// ParseJsonRpcPrestateDiffs converts a block of prestateTracer diff results into state diffs.
+// When a header is given the block number, block hash and transaction index are filled in.
+func ParseJsonRpcPrestateDiffs(results []*JsonRpcPrestateDiffTrace, header *BlockHeader) ([]*StateDiff, error) {
+	diffs := make([]*StateDiff, 0, len(results))
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		if r.Error != "" {
+			return nil, fmt.Errorf("state diff of transaction %s failed: %s", r.TxHash, r.Error)
+		}
+		if r.Result == nil {
+			return nil, fmt.Errorf("state diff of transaction %s has no result", r.TxHash)
+		}
+		diff, err := r.Result.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state diff %d: %w", i, err)
+		}
+		if diff.TransactionHash, err = HexToBytes(r.TxHash); err != nil {
+			return nil, fmt.Errorf("failed to parse state diff %d txHash: %w", i, err)
+		}
+		if header != nil {
+			diff.BlockNumber = Uint64Ptr(header.Number)
+			diff.BlockHash = header.Hash
+			diff.TransactionIndex = Uint32Ptr(uint32(i))
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// JsonRpcParityDelta is a parity stateDiff value: "=" (unchanged), {"+": v} (added),
+// {"-": v} (removed) or {"*": {"from": a, "to": b}} (changed).
+type JsonRpcParityDelta struct {
+	From *string
+	To   *string
+}
+
+func (d *JsonRpcParityDelta) UnmarshalJSON(data []byte) error {
+	*d = JsonRpcParityDelta{}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s != "=" {
+			return fmt.Errorf("unsupported state diff marker: %s", s)
+		}
+		return nil
+	}
+
+	var raw struct {
+		Added   *string `json:"+"`
+		Removed *string `json:"-,"`
+		Changed *struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"*"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch {
+	case raw.Added != nil:
+		d.To = raw.Added
+	case raw.Removed != nil:
+		d.From = raw.Removed
+	case raw.Changed != nil:
+		d.From = &raw.Changed.From
+		d.To = &raw.Changed.To
+	}
+	return nil
+}
+
+func (d JsonRpcParityDelta) MarshalJSON() ([]byte, error) {
+	return json.Marshal(parityDelta(d.From, d.To))
+}
+
+// JsonRpcParityAccountDiff is the per-account entry of a parity stateDiff.
+type JsonRpcParityAccountDiff struct {
+	Balance JsonRpcParityDelta            `json:"balance"`
+	Nonce   JsonRpcParityDelta            `json:"nonce"`
+	Code    JsonRpcParityDelta            `json:"code"`
+	Storage map[string]JsonRpcParityDelta `json:"storage"`
+}
+
+// JsonRpcParityStateDiff is the stateDiff object returned by trace_replayTransaction and
+// trace_replayBlockTransactions with the "stateDiff" trace type, keyed by account address.
+type JsonRpcParityStateDiff map[string]*JsonRpcParityAccountDiff
+
+// ToProto converts a parity stateDiff into a StateDiff. Accounts whose balance is marked "+" are
+// reported as created and those marked "-" as deleted. Balances are stored as canonical hex quantities.
+func (d JsonRpcParityStateDiff) ToProto() (*StateDiff, error) {
+	diff := &StateDiff{}
+	for addr, a := range d {
+		if a == nil {
+			continue
+		}
+		address, err := HexToBytes(addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state diff address: %w", err)
+		}
+		account := &AccountDiff{
+			Address: address,
+			Created: a.Balance.From == nil && a.Balance.To != nil && a.Nonce.From == nil,
+			Deleted: a.Balance.From != nil && a.Balance.To == nil && a.Nonce.To == nil,
+		}
+
+		if a.Balance.From != nil {
+			b, err := DecimalStringToHex(*a.Balance.From)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse balance of %s: %w", addr, err)
+			}
+			account.BalanceBefore = &b
+		}
+		if a.Balance.To != nil {
+			b, err := DecimalStringToHex(*a.Balance.To)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse balance of %s: %w", addr, err)
+			}
+			account.BalanceAfter = &b
+		}
+		if a.Nonce.From != nil {
+			n, err := NumberishToUint64(*a.Nonce.From)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse nonce of %s: %w", addr, err)
+			}
+			account.NonceBefore = &n
+		}
+		if a.Nonce.To != nil {
+			n, err := NumberishToUint64(*a.Nonce.To)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse nonce of %s: %w", addr, err)
+			}
+			account.NonceAfter = &n
+		}
+		if a.Code.From != nil {
+			if account.CodeBefore, err = HexToBytes(*a.Code.From); err != nil {
+				return nil, fmt.Errorf("failed to parse code of %s: %w", addr, err)
+			}
+		}
+		if a.Code.To != nil {
+			if account.CodeAfter, err = HexToBytes(*a.Code.To); err != nil {
+				return nil, fmt.Errorf("failed to parse code of %s: %w", addr, err)
+			}
+		}
+
+		for slot, delta := range a.Storage {
+			var before, after string
+			if delta.From != nil {
+				before = *delta.From
+			}
+			if delta.To != nil {
+				after = *delta.To
+			}
+			change, err := newStorageChange(slot, before, after)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse storage of %s: %w", addr, err)
+			}
+			if change != nil {
+				account.Storage = append(account.Storage, change)
+			}
+		}
+		sortStorageChanges(account.Storage)
+
+		diff.Accounts = append(diff.Accounts, account)
+	}
+	sortAccountDiffs(diff.Accounts)
+	return diff, nil
+}
+
+// JsonRpcParityReplayResult is one entry of the trace_replayBlockTransactions result array.
+// Only the state diff is converted; trace and vmTrace are kept raw.
+type JsonRpcParityReplayResult struct {
+	Output          string                 `json:"output"`
+	StateDiff       JsonRpcParityStateDiff `json:"stateDiff"`
+	Trace           json.RawMessage        `json:"trace,omitempty"`
+	VmTrace         json.RawMessage        `json:"vmTrace,omitempty"`
+	TransactionHash string                 `json:"transactionHash"`
+}
+
+// ParseJsonRpcParityStateDiffs converts the result of trace_replayBlockTransactions into state diffs.
+// Results are returned in block order, so when a header is given the block number, block hash and
+// transaction index are filled in.
+func ParseJsonRpcParityStateDiffs(results []*JsonRpcParityReplayResult, header *BlockHeader) ([]*StateDiff, error) {
+	diffs := make([]*StateDiff, 0, len(results))
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		diff, err := r.StateDiff.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state diff %d: %w", i, err)
+		}
+		if diff.TransactionHash, err = HexToBytes(r.TransactionHash); err != nil {
+			return nil, fmt.Errorf("failed to parse state diff %d transactionHash: %w", i, err)
+		}
+		if header != nil {
+			diff.BlockNumber = Uint64Ptr(header.Number)
+			diff.BlockHash = header.Hash
+			diff.TransactionIndex = Uint32Ptr(uint32(i))
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// StateDiffToPrestateJsonRpc serialises a *StateDiff into a geth prestateTracer diff mode result.
+// Only changed fields are known, so "pre" carries the before values of changed fields rather than
+// the full original account.
+func StateDiffToPrestateJsonRpc(d *StateDiff) map[string]interface{} {
+	if d == nil {
+		return nil
+	}
+	pre := map[string]interface{}{}
+	post := map[string]interface{}{}
+	for _, a := range d.Accounts {
+		if a == nil {
+			continue
+		}
+		addr := BytesToHex(a.Address)
+		if !a.Created {
+			account := map[string]interface{}{}
+			if a.BalanceBefore != nil {
+				if hex, err := DecimalStringToHex(*a.BalanceBefore); err == nil {
+					account["balance"] = hex
+				}
+			}
+			if a.NonceBefore != nil && *a.NonceBefore != 0 {
+				account["nonce"] = *a.NonceBefore
+			}
+			if len(a.CodeBefore) > 0 {
+				account["code"] = BytesToHex(a.CodeBefore)
+			}
+			storage := map[string]interface{}{}
+			for _, s := range a.Storage {
+				storage[BytesToHex(s.Slot)] = BytesToHexFixed(s.Before, 32)
+			}
+			if len(storage) > 0 {
+				account["storage"] = storage
+			}
+			pre[addr] = account
+		}
+		if !a.Deleted {
+			account := map[string]interface{}{}
+			if a.BalanceAfter != nil {
+				if hex, err := DecimalStringToHex(*a.BalanceAfter); err == nil {
+					account["balance"] = hex
+				}
+			}
+			if a.NonceAfter != nil {
+				account["nonce"] = *a.NonceAfter
+			}
+			if len(a.CodeAfter) > 0 {
+				account["code"] = BytesToHex(a.CodeAfter)
+			}
+			storage := map[string]interface{}{}
+			for _, s := range a.Storage {
+				if !isZeroWord(s.After) {
+					storage[BytesToHex(s.Slot)] = BytesToHexFixed(s.After, 32)
+				}
+			}
+			if len(storage) > 0 {
+				account["storage"] = storage
+			}
+			post[addr] = account
+		}
+	}
+	return map[string]interface{}{
+		"pre":  pre,
+		"post": post,
+	}
+}
+
+// StateDiffsToPrestateJsonRpc converts a slice of *StateDiff to a debug_traceBlock* (prestateTracer, diff mode) response.
+func StateDiffsToPrestateJsonRpc(diffs []*StateDiff) []interface{} {
+	out := make([]interface{}, len(diffs))
+	for i, d := range diffs {
+		if d == nil {
+			continue
+		}
+		out[i] = map[string]interface{}{
+			"txHash": BytesToHex(d.TransactionHash),
+			"result": StateDiffToPrestateJsonRpc(d),
+		}
+	}
+	return out
+}
+
+// StateDiffToParityJsonRpc serialises a *StateDiff into a parity stateDiff object.
+func StateDiffToParityJsonRpc(d *StateDiff) map[string]interface{} {
+	if d == nil {
+		return nil
+	}
+	out := map[string]interface{}{}
+	for _, a := range d.Accounts {
+		if a == nil {
+			continue
+		}
+		var balanceFrom, balanceTo, nonceFrom, nonceTo, codeFrom, codeTo *string
+		if a.BalanceBefore != nil {
+			if hex, err := DecimalStringToHex(*a.BalanceBefore); err == nil {
+				balanceFrom = &hex
+			}
+		}
+		if a.BalanceAfter != nil {
+			if hex, err := DecimalStringToHex(*a.BalanceAfter); err == nil {
+				balanceTo = &hex
+			}
+		}
+		if a.NonceBefore != nil {
+			nonceFrom = StringPtr(fmt.Sprintf("0x%x", *a.NonceBefore))
+		}
+		if a.NonceAfter != nil {
+			nonceTo = StringPtr(fmt.Sprintf("0x%x", *a.NonceAfter))
+		}
+		if a.CodeBefore != nil {
+			codeFrom = StringPtr(BytesToHex(a.CodeBefore))
+		}
+		if a.CodeAfter != nil {
+			codeTo = StringPtr(BytesToHex(a.CodeAfter))
+		}
+
+		storage := map[string]interface{}{}
+		for _, s := range a.Storage {
+			var from, to *string
+			if !a.Created {
+				from = StringPtr(BytesToHexFixed(s.Before, 32))
+			}
+			if !a.Deleted {
+				to = StringPtr(BytesToHexFixed(s.After, 32))
+			}
+			storage[BytesToHexFixed(s.Slot, 32)] = parityDelta(from, to)
+		}
+
+		out[BytesToHex(a.Address)] = map[string]interface{}{
+			"balance": parityDelta(balanceFrom, balanceTo),
+			"nonce":   parityDelta(nonceFrom, nonceTo),
+			"code":    parityDelta(codeFrom, codeTo),
+			"storage": storage,
+		}
+	}
+	return out
+}
+
+// StateDiffsToParityJsonRpc converts a slice of *StateDiff to a trace_replayBlockTransactions
+// (stateDiff) response. Output, trace and vmTrace are not part of the model and are left empty.
+func StateDiffsToParityJsonRpc(diffs []*StateDiff) []interface{} {
+	out := make([]interface{}, len(diffs))
+	for i, d := range diffs {
+		if d == nil {
+			continue
+		}
+		out[i] = map[string]interface{}{
+			"output":          "0x",
+			"stateDiff":       StateDiffToParityJsonRpc(d),
+			"trace":           []interface{}{},
+			"vmTrace":         nil,
+			"transactionHash": BytesToHex(d.TransactionHash),
+		}
+	}
+	return out
+}
+
+func parityDelta(from, to *string) interface{} {
+	switch {
+	case from == nil && to == nil:
+		return "="
+	case from == nil:
+		return map[string]interface{}{"+": *to}
+	case to == nil:
+		return map[string]interface{}{"-": *from}
+	}
+	return map[string]interface{}{"*": map[string]interface{}{"from": *from, "to": *to}}
+}
+
+// newStorageChange builds a StorageChange with both values widened to 32-byte words.
+// Missing values count as zero; unchanged slots yield nil.
+func newStorageChange(slot, before, after string) (*StorageChange, error) {
+	key, err := storageWord(slot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slot %s: %w", slot, err)
+	}
+	b, err := storageWord(before)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of slot %s: %w", slot, err)
+	}
+	a, err := storageWord(after)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of slot %s: %w", slot, err)
+	}
+	if bytes.Equal(b, a) {
+		return nil, nil
+	}
+	return &StorageChange{Slot: key, Before: b, After: a}, nil
+}
+
+func storageWord(s string) ([]byte, error) {
+	b, err := HexToBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > 32 {
+		return nil, fmt.Errorf("value exceeds 32 bytes")
+	}
+	word := make([]byte, 32)
+	copy(word[32-len(b):], b)
+	return word, nil
+}
+
+func isZeroWord(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func sortAccountDiffs(accounts []*AccountDiff) {
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address, accounts[j].Address) < 0
+	})
+}
+
+func sortStorageChanges(changes []*StorageChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Slot, changes[j].Slot) < 0
+	})
+}
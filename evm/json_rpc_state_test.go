@@ -0,0 +1,207 @@
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func stateTestWord(n int) string {
+	return fmt.Sprintf("0x%064x", n)
+}
+
+const (
+	stateTestSender   = "0x742d35cc6634c0532925a3b844bc9e7595f0beb7"
+	stateTestContract = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	stateTestCreated  = "0x5fbdb2315678afecb367f032d93f642f64180aa3"
+	stateTestMiner    = "0xea674fdde714fd979de3edf0f56aa9716b898ec8"
+	stateTestDeleted  = "0x43506849d7c04f9138d1a2050bbf3a0c054402dd"
+)
+
+func prestateDiffTestJson() string {
+	r := strings.NewReplacer("$0", stateTestWord(0), "$1", stateTestWord(1), "$2", stateTestWord(2), "$5", stateTestWord(5), "$7", stateTestWord(7), "$9", stateTestWord(9))
+	return r.Replace(`[{
+  "txHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+  "result": {
+    "pre": {
+      "` + stateTestSender + `": {"balance": "0x10", "nonce": 1},
+      "` + stateTestContract + `": {"balance": "0x0", "code": "0x6001", "storage": {"$0": "$1", "$1": "$5"}},
+      "` + stateTestMiner + `": {"balance": "0x100"},
+      "` + stateTestDeleted + `": {"balance": "0x3", "nonce": 1, "code": "0x60", "storage": {"$2": "$9"}}
+    },
+    "post": {
+      "` + stateTestSender + `": {"balance": "0x5", "nonce": 2},
+      "` + stateTestContract + `": {"storage": {"$0": "$2"}},
+      "` + stateTestCreated + `": {"nonce": 1, "code": "0x6080", "storage": {"$0": "$7"}},
+      "` + stateTestMiner + `": {"balance": "0x200"}
+    }
+  }
+}]`)
+}
+
+func parityStateDiffTestJson() string {
+	r := strings.NewReplacer("$0", stateTestWord(0), "$1", stateTestWord(1), "$2", stateTestWord(2), "$5", stateTestWord(5), "$7", stateTestWord(7), "$9", stateTestWord(9), "$Z", stateTestWord(0))
+	return r.Replace(`[{
+  "output": "0x",
+  "stateDiff": {
+    "` + stateTestSender + `": {"balance": {"*": {"from": "0x10", "to": "0x5"}}, "nonce": {"*": {"from": "0x1", "to": "0x2"}}, "code": "=", "storage": {}},
+    "` + stateTestContract + `": {"balance": "=", "nonce": "=", "code": "=", "storage": {"$0": {"*": {"from": "$1", "to": "$2"}}, "$1": {"*": {"from": "$5", "to": "$Z"}}}},
+    "` + stateTestCreated + `": {"balance": {"+": "0x0"}, "nonce": {"+": "0x1"}, "code": {"+": "0x6080"}, "storage": {"$0": {"+": "$7"}}},
+    "` + stateTestMiner + `": {"balance": {"*": {"from": "0x100", "to": "0x200"}}, "nonce": "=", "code": "=", "storage": {}},
+    "` + stateTestDeleted + `": {"balance": {"-": "0x3"}, "nonce": {"-": "0x1"}, "code": {"-": "0x60"}, "storage": {"$2": {"-": "$9"}}}
+  },
+  "trace": [],
+  "vmTrace": null,
+  "transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111"
+}]`)
+}
+
+func TestStateDiffConverters(t *testing.T) {
+	header := &BlockHeader{Number: 19000000, Hash: MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890")}
+
+	var gethResults []*JsonRpcPrestateDiffTrace
+	if err := json.Unmarshal([]byte(prestateDiffTestJson()), &gethResults); err != nil {
+		t.Fatalf("Failed to unmarshal prestateTracer output: %v", err)
+	}
+	gethDiffs, err := ParseJsonRpcPrestateDiffs(gethResults, header)
+	if err != nil {
+		t.Fatalf("Failed to convert prestateTracer output: %v", err)
+	}
+
+	var parityResults []*JsonRpcParityReplayResult
+	if err := json.Unmarshal([]byte(parityStateDiffTestJson()), &parityResults); err != nil {
+		t.Fatalf("Failed to unmarshal trace_replayBlockTransactions output: %v", err)
+	}
+	parityDiffs, err := ParseJsonRpcParityStateDiffs(parityResults, header)
+	if err != nil {
+		t.Fatalf("Failed to convert trace_replayBlockTransactions output: %v", err)
+	}
+
+	t.Run("FlavoursAgree", func(t *testing.T) {
+		if len(gethDiffs) != 1 || len(parityDiffs) != 1 {
+			t.Fatalf("Expected one diff per flavour, got %d and %d", len(gethDiffs), len(parityDiffs))
+		}
+		if !proto.Equal(gethDiffs[0], parityDiffs[0]) {
+			t.Errorf("Normalized diffs differ\ngeth:   %v\nparity: %v", gethDiffs[0], parityDiffs[0])
+		}
+	})
+
+	t.Run("Semantics", func(t *testing.T) {
+		diff := gethDiffs[0]
+		if len(diff.Accounts) != 5 {
+			t.Fatalf("Expected 5 accounts, got %d", len(diff.Accounts))
+		}
+		byAddress := map[string]*AccountDiff{}
+		for _, a := range diff.Accounts {
+			byAddress[BytesToHex(a.Address)] = a
+		}
+
+		sender := byAddress[stateTestSender]
+		if sender.GetBalanceBefore() != "0x10" || sender.GetBalanceAfter() != "0x5" || sender.GetNonceBefore() != 1 || sender.GetNonceAfter() != 2 {
+			t.Errorf("Unexpected sender diff: %v", sender)
+		}
+		if sender.CodeBefore != nil || sender.CodeAfter != nil {
+			t.Errorf("Sender code should be unchanged: %v", sender)
+		}
+
+		contract := byAddress[stateTestContract]
+		if contract.BalanceBefore != nil || len(contract.Storage) != 2 {
+			t.Fatalf("Unexpected contract diff: %v", contract)
+		}
+		if BytesToHex(contract.Storage[1].After) != stateTestWord(0) {
+			t.Errorf("Cleared slot should be zero after: %v", contract.Storage[1])
+		}
+
+		created := byAddress[stateTestCreated]
+		if !created.Created || created.BalanceBefore != nil || created.GetBalanceAfter() != "0x0" || BytesToHex(created.CodeAfter) != "0x6080" {
+			t.Errorf("Unexpected created account diff: %v", created)
+		}
+
+		deleted := byAddress[stateTestDeleted]
+		if !deleted.Deleted || deleted.BalanceAfter != nil || deleted.GetNonceBefore() != 1 || BytesToHex(deleted.Storage[0].Before) != stateTestWord(9) {
+			t.Errorf("Unexpected deleted account diff: %v", deleted)
+		}
+
+		if diff.GetTransactionIndex() != 0 || diff.GetBlockNumber() != 19000000 {
+			t.Errorf("Block context not filled: %v", diff)
+		}
+	})
+
+	t.Run("PrestateRoundTrip", func(t *testing.T) {
+		encoded, err := json.Marshal(StateDiffsToPrestateJsonRpc(gethDiffs))
+		if err != nil {
+			t.Fatalf("Failed to marshal diffs: %v", err)
+		}
+		var decoded []*JsonRpcPrestateDiffTrace
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal diffs: %v", err)
+		}
+		again, err := ParseJsonRpcPrestateDiffs(decoded, header)
+		if err != nil {
+			t.Fatalf("Failed to convert round-tripped diffs: %v", err)
+		}
+		if !proto.Equal(again[0], gethDiffs[0]) {
+			t.Errorf("Round trip mismatch\nwant: %v\ngot:  %v", gethDiffs[0], again[0])
+		}
+	})
+
+	t.Run("ParityRoundTrip", func(t *testing.T) {
+		encoded, err := json.Marshal(StateDiffsToParityJsonRpc(parityDiffs))
+		if err != nil {
+			t.Fatalf("Failed to marshal diffs: %v", err)
+		}
+		var decoded []*JsonRpcParityReplayResult
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal diffs: %v", err)
+		}
+		again, err := ParseJsonRpcParityStateDiffs(decoded, header)
+		if err != nil {
+			t.Fatalf("Failed to convert round-tripped diffs: %v", err)
+		}
+		if !proto.Equal(again[0], parityDiffs[0]) {
+			t.Errorf("Round trip mismatch\nwant: %v\ngot:  %v", parityDiffs[0], again[0])
+		}
+	})
+
+	t.Run("CreatedAccount", func(t *testing.T) {
+		// Geth lists a newly funded account in "pre" with a zero balance; parity marks it added.
+		var geth JsonRpcPrestateDiff
+		if err := json.Unmarshal([]byte(`{
+  "pre": {"`+stateTestCreated+`": {"balance": "0x0"}},
+  "post": {"`+stateTestCreated+`": {"balance": "0xde0b6b3a7640000"}}
+}`), &geth); err != nil {
+			t.Fatalf("Failed to unmarshal prestateTracer diff: %v", err)
+		}
+		var parity JsonRpcParityStateDiff
+		if err := json.Unmarshal([]byte(`{
+  "`+stateTestCreated+`": {"balance": {"+": "1000000000000000000"}, "nonce": {"+": "0x0"}, "code": {"+": "0x"}, "storage": {}}
+}`), &parity); err != nil {
+			t.Fatalf("Failed to unmarshal parity stateDiff: %v", err)
+		}
+		gethDiff, err := geth.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert prestateTracer diff: %v", err)
+		}
+		parityDiff, err := parity.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert parity stateDiff: %v", err)
+		}
+		if !proto.Equal(gethDiff, parityDiff) {
+			t.Errorf("Normalized diffs differ\ngeth:   %v\nparity: %v", gethDiff, parityDiff)
+		}
+		created := gethDiff.Accounts[0]
+		if !created.Created || created.BalanceBefore != nil || created.GetBalanceAfter() != "0xde0b6b3a7640000" {
+			t.Errorf("Unexpected created account diff: %v", created)
+		}
+	})
+
+	t.Run("InvalidMarker", func(t *testing.T) {
+		var d JsonRpcParityDelta
+		if err := json.Unmarshal([]byte(`"~"`), &d); err == nil {
+			t.Error("Expected error for unknown state diff marker")
+		}
+	})
+}
@@ -1,7 +1,12 @@
 package evm
 
 import (
+	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
 )
 
 func TestReceiptL1BlobBaseFeeConversion(t *testing.T) {
@@ -86,3 +91,211 @@ func TestReceiptL1BlobBaseFeeOmitted(t *testing.T) {
 		t.Error("Expected l1BlobBaseFee to be omitted from JSON-RPC output when nil")
 	}
 }
+
+func TestJsonRpcBlockTypedTransactions(t *testing.T) {
+	t.Run("Full transaction objects", func(t *testing.T) {
+		raw := `{
+			"number": "0x10",
+			"hash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+			"parentHash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+			"timestamp": "0x65000000",
+			"gasLimit": "0x1c9c380",
+			"gasUsed": "0x5208",
+			"logsBloom": "0x00",
+			"transactionsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			"stateRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			"receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+			"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			"miner": "0x0000000000000000000000000000000000000000",
+			"extraData": "0x",
+			"transactions": [{
+				"hash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+				"nonce": "0x2",
+				"from": "0x742d35cc6634c0532925a3b844bc9e7595f0beb7",
+				"to": null,
+				"value": "0x0",
+				"input": "0x",
+				"gas": "0x5208",
+				"type": "0x4",
+				"chainId": "0x1",
+				"maxFeePerGas": "0x3b9aca00",
+				"maxPriorityFeePerGas": "0x1",
+				"r": "0x01",
+				"s": "0x02",
+				"yParity": "0x1",
+				"transactionIndex": "0x0",
+				"accessList": [{
+					"address": "0x4200000000000000000000000000000000000015",
+					"storageKeys": ["0x0000000000000000000000000000000000000000000000000000000000000001"]
+				}],
+				"authorizationList": [{
+					"chainId": "0x1",
+					"address": "0x4200000000000000000000000000000000000016",
+					"nonce": "0x7",
+					"r": "0x03",
+					"s": "0x04",
+					"yParity": "0x0"
+				}],
+				"blobVersionedHashes": ["0x01abcdef1234567890abcdef1234567890abcdef1234567890abcdef12345678"]
+			}]
+		}`
+
+		var block JsonRpcBlock
+		if err := json.Unmarshal([]byte(raw), &block); err != nil {
+			t.Fatalf("Failed to unmarshal block: %v", err)
+		}
+		if len(block.Transactions.Full) != 1 || len(block.Transactions.Hashes) != 0 {
+			t.Fatalf("Expected 1 full transaction, got %d full and %d hashes", len(block.Transactions.Full), len(block.Transactions.Hashes))
+		}
+
+		protoBlock, err := block.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert block to proto: %v", err)
+		}
+		if len(protoBlock.FullTransactions) != 1 || len(protoBlock.TransactionHashes) != 1 {
+			t.Fatalf("Expected 1 transaction and 1 hash, got %d and %d", len(protoBlock.FullTransactions), len(protoBlock.TransactionHashes))
+		}
+
+		tx := protoBlock.FullTransactions[0]
+		if tx.Type != 4 {
+			t.Errorf("Expected type 4, got %d", tx.Type)
+		}
+		if tx.To != nil {
+			t.Errorf("Expected nil to for null JSON value, got %x", tx.To)
+		}
+		if tx.BlockNumber == nil || *tx.BlockNumber != 0x10 {
+			t.Error("Expected blockNumber to be inherited from the block header")
+		}
+		if tx.BlockTimestamp == nil || *tx.BlockTimestamp != 0x65000000 {
+			t.Error("Expected blockTimestamp to be inherited from the block header")
+		}
+		if len(tx.AccessList) != 1 || len(tx.AccessList[0].StorageKeys) != 1 {
+			t.Errorf("Expected 1 access list item with 1 storage key, got %v", tx.AccessList)
+		}
+		if len(tx.AuthorizationList) != 1 || tx.AuthorizationList[0].Nonce != 7 {
+			t.Errorf("Expected 1 authorization with nonce 7, got %v", tx.AuthorizationList)
+		}
+		if len(tx.BlobVersionedHashes) != 1 {
+			t.Errorf("Expected 1 blob versioned hash, got %d", len(tx.BlobVersionedHashes))
+		}
+	})
+
+	t.Run("Transaction hashes only", func(t *testing.T) {
+		raw := `{"transactions": ["0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"]}`
+
+		var block JsonRpcBlock
+		if err := json.Unmarshal([]byte(raw), &block); err != nil {
+			t.Fatalf("Failed to unmarshal block: %v", err)
+		}
+		if len(block.Transactions.Hashes) != 1 || len(block.Transactions.Full) != 0 {
+			t.Fatalf("Expected 1 hash, got %d hashes and %d full", len(block.Transactions.Hashes), len(block.Transactions.Full))
+		}
+
+		hashes, txs, err := block.Transactions.ToProto(nil)
+		if err != nil {
+			t.Fatalf("Failed to convert transactions: %v", err)
+		}
+		if len(hashes) != 1 || len(txs) != 0 {
+			t.Errorf("Expected 1 hash and no transactions, got %d and %d", len(hashes), len(txs))
+		}
+	})
+}
+
+func TestJsonRpcTransactionToProto(t *testing.T) {
+	jtx := &JsonRpcTransaction{
+		Hash:             "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		Nonce:            "0x1",
+		From:             "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb7",
+		To:               "0x4200000000000000000000000000000000000015",
+		Value:            "0x100",
+		Input:            "0x",
+		Gas:              "0x5208",
+		Type:             "0x69",
+		R:                "0x1",
+		S:                "0x2",
+		V:                "0x0",
+		BlockNumber:      "0x1000",
+		TransactionIndex: "0x3",
+		TicketId:         "0xabcdef",
+		MaxSubmissionFee: "0x10",
+		L1FeeScalar:      "1.5",
+	}
+
+	tx, err := jtx.ToProto()
+	if err != nil {
+		t.Fatalf("Failed to convert transaction: %v", err)
+	}
+	if tx.BlockNumber == nil || *tx.BlockNumber != 0x1000 {
+		t.Error("Expected blockNumber to be 0x1000")
+	}
+	if tx.TransactionIndex == nil || *tx.TransactionIndex != 3 {
+		t.Error("Expected transactionIndex to be 3")
+	}
+	if BytesToHex(tx.TicketId) != "0xabcdef" {
+		t.Errorf("Expected ticketId to be '0xabcdef', got '%s'", BytesToHex(tx.TicketId))
+	}
+	if tx.MaxSubmissionFee == nil || *tx.MaxSubmissionFee != "0x10" {
+		t.Error("Expected maxSubmissionFee to be '0x10'")
+	}
+	if tx.L1FeeScalar == nil || *tx.L1FeeScalar != 1.5 {
+		t.Error("Expected l1FeeScalar to be 1.5")
+	}
+	if tx.GasPrice != nil {
+		t.Error("Expected gasPrice to be unset")
+	}
+}
+
+func TestParseJsonRpcTransactionMap(t *testing.T) {
+	t.Run("AllStringFields", func(t *testing.T) {
+		covered := map[string]bool{}
+		for _, f := range jsonRpcTransactionStringFields {
+			covered[f.name] = true
+		}
+		rt := reflect.TypeOf(JsonRpcTransaction{})
+		for i := 0; i < rt.NumField(); i++ {
+			if rt.Field(i).Type.Kind() == reflect.String && !covered[rt.Field(i).Tag.Get("json")] {
+				t.Errorf("Field %s is not read from maps", rt.Field(i).Name)
+			}
+		}
+	})
+
+	t.Run("MatchesTyped", func(t *testing.T) {
+		raw := `{
+			"hash": "0x01", "nonce": "0x2", "from": "0x03", "to": "0x04", "value": "0x5", "input": "0x",
+			"gas": "0x5208", "type": "0x4", "chainId": "0x1", "r": "0x1", "s": "0x2", "yParity": "0x1",
+			"maxFeePerGas": "0x10", "maxPriorityFeePerGas": "0x1", "isSystemTx": false,
+			"accessList": [{"address": "0x05", "storageKeys": ["0x06"]}],
+			"authorizationList": [{"chainId": "0x1", "address": "0x07", "nonce": "0x0", "r": "0x1", "s": "0x2", "yParity": "0x0"}],
+			"blobVersionedHashes": null, "unknownField": 7
+		}`
+		var m map[string]interface{}
+		var jtx JsonRpcTransaction
+		if err := json.Unmarshal([]byte(raw), &m); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(raw), &jtx); err != nil {
+			t.Fatal(err)
+		}
+		p := newFieldParser(true)
+		if got := jsonRpcTransactionFromMap(p, m); !reflect.DeepEqual(got, &jtx) || p.err() != nil {
+			t.Errorf("Expected %+v, got %+v (%v)", jtx, *got, p.err())
+		}
+	})
+
+	t.Run("WrongTypes", func(t *testing.T) {
+		m := map[string]interface{}{
+			"hash": "0x01", "nonce": float64(2), "gas": "0x5208", "r": "0x1", "s": "0x2",
+			"isSystemTx": "yes",
+			"accessList": []interface{}{map[string]interface{}{"address": "0x05", "storageKeys": []interface{}{"0x06", true}}},
+		}
+		_, err := ParseJsonRpcTransaction(m, nil, WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+			t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+		}
+		want := []string{"nonce", "isSystemTx", "accessList[0].storageKeys[1]"}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+			t.Errorf("Expected paths %v, got %v (%v)", want, paths, err)
+		}
+	})
+}
@@ -0,0 +1,557 @@
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JsonRpcCallFrame is a single frame produced by geth's callTracer
+// (debug_traceTransaction / debug_traceBlockByNumber with {"tracer": "callTracer"}).
+type JsonRpcCallFrame struct {
+	Type         string              `json:"type"`
+	From         string              `json:"from"`
+	To           string              `json:"to,omitempty"`
+	Value        string              `json:"value,omitempty"`
+	Gas          string              `json:"gas"`
+	GasUsed      string              `json:"gasUsed"`
+	Input        string              `json:"input"`
+	Output       string              `json:"output,omitempty"`
+	Error        string              `json:"error,omitempty"`
+	RevertReason string              `json:"revertReason,omitempty"`
+	Calls        []*JsonRpcCallFrame `json:"calls,omitempty"`
+}
+
+func (f *JsonRpcCallFrame) ToProto() (*CallFrame, error) {
+	p := newFieldParser(false)
+	frame := f.toProto(p, nil)
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+func (f *JsonRpcCallFrame) toProto(p *fieldParser, traceAddress []uint32) *CallFrame {
+	callType, err := ParseCallType(f.Type)
+	if err != nil {
+		p.fail("type", err)
+	}
+	var gas, gasUsed uint64
+	if f.Gas != "" {
+		gas = p.uint64("gas", f.Gas)
+	}
+	if f.GasUsed != "" {
+		gasUsed = p.uint64("gasUsed", f.GasUsed)
+	}
+
+	frame := &CallFrame{
+		Type:         callType,
+		From:         p.bytes("from", f.From),
+		To:           p.bytes("to", f.To),
+		Value:        optionalString(f.Value),
+		Gas:          gas,
+		GasUsed:      gasUsed,
+		Input:        p.bytes("input", f.Input),
+		Output:       p.bytes("output", f.Output),
+		Error:        optionalString(f.Error),
+		RevertReason: optionalString(f.RevertReason),
+		TraceAddress: traceAddress,
+	}
+	if len(f.Calls) > 0 {
+		frame.Calls = make([]*CallFrame, 0, len(f.Calls))
+		for i, c := range f.Calls {
+			if c == nil {
+				continue
+			}
+			childAddress := make([]uint32, len(traceAddress)+1)
+			copy(childAddress, traceAddress)
+			childAddress[len(traceAddress)] = uint32(i)
+			frame.Calls = append(frame.Calls, c.toProto(p.item("calls", i), childAddress))
+			if p.done() {
+				break
+			}
+		}
+	}
+	return frame
+}
+
+// JsonRpcCallTrace is one entry of the debug_traceBlockByNumber / debug_traceBlockByHash
+// result array when the callTracer is used.
+type JsonRpcCallTrace struct {
+	TxHash string            `json:"txHash"`
+	Result *JsonRpcCallFrame `json:"result"`
+	Error  string            `json:"error,omitempty"`
+}
+
+func (t *JsonRpcCallTrace) ToProto() (*Trace, error) {
+	p := newFieldParser(false)
+	trace := t.toProto(p)
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+func (t *JsonRpcCallTrace) toProto(p *fieldParser) *Trace {
+	if t.Error != "" {
+		p.fail("error", fmt.Errorf("trace of transaction %s failed: %s", t.TxHash, t.Error))
+		return nil
+	}
+	if t.Result == nil {
+		p.fail("result", fmt.Errorf("trace of transaction %s has no result", t.TxHash))
+		return nil
+	}
+	return &Trace{
+		TransactionHash: p.bytes("txHash", t.TxHash),
+		Root:            t.Result.toProto(p.child("result"), nil),
+	}
+}
+
+// ParseJsonRpcCallTraces converts the result of debug_traceBlockByNumber / debug_traceBlockByHash
+// (callTracer) into traces. geth returns one entry per transaction in block order, so when a
+// header is given the block number, block hash and transaction index are filled in. Errors have
+// paths such as "txs[2].result.calls[0].gas"; with WithCollectErrors every failing transaction
+// is listed rather than only the first.
+func ParseJsonRpcCallTraces(results []*JsonRpcCallTrace, header *BlockHeader, opts ...ConvertOption) ([]*Trace, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	traces := make([]*Trace, 0, len(results))
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		trace := r.toProto(p.item("txs", i))
+		if p.done() {
+			break
+		}
+		if trace == nil {
+			continue
+		}
+		if header != nil {
+			trace.BlockNumber = Uint64Ptr(header.Number)
+			trace.BlockHash = header.Hash
+			trace.TransactionIndex = Uint32Ptr(uint32(i))
+		}
+		traces = append(traces, trace)
+	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// JsonRpcParityTrace is a single flat trace entry returned by parity-style tracers
+// (trace_block, trace_transaction, trace_filter on erigon, nethermind, reth and openethereum).
+type JsonRpcParityTrace struct {
+	Action              JsonRpcParityTraceAction  `json:"action"`
+	BlockHash           string                    `json:"blockHash,omitempty"`
+	BlockNumber         *jsonRpcNumberish         `json:"blockNumber,omitempty"`
+	Error               string                    `json:"error,omitempty"`
+	Result              *JsonRpcParityTraceResult `json:"result"`
+	Subtraces           int                       `json:"subtraces"`
+	TraceAddress        []uint32                  `json:"traceAddress"`
+	TransactionHash     string                    `json:"transactionHash,omitempty"`
+	TransactionPosition *jsonRpcNumberish         `json:"transactionPosition,omitempty"`
+	Type                string                    `json:"type"`
+}
+
+// JsonRpcParityTraceAction is the union of all parity trace action shapes
+// (call, create, suicide and reward).
+type JsonRpcParityTraceAction struct {
+	CallType       string `json:"callType,omitempty"`
+	From           string `json:"from,omitempty"`
+	To             string `json:"to,omitempty"`
+	Gas            string `json:"gas,omitempty"`
+	Input          string `json:"input,omitempty"`
+	Init           string `json:"init,omitempty"`
+	Value          string `json:"value,omitempty"`
+	CreationMethod string `json:"creationMethod,omitempty"`
+	Address        string `json:"address,omitempty"`
+	RefundAddress  string `json:"refundAddress,omitempty"`
+	Balance        string `json:"balance,omitempty"`
+	Author         string `json:"author,omitempty"`
+	RewardType     string `json:"rewardType,omitempty"`
+}
+
+// JsonRpcParityTraceResult is the union of parity call and create results.
+type JsonRpcParityTraceResult struct {
+	GasUsed string `json:"gasUsed,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Address string `json:"address,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// jsonRpcNumberish accepts both JSON numbers (parity, erigon) and hex or decimal strings.
+type jsonRpcNumberish string
+
+func (n *jsonRpcNumberish) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*n = jsonRpcNumberish(s)
+		return nil
+	}
+	*n = jsonRpcNumberish(data)
+	return nil
+}
+
+// ToProto converts a single flat parity trace into a call frame without any children.
+// Use ParseJsonRpcParityTraces to rebuild the call trees of a whole transaction or block.
+func (t *JsonRpcParityTrace) ToProto() (*CallFrame, error) {
+	p := newFieldParser(false)
+	frame := t.toProto(p)
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+func (t *JsonRpcParityTrace) toProto(p *fieldParser) *CallFrame {
+	a := &t.Action
+	ap := p.child("action")
+	rp := p.child("result")
+	frame := &CallFrame{
+		TraceAddress: append([]uint32(nil), t.TraceAddress...),
+		Error:        optionalString(t.Error),
+	}
+
+	switch strings.ToLower(t.Type) {
+	case "call":
+		callType := a.CallType
+		if callType == "" {
+			callType = "call"
+		}
+		var err error
+		if frame.Type, err = ParseCallType(callType); err != nil {
+			ap.fail("callType", err)
+		}
+		frame.From = ap.bytes("from", a.From)
+		frame.To = ap.bytes("to", a.To)
+		frame.Input = ap.bytes("input", a.Input)
+		if t.Result != nil {
+			frame.Output = rp.bytes("output", t.Result.Output)
+		}
+	case "create":
+		frame.Type = CallType_CREATE
+		if strings.EqualFold(a.CreationMethod, "create2") {
+			frame.Type = CallType_CREATE2
+		}
+		frame.From = ap.bytes("from", a.From)
+		frame.Input = ap.bytes("init", a.Init)
+		if t.Result != nil {
+			frame.To = rp.bytes("address", t.Result.Address)
+			frame.Output = rp.bytes("code", t.Result.Code)
+		}
+	case "suicide", "selfdestruct":
+		frame.Type = CallType_SELFDESTRUCT
+		frame.From = ap.bytes("address", a.Address)
+		frame.To = ap.bytes("refundAddress", a.RefundAddress)
+		frame.Value = optionalString(a.Balance)
+	case "reward":
+		frame.Type = CallType_REWARD
+		frame.To = ap.bytes("author", a.Author)
+		frame.RewardType = optionalString(a.RewardType)
+	default:
+		p.fail("type", fmt.Errorf("unsupported trace type: %s", t.Type))
+	}
+
+	if frame.Type != CallType_SELFDESTRUCT {
+		frame.Value = optionalString(a.Value)
+	}
+	if a.Gas != "" {
+		frame.Gas = ap.uint64("gas", a.Gas)
+	}
+	if t.Result != nil && t.Result.GasUsed != "" {
+		frame.GasUsed = rp.uint64("gasUsed", t.Result.GasUsed)
+	}
+	return frame
+}
+
+// ParseJsonRpcParityTraces converts the flat output of trace_block / trace_transaction into one
+// Trace per transaction (and one per reward entry), rebuilding each call tree from traceAddress.
+// Traces are returned in the order their root frames appear in the input. Errors have paths
+// such as "traces[4].action.gas"; with WithCollectErrors every invalid entry is listed rather
+// than only the first.
+func ParseJsonRpcParityTraces(entries []*JsonRpcParityTrace, opts ...ConvertOption) ([]*Trace, error) {
+	cfg := newConvertConfig(opts)
+	p := newFieldParser(cfg.collectErrors)
+	var traces []*Trace
+	var current *Trace
+	var currentKey string
+	frames := map[string]*CallFrame{}
+
+	for i, e := range entries {
+		if e == nil {
+			continue
+		}
+		tp := p.item("traces", i)
+		frame := e.toProto(tp)
+
+		key := strings.ToLower(e.TransactionHash)
+		switch {
+		case len(e.TraceAddress) == 0:
+			trace := &Trace{
+				TransactionHash: tp.bytes("transactionHash", e.TransactionHash),
+				BlockHash:       tp.bytes("blockHash", e.BlockHash),
+				Root:            frame,
+			}
+			if e.BlockNumber != nil {
+				trace.BlockNumber = tp.optUint64("blockNumber", string(*e.BlockNumber))
+			}
+			if e.TransactionPosition != nil {
+				trace.TransactionIndex = tp.optUint32("transactionPosition", string(*e.TransactionPosition))
+			}
+			traces = append(traces, trace)
+			current = trace
+			currentKey = key
+			frames = map[string]*CallFrame{"": frame}
+		case current == nil || key != currentKey:
+			tp.fail("traceAddress", fmt.Errorf("%v has no root frame for transaction %s", e.TraceAddress, e.TransactionHash))
+		default:
+			parent, ok := frames[traceAddressKey(e.TraceAddress[:len(e.TraceAddress)-1])]
+			if !ok {
+				tp.fail("traceAddress", fmt.Errorf("%v has no parent frame", e.TraceAddress))
+				break
+			}
+			parent.Calls = append(parent.Calls, frame)
+			frames[traceAddressKey(e.TraceAddress)] = frame
+		}
+		if p.done() {
+			break
+		}
+	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+func traceAddressKey(address []uint32) string {
+	var sb strings.Builder
+	for i, a := range address {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(strconv.FormatUint(uint64(a), 10))
+	}
+	return sb.String()
+}
+
+// ParseCallType maps a tracer frame type (e.g. "CALL", "delegatecall", "CREATE2") to a CallType.
+func ParseCallType(s string) (CallType, error) {
+	switch strings.ToUpper(s) {
+	case "", "CALL":
+		return CallType_CALL, nil
+	case "STATICCALL":
+		return CallType_STATICCALL, nil
+	case "DELEGATECALL":
+		return CallType_DELEGATECALL, nil
+	case "CALLCODE":
+		return CallType_CALLCODE, nil
+	case "CREATE":
+		return CallType_CREATE, nil
+	case "CREATE2":
+		return CallType_CREATE2, nil
+	case "SELFDESTRUCT", "SUICIDE":
+		return CallType_SELFDESTRUCT, nil
+	case "REWARD":
+		return CallType_REWARD, nil
+	}
+	return CallType_CALL, fmt.Errorf("unsupported call type: %s", s)
+}
+
+// CallFrameToJsonRpc serialises a *CallFrame into a geth callTracer compatible map[string]interface{}.
+func CallFrameToJsonRpc(f *CallFrame) map[string]interface{} {
+	if f == nil {
+		return nil
+	}
+	result := map[string]interface{}{
+		"type":    f.Type.String(),
+		"from":    BytesToHex(f.From),
+		"gas":     fmt.Sprintf("0x%x", f.Gas),
+		"gasUsed": fmt.Sprintf("0x%x", f.GasUsed),
+		"input":   BytesToHex(f.Input),
+	}
+	if len(f.To) > 0 {
+		result["to"] = BytesToHex(f.To)
+	}
+	if f.Value != nil {
+		if hex, err := DecimalStringToHex(*f.Value); err == nil {
+			result["value"] = hex
+		}
+	}
+	if len(f.Output) > 0 {
+		result["output"] = BytesToHex(f.Output)
+	}
+	if f.Error != nil {
+		result["error"] = *f.Error
+	}
+	if f.RevertReason != nil {
+		result["revertReason"] = *f.RevertReason
+	}
+	if len(f.Calls) > 0 {
+		calls := make([]interface{}, 0, len(f.Calls))
+		for _, c := range f.Calls {
+			if c != nil {
+				calls = append(calls, CallFrameToJsonRpc(c))
+			}
+		}
+		result["calls"] = calls
+	}
+	return result
+}
+
+// TraceToJsonRpc serialises a *Trace into a debug_traceBlockByNumber (callTracer) result entry.
+func TraceToJsonRpc(t *Trace) map[string]interface{} {
+	if t == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"txHash": BytesToHex(t.TransactionHash),
+		"result": CallFrameToJsonRpc(t.Root),
+	}
+}
+
+// TracesToJsonRpc converts a slice of *Trace to []interface{} for debug_traceBlock* responses.
+func TracesToJsonRpc(traces []*Trace) []interface{} {
+	out := make([]interface{}, len(traces))
+	for i, t := range traces {
+		out[i] = TraceToJsonRpc(t)
+	}
+	return out
+}
+
+// TraceToParityJsonRpc flattens a *Trace into parity-style trace entries (trace_transaction),
+// depth-first in execution order with traceAddress and subtraces computed from the tree.
+func TraceToParityJsonRpc(t *Trace) []interface{} {
+	if t == nil || t.Root == nil {
+		return []interface{}{}
+	}
+	var out []interface{}
+	var walk func(f *CallFrame, address []uint32)
+	walk = func(f *CallFrame, address []uint32) {
+		out = append(out, parityTraceEntry(t, f, address))
+		n := 0
+		for _, c := range f.Calls {
+			if c == nil {
+				continue
+			}
+			childAddress := make([]uint32, len(address)+1)
+			copy(childAddress, address)
+			childAddress[len(address)] = uint32(n)
+			walk(c, childAddress)
+			n++
+		}
+	}
+	walk(t.Root, []uint32{})
+	return out
+}
+
+// TracesToParityJsonRpc flattens a slice of *Trace into a trace_block compatible response.
+func TracesToParityJsonRpc(traces []*Trace) []interface{} {
+	out := []interface{}{}
+	for _, t := range traces {
+		out = append(out, TraceToParityJsonRpc(t)...)
+	}
+	return out
+}
+
+func parityTraceEntry(t *Trace, f *CallFrame, address []uint32) map[string]interface{} {
+	subtraces := 0
+	for _, c := range f.Calls {
+		if c != nil {
+			subtraces++
+		}
+	}
+
+	var value interface{}
+	if f.Value != nil {
+		if hex, err := DecimalStringToHex(*f.Value); err == nil {
+			value = hex
+		}
+	}
+	if value == nil {
+		value = "0x0"
+	}
+
+	action := map[string]interface{}{}
+	var result interface{}
+	var traceType string
+	switch f.Type {
+	case CallType_CREATE, CallType_CREATE2:
+		traceType = "create"
+		action["from"] = BytesToHex(f.From)
+		action["gas"] = fmt.Sprintf("0x%x", f.Gas)
+		action["init"] = BytesToHex(f.Input)
+		action["value"] = value
+		if f.Type == CallType_CREATE2 {
+			action["creationMethod"] = "create2"
+		} else {
+			action["creationMethod"] = "create"
+		}
+		if f.Error == nil {
+			result = map[string]interface{}{
+				"address": BytesToHex(f.To),
+				"code":    BytesToHex(f.Output),
+				"gasUsed": fmt.Sprintf("0x%x", f.GasUsed),
+			}
+		}
+	case CallType_SELFDESTRUCT:
+		traceType = "suicide"
+		action["address"] = BytesToHex(f.From)
+		action["refundAddress"] = BytesToHex(f.To)
+		action["balance"] = value
+	case CallType_REWARD:
+		traceType = "reward"
+		action["author"] = BytesToHex(f.To)
+		action["value"] = value
+		if f.RewardType != nil {
+			action["rewardType"] = *f.RewardType
+		}
+	default:
+		traceType = "call"
+		action["callType"] = strings.ToLower(f.Type.String())
+		action["from"] = BytesToHex(f.From)
+		action["to"] = BytesToHex(f.To)
+		action["gas"] = fmt.Sprintf("0x%x", f.Gas)
+		action["input"] = BytesToHex(f.Input)
+		action["value"] = value
+		if f.Error == nil {
+			result = map[string]interface{}{
+				"gasUsed": fmt.Sprintf("0x%x", f.GasUsed),
+				"output":  BytesToHex(f.Output),
+			}
+		}
+	}
+
+	entry := map[string]interface{}{
+		"action":       action,
+		"result":       result,
+		"subtraces":    subtraces,
+		"traceAddress": address,
+		"type":         traceType,
+	}
+	if f.Error != nil {
+		entry["error"] = *f.Error
+	}
+	if len(t.BlockHash) > 0 {
+		entry["blockHash"] = BytesToHex(t.BlockHash)
+	}
+	if t.BlockNumber != nil {
+		entry["blockNumber"] = *t.BlockNumber
+	}
+	if len(t.TransactionHash) > 0 {
+		entry["transactionHash"] = BytesToHex(t.TransactionHash)
+	} else {
+		entry["transactionHash"] = nil
+	}
+	if t.TransactionIndex != nil {
+		entry["transactionPosition"] = *t.TransactionIndex
+	} else {
+		entry["transactionPosition"] = nil
+	}
+	return entry
+}
@@ -0,0 +1,310 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// traceErrorPaths returns the paths of an INVALID_PARAMETER BaseError.
+func traceErrorPaths(t *testing.T, err error) []string {
+	t.Helper()
+	var baseErr *common.BaseError
+	if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+		t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+	}
+	if paths, ok := baseErr.Details[ConvertErrorPathsDetail].([]string); ok {
+		return paths
+	}
+	return []string{baseErr.Details[ConvertErrorPathDetail].(string)}
+}
+
+const callTracerBlockJson = `[
+  {
+    "txHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+    "result": {
+      "type": "CALL",
+      "from": "0x742d35cc6634c0532925a3b844bc9e7595f0beb7",
+      "to": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+      "value": "0xde0b6b3a7640000",
+      "gas": "0x7a120",
+      "gasUsed": "0x5208",
+      "input": "0xa9059cbb",
+      "output": "0x",
+      "error": "execution reverted",
+      "revertReason": "insufficient balance",
+      "calls": [
+        {
+          "type": "DELEGATECALL",
+          "from": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+          "to": "0x43506849d7c04f9138d1a2050bbf3a0c054402dd",
+          "gas": "0x7000",
+          "gasUsed": "0x100",
+          "input": "0xa9059cbb",
+          "output": "0x01",
+          "calls": [
+            {
+              "type": "STATICCALL",
+              "from": "0x43506849d7c04f9138d1a2050bbf3a0c054402dd",
+              "to": "0x0000000000000000000000000000000000000001",
+              "gas": "0xbb8",
+              "gasUsed": "0xbb8",
+              "input": "0x"
+            }
+          ]
+        },
+        {
+          "type": "CREATE2",
+          "from": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+          "to": "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+          "value": "0x0",
+          "gas": "0x1000",
+          "gasUsed": "0x800",
+          "input": "0x6080",
+          "output": "0x6080"
+        }
+      ]
+    }
+  }
+]`
+
+const parityTraceBlockJson = `[
+  {
+    "action": {"callType": "call", "from": "0x742d35cc6634c0532925a3b844bc9e7595f0beb7", "gas": "0x7a120", "input": "0xa9059cbb", "to": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "value": "0xde0b6b3a7640000"},
+    "blockHash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+    "blockNumber": 19000000,
+    "result": {"gasUsed": "0x5208", "output": "0x"},
+    "subtraces": 2,
+    "traceAddress": [],
+    "transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+    "transactionPosition": 3,
+    "type": "call"
+  },
+  {
+    "action": {"callType": "delegatecall", "from": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "gas": "0x7000", "input": "0xa9059cbb", "to": "0x43506849d7c04f9138d1a2050bbf3a0c054402dd", "value": "0x0"},
+    "blockHash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+    "blockNumber": 19000000,
+    "error": "Reverted",
+    "result": null,
+    "subtraces": 1,
+    "traceAddress": [0],
+    "transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+    "transactionPosition": 3,
+    "type": "call"
+  },
+  {
+    "action": {"address": "0x43506849d7c04f9138d1a2050bbf3a0c054402dd", "balance": "0x10", "refundAddress": "0x742d35cc6634c0532925a3b844bc9e7595f0beb7"},
+    "blockHash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+    "blockNumber": 19000000,
+    "result": null,
+    "subtraces": 0,
+    "traceAddress": [0, 0],
+    "transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+    "transactionPosition": 3,
+    "type": "suicide"
+  },
+  {
+    "action": {"creationMethod": "create2", "from": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "gas": "0x1000", "init": "0x6080", "value": "0x0"},
+    "blockHash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+    "blockNumber": 19000000,
+    "result": {"address": "0x5fbdb2315678afecb367f032d93f642f64180aa3", "code": "0x6080", "gasUsed": "0x800"},
+    "subtraces": 0,
+    "traceAddress": [1],
+    "transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+    "transactionPosition": 3,
+    "type": "create"
+  },
+  {
+    "action": {"author": "0xea674fdde714fd979de3edf0f56aa9716b898ec8", "rewardType": "block", "value": "0x1bc16d674ec80000"},
+    "blockHash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+    "blockNumber": 19000000,
+    "result": null,
+    "subtraces": 0,
+    "traceAddress": [],
+    "transactionHash": null,
+    "transactionPosition": null,
+    "type": "reward"
+  }
+]`
+
+func TestJsonRpcCallTraceToProto(t *testing.T) {
+	var results []*JsonRpcCallTrace
+	if err := json.Unmarshal([]byte(callTracerBlockJson), &results); err != nil {
+		t.Fatalf("Failed to unmarshal callTracer output: %v", err)
+	}
+	header := &BlockHeader{Number: 19000000, Hash: MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890")}
+
+	traces, err := ParseJsonRpcCallTraces(results, header)
+	if err != nil {
+		t.Fatalf("Failed to convert traces: %v", err)
+	}
+	if len(traces) != 1 {
+		t.Fatalf("Expected 1 trace, got %d", len(traces))
+	}
+
+	trace := traces[0]
+	if trace.GetBlockNumber() != 19000000 || trace.GetTransactionIndex() != 0 {
+		t.Errorf("Block context not filled: number=%d index=%d", trace.GetBlockNumber(), trace.GetTransactionIndex())
+	}
+	root := trace.Root
+	if root.Type != CallType_CALL || root.Gas != 500000 || root.GasUsed != 21000 {
+		t.Errorf("Unexpected root frame: %v", root)
+	}
+	if root.GetValue() != "0xde0b6b3a7640000" || root.GetError() != "execution reverted" || root.GetRevertReason() != "insufficient balance" {
+		t.Errorf("Unexpected root value/error: %v", root)
+	}
+	if len(root.Calls) != 2 {
+		t.Fatalf("Expected 2 child calls, got %d", len(root.Calls))
+	}
+	if root.Calls[0].Type != CallType_DELEGATECALL || root.Calls[0].Value != nil {
+		t.Errorf("Unexpected first child: %v", root.Calls[0])
+	}
+	nested := root.Calls[0].Calls[0]
+	if nested.Type != CallType_STATICCALL || len(nested.TraceAddress) != 2 || nested.TraceAddress[0] != 0 || nested.TraceAddress[1] != 0 {
+		t.Errorf("Unexpected nested frame: %v", nested)
+	}
+	if root.Calls[1].Type != CallType_CREATE2 || BytesToHex(root.Calls[1].To) != "0x5fbdb2315678afecb367f032d93f642f64180aa3" {
+		t.Errorf("Unexpected create2 frame: %v", root.Calls[1])
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		encoded, err := json.Marshal(TracesToJsonRpc(traces))
+		if err != nil {
+			t.Fatalf("Failed to marshal traces: %v", err)
+		}
+		var decoded []*JsonRpcCallTrace
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal traces: %v", err)
+		}
+		again, err := ParseJsonRpcCallTraces(decoded, header)
+		if err != nil {
+			t.Fatalf("Failed to convert round-tripped traces: %v", err)
+		}
+		if again[0].String() != traces[0].String() {
+			t.Errorf("Round trip mismatch\nwant: %v\ngot:  %v", traces[0], again[0])
+		}
+	})
+
+	t.Run("TracerError", func(t *testing.T) {
+		_, err := ParseJsonRpcCallTraces([]*JsonRpcCallTrace{{TxHash: "0x01", Error: "execution timeout"}}, nil)
+		if paths := traceErrorPaths(t, err); !reflect.DeepEqual(paths, []string{"txs[0].error"}) {
+			t.Errorf("Unexpected paths %v", paths)
+		}
+	})
+
+	t.Run("CollectErrors", func(t *testing.T) {
+		var block []*JsonRpcCallTrace
+		json.Unmarshal([]byte(callTracerBlockJson), &block)
+		bad := *block[0].Result
+		bad.Calls = []*JsonRpcCallFrame{{Type: "CALL", Gas: "0xzz"}, {Type: "JUMP"}}
+		block = append(block, &JsonRpcCallTrace{TxHash: "0x02", Error: "execution timeout"}, &JsonRpcCallTrace{TxHash: "0x03", Result: &bad})
+
+		_, err := ParseJsonRpcCallTraces(block, header)
+		if paths := traceErrorPaths(t, err); !reflect.DeepEqual(paths, []string{"txs[1].error"}) {
+			t.Errorf("Unexpected paths %v", paths)
+		}
+		_, err = ParseJsonRpcCallTraces(block, header, WithCollectErrors(true))
+		want := []string{"txs[1].error", "txs[2].result.calls[0].gas", "txs[2].result.calls[1].type"}
+		if paths := traceErrorPaths(t, err); !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+	})
+}
+
+func TestJsonRpcParityTracesToProto(t *testing.T) {
+	var entries []*JsonRpcParityTrace
+	if err := json.Unmarshal([]byte(parityTraceBlockJson), &entries); err != nil {
+		t.Fatalf("Failed to unmarshal trace_block output: %v", err)
+	}
+
+	traces, err := ParseJsonRpcParityTraces(entries)
+	if err != nil {
+		t.Fatalf("Failed to convert traces: %v", err)
+	}
+	if len(traces) != 2 {
+		t.Fatalf("Expected 2 traces (transaction and reward), got %d", len(traces))
+	}
+
+	tx := traces[0]
+	if tx.GetBlockNumber() != 19000000 || tx.GetTransactionIndex() != 3 {
+		t.Errorf("Unexpected block context: number=%d index=%d", tx.GetBlockNumber(), tx.GetTransactionIndex())
+	}
+	if len(tx.Root.Calls) != 2 {
+		t.Fatalf("Expected 2 root children, got %d", len(tx.Root.Calls))
+	}
+	delegate := tx.Root.Calls[0]
+	if delegate.Type != CallType_DELEGATECALL || delegate.GetError() != "Reverted" || delegate.GasUsed != 0 {
+		t.Errorf("Unexpected delegatecall frame: %v", delegate)
+	}
+	if len(delegate.Calls) != 1 || delegate.Calls[0].Type != CallType_SELFDESTRUCT || delegate.Calls[0].GetValue() != "0x10" {
+		t.Errorf("Unexpected selfdestruct frame: %v", delegate.Calls)
+	}
+	create := tx.Root.Calls[1]
+	if create.Type != CallType_CREATE2 || BytesToHex(create.Output) != "0x6080" || create.GasUsed != 0x800 {
+		t.Errorf("Unexpected create frame: %v", create)
+	}
+
+	reward := traces[1]
+	if len(reward.TransactionHash) != 0 || reward.TransactionIndex != nil {
+		t.Errorf("Reward trace should not reference a transaction: %v", reward)
+	}
+	if reward.Root.Type != CallType_REWARD || reward.Root.GetRewardType() != "block" || BytesToHex(reward.Root.To) != "0xea674fdde714fd979de3edf0f56aa9716b898ec8" {
+		t.Errorf("Unexpected reward frame: %v", reward.Root)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		encoded, err := json.Marshal(TracesToParityJsonRpc(traces))
+		if err != nil {
+			t.Fatalf("Failed to marshal traces: %v", err)
+		}
+		var decoded []*JsonRpcParityTrace
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal traces: %v", err)
+		}
+		if len(decoded) != len(entries) {
+			t.Fatalf("Expected %d flat entries, got %d", len(entries), len(decoded))
+		}
+		for i := range entries {
+			if decoded[i].Subtraces != entries[i].Subtraces || traceAddressKey(decoded[i].TraceAddress) != traceAddressKey(entries[i].TraceAddress) {
+				t.Errorf("Entry %d: subtraces/traceAddress mismatch", i)
+			}
+		}
+		again, err := ParseJsonRpcParityTraces(decoded)
+		if err != nil {
+			t.Fatalf("Failed to convert round-tripped traces: %v", err)
+		}
+		for i := range traces {
+			if again[i].String() != traces[i].String() {
+				t.Errorf("Round trip mismatch for trace %d\nwant: %v\ngot:  %v", i, traces[i], again[i])
+			}
+		}
+	})
+
+	t.Run("OrphanFrame", func(t *testing.T) {
+		_, err := ParseJsonRpcParityTraces(entries[1:2])
+		if paths := traceErrorPaths(t, err); !reflect.DeepEqual(paths, []string{"traces[0].traceAddress"}) {
+			t.Errorf("Unexpected paths %v", paths)
+		}
+	})
+
+	t.Run("CollectErrors", func(t *testing.T) {
+		var block []*JsonRpcParityTrace
+		json.Unmarshal([]byte(parityTraceBlockJson), &block)
+		block[1].Action.Gas = "0xzz"
+		block[3].Result.GasUsed = "0xzz"
+		block[4].Type = "jump"
+
+		_, err := ParseJsonRpcParityTraces(block)
+		if paths := traceErrorPaths(t, err); !reflect.DeepEqual(paths, []string{"traces[1].action.gas"}) {
+			t.Errorf("Unexpected paths %v", paths)
+		}
+		_, err = ParseJsonRpcParityTraces(block, WithCollectErrors(true))
+		want := []string{"traces[1].action.gas", "traces[3].result.gasUsed", "traces[4].type"}
+		if paths := traceErrorPaths(t, err); !reflect.DeepEqual(paths, want) {
+			t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", want, paths)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// JsonRpcTxPoolContent is the result of txpool_content: full transaction objects keyed by
+// sender address and then by nonce, for the pending and queued pools.
+type JsonRpcTxPoolContent struct {
+	Pending map[string]map[string]*JsonRpcTransaction `json:"pending"`
+	Queued  map[string]map[string]*JsonRpcTransaction `json:"queued"`
+}
+
+// ToProto converts a txpool_content result into pending transactions ordered by pool, sender
+// and nonce. seenAt is recorded as the first-seen time unless it is zero.
+func (c *JsonRpcTxPoolContent) ToProto(seenAt time.Time, opts ...ConvertOption) ([]*PendingTransaction, error) {
+	cfg := newConvertConfig(opts)
+	var out []*PendingTransaction
+	for _, pool := range []struct {
+		status PendingTransactionStatus
+		txs    map[string]map[string]*JsonRpcTransaction
+	}{
+		{PendingTransactionStatus_PENDING, c.Pending},
+		{PendingTransactionStatus_QUEUED, c.Queued},
+	} {
+		for _, sender := range sortedKeys(pool.txs) {
+			nonces, err := sortedNonces(pool.txs[sender])
+			if err != nil {
+				return nil, fmt.Errorf("invalid nonce for %s: %w", sender, err)
+			}
+			for _, nonce := range nonces {
+				jtx := pool.txs[sender][nonce]
+				if jtx == nil {
+					return nil, fmt.Errorf("%s transaction %s/%s is null", poolName(pool.status), sender, nonce)
+				}
+				p := newFieldParser(cfg.collectErrors)
+				tx := jtx.toProto(p, nil, cfg)
+				if err := p.err(); err != nil {
+					return nil, fmt.Errorf("failed to parse %s transaction %s/%s: %w", poolName(pool.status), sender, nonce, err)
+				}
+				out = append(out, newPendingTransaction(tx, pool.status, seenAt))
+			}
+		}
+	}
+	return out, nil
+}
+
+// JsonRpcTxPoolInspect is the result of txpool_inspect: one-line summaries keyed by sender
+// address and then by nonce, such as "0x…: 1000 wei + 21000 gas × 20000000000 wei".
+type JsonRpcTxPoolInspect struct {
+	Pending map[string]map[string]string `json:"pending"`
+	Queued  map[string]map[string]string `json:"queued"`
+}
+
+// ToProto converts a txpool_inspect result into pending transactions ordered by pool, sender
+// and nonce. The summaries only carry the recipient, value, gas limit and gas price, so the
+// transactions have no hash, input or signature.
+func (c *JsonRpcTxPoolInspect) ToProto(seenAt time.Time) ([]*PendingTransaction, error) {
+	var out []*PendingTransaction
+	for _, pool := range []struct {
+		status PendingTransactionStatus
+		txs    map[string]map[string]string
+	}{
+		{PendingTransactionStatus_PENDING, c.Pending},
+		{PendingTransactionStatus_QUEUED, c.Queued},
+	} {
+		for _, sender := range sortedKeys(pool.txs) {
+			from, err := HexToBytes(sender)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sender %s: %w", sender, err)
+			}
+			nonces, err := sortedNonces(pool.txs[sender])
+			if err != nil {
+				return nil, fmt.Errorf("invalid nonce for %s: %w", sender, err)
+			}
+			for _, nonce := range nonces {
+				tx, err := parseTxPoolSummary(pool.txs[sender][nonce])
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse %s transaction %s/%s: %w", poolName(pool.status), sender, nonce, err)
+				}
+				tx.From = from
+				tx.Nonce, _ = NumberishToUint64(nonce)
+				out = append(out, newPendingTransaction(tx, pool.status, seenAt))
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseTxPoolSummary parses a txpool_inspect summary:
+// "<to or 'contract creation'>: <value> wei + <gas> gas × <gasPrice> wei".
+func parseTxPoolSummary(summary string) (*Transaction, error) {
+	to, rest, ok := strings.Cut(summary, ": ")
+	if !ok {
+		return nil, fmt.Errorf("malformed summary %q", summary)
+	}
+	fields := strings.Fields(rest)
+	if len(fields) != 8 || fields[1] != "wei" || fields[2] != "+" || fields[4] != "gas" || fields[5] != "×" || fields[7] != "wei" {
+		return nil, fmt.Errorf("malformed summary %q", summary)
+	}
+	tx := &Transaction{}
+	if to != "contract creation" {
+		addr, err := HexToBytes(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		tx.To = addr
+	}
+	if _, ok := parseWei(fields[0]); !ok {
+		return nil, fmt.Errorf("invalid value %q", fields[0])
+	}
+	tx.Value = fields[0]
+	gas, err := strconv.ParseUint(fields[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas %q: %w", fields[3], err)
+	}
+	tx.GasLimit = gas
+	if _, ok := parseWei(fields[6]); !ok {
+		return nil, fmt.Errorf("invalid gas price %q", fields[6])
+	}
+	tx.GasPrice = StringPtr(fields[6])
+	return tx, nil
+}
+
+// ParseJsonRpcPendingTransaction converts the full transaction object delivered by an
+// eth_subscribe("newPendingTransactions", true) notification into a pending transaction.
+func ParseJsonRpcPendingTransaction(jtx *JsonRpcTransaction, seenAt time.Time, opts ...ConvertOption) (*PendingTransaction, error) {
+	if jtx == nil {
+		return nil, errors.New("pending transaction is null")
+	}
+	tx, err := jtx.ToProto(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newPendingTransaction(tx, PendingTransactionStatus_PENDING, seenAt), nil
+}
+
+// ReplacePendingTransaction records that replacement (same sender and nonce, usually a fee
+// bump) superseded replaced. It returns copies of both: the old one with replacedBy set and the
+// new one with the old hash appended to its replacement chain. The inputs are not modified.
+func ReplacePendingTransaction(replaced, replacement *PendingTransaction) (*PendingTransaction, *PendingTransaction, error) {
+	if replaced.GetTransaction() == nil || replacement.GetTransaction() == nil {
+		return nil, nil, errors.New("both pending transactions need a transaction body")
+	}
+	oldTx, newTx := replaced.Transaction, replacement.Transaction
+	if !bytes.Equal(oldTx.From, newTx.From) || oldTx.Nonce != newTx.Nonce {
+		return nil, nil, fmt.Errorf("transaction %s/%d cannot replace %s/%d", BytesToHex(newTx.From), newTx.Nonce, BytesToHex(oldTx.From), oldTx.Nonce)
+	}
+
+	old := proto.Clone(replaced).(*PendingTransaction)
+	old.ReplacedBy = newTx.Hash
+	next := proto.Clone(replacement).(*PendingTransaction)
+	next.Replaces = append(append([][]byte{}, replaced.Replaces...), oldTx.Hash)
+	return old, next, nil
+}
+
+// PendingTransactionsToTxPoolContent converts pending transactions into the txpool_content
+// result format, grouping them by pool, sender and nonce.
+func PendingTransactionsToTxPoolContent(txs []*PendingTransaction, opts ...ConvertOption) map[string]interface{} {
+	pools := map[PendingTransactionStatus]map[string]interface{}{
+		PendingTransactionStatus_PENDING: {},
+		PendingTransactionStatus_QUEUED:  {},
+	}
+	for _, ptx := range txs {
+		if ptx.GetTransaction() == nil {
+			continue
+		}
+		pool, ok := pools[ptx.Status]
+		if !ok {
+			continue
+		}
+		sender := BytesToHex(ptx.Transaction.From)
+		byNonce, ok := pool[sender].(map[string]interface{})
+		if !ok {
+			byNonce = map[string]interface{}{}
+			pool[sender] = byNonce
+		}
+		byNonce[strconv.FormatUint(ptx.Transaction.Nonce, 10)] = TransactionToJsonRpc(ptx.Transaction, opts...)
+	}
+	return map[string]interface{}{
+		"pending": pools[PendingTransactionStatus_PENDING],
+		"queued":  pools[PendingTransactionStatus_QUEUED],
+	}
+}
+
+func newPendingTransaction(tx *Transaction, status PendingTransactionStatus, seenAt time.Time) *PendingTransaction {
+	ptx := &PendingTransaction{Transaction: tx, Status: status}
+	if !seenAt.IsZero() {
+		ptx.FirstSeenTimestampMs = Uint64Ptr(uint64(seenAt.UnixMilli()))
+	}
+	return ptx
+}
+
+func poolName(status PendingTransactionStatus) string {
+	return strings.ToLower(status.String())
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedNonces returns the nonce keys of a txpool sender entry in numeric order.
+func sortedNonces[V any](m map[string]V) ([]string, error) {
+	keys := sortedKeys(m)
+	values := make(map[string]uint64, len(keys))
+	for _, k := range keys {
+		n, err := NumberishToUint64(k)
+		if err != nil {
+			return nil, err
+		}
+		values[k] = n
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return values[keys[i]] < values[keys[j]] })
+	return keys, nil
+}
@@ -0,0 +1,131 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const txPoolSender = "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+
+func txPoolTestTransaction(nonce, hash string) string {
+	return `{"blockHash":null,"blockNumber":null,"from":"` + txPoolSender + `","gas":"0x5208","gasPrice":"0x4a817c800","maxFeePerGas":"0x4a817c800","maxPriorityFeePerGas":"0x3b9aca00","hash":"` + hash + `","input":"0x","nonce":"` + nonce + `","to":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","transactionIndex":null,"value":"0xde0b6b3a7640000","type":"0x2","accessList":[],"chainId":"0x1","v":"0x1","r":"0x01","s":"0x02","yParity":"0x1"}`
+}
+
+func TestTxPoolContent(t *testing.T) {
+	raw := `{"pending":{"` + txPoolSender + `":{"10":` + txPoolTestTransaction("0xa", "0x00000000000000000000000000000000000000000000000000000000000000aa") +
+		`,"9":` + txPoolTestTransaction("0x9", "0x0000000000000000000000000000000000000000000000000000000000000099") + `}},` +
+		`"queued":{"` + txPoolSender + `":{"12":` + txPoolTestTransaction("0xc", "0x00000000000000000000000000000000000000000000000000000000000000cc") + `}}}`
+	var content JsonRpcTxPoolContent
+	if err := json.Unmarshal([]byte(raw), &content); err != nil {
+		t.Fatalf("Failed to unmarshal txpool_content: %v", err)
+	}
+	seenAt := time.UnixMilli(1_700_000_000_123)
+	txs, err := content.ToProto(seenAt)
+	if err != nil {
+		t.Fatalf("Failed to convert txpool_content: %v", err)
+	}
+	if len(txs) != 3 {
+		t.Fatalf("Expected 3 pending transactions, got %d", len(txs))
+	}
+	for i, want := range []struct {
+		nonce  uint64
+		status PendingTransactionStatus
+	}{
+		{9, PendingTransactionStatus_PENDING},
+		{10, PendingTransactionStatus_PENDING},
+		{12, PendingTransactionStatus_QUEUED},
+	} {
+		if txs[i].Transaction.Nonce != want.nonce || txs[i].Status != want.status {
+			t.Errorf("txs[%d] = nonce %d %v, want nonce %d %v", i, txs[i].Transaction.Nonce, txs[i].Status, want.nonce, want.status)
+		}
+		if txs[i].GetFirstSeenTimestampMs() != 1_700_000_000_123 {
+			t.Errorf("txs[%d] first seen = %d", i, txs[i].GetFirstSeenTimestampMs())
+		}
+		if txs[i].Transaction.BlockNumber != nil || len(txs[i].Transaction.BlockHash) != 0 {
+			t.Errorf("txs[%d] should have no block fields", i)
+		}
+	}
+
+	out := PendingTransactionsToTxPoolContent(txs)
+	queued := out["queued"].(map[string]interface{})[txPoolSender].(map[string]interface{})
+	if tx, ok := queued["12"].(map[string]interface{}); !ok || tx["nonce"] != "0xc" {
+		t.Errorf("Expected queued nonce 12 in txpool_content output, got %v", queued)
+	}
+
+	content.Pending[txPoolSender]["9"].Gas = "0xzz"
+	if _, err := content.ToProto(seenAt); err == nil || !strings.Contains(err.Error(), "pending transaction "+txPoolSender+"/9") {
+		t.Errorf("Expected error naming the pending transaction, got %v", err)
+	}
+}
+
+func TestTxPoolInspect(t *testing.T) {
+	raw := `{"pending":{"` + txPoolSender + `":{"9":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48: 1000000000000000000 wei + 21000 gas × 20000000000 wei"}},` +
+		`"queued":{"` + txPoolSender + `":{"12":"contract creation: 0 wei + 53000 gas × 1000000000 wei"}}}`
+	var inspect JsonRpcTxPoolInspect
+	if err := json.Unmarshal([]byte(raw), &inspect); err != nil {
+		t.Fatalf("Failed to unmarshal txpool_inspect: %v", err)
+	}
+	txs, err := inspect.ToProto(time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to convert txpool_inspect: %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Expected 2 pending transactions, got %d", len(txs))
+	}
+
+	tx := txs[0].Transaction
+	if tx.Nonce != 9 || tx.GasLimit != 21000 || tx.GetGasPrice() != "20000000000" || tx.Value != "1000000000000000000" {
+		t.Errorf("Unexpected pending transaction: %v", tx)
+	}
+	if !bytes.Equal(tx.From, MustHexToBytes(txPoolSender)) || BytesToHex(tx.To) != "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" {
+		t.Errorf("Unexpected from/to: %x, %x", tx.From, tx.To)
+	}
+	if txs[0].FirstSeenTimestampMs != nil {
+		t.Error("Expected no first-seen time for a zero seenAt")
+	}
+	if txs[1].Status != PendingTransactionStatus_QUEUED || txs[1].Transaction.To != nil || txs[1].Transaction.GasLimit != 53000 {
+		t.Errorf("Unexpected contract creation: %v", txs[1])
+	}
+
+	inspect.Pending[txPoolSender]["9"] = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48: lots of wei"
+	if _, err := inspect.ToProto(time.Time{}); err == nil {
+		t.Error("Expected malformed summary to fail")
+	}
+}
+
+func TestPendingTransactionReplacement(t *testing.T) {
+	var first, second *JsonRpcTransaction
+	json.Unmarshal([]byte(txPoolTestTransaction("0x9", "0x00000000000000000000000000000000000000000000000000000000000000aa")), &first)
+	json.Unmarshal([]byte(txPoolTestTransaction("0x9", "0x00000000000000000000000000000000000000000000000000000000000000bb")), &second)
+
+	original, err := ParseJsonRpcPendingTransaction(first, time.UnixMilli(1000))
+	if err != nil {
+		t.Fatalf("Failed to parse pending transaction: %v", err)
+	}
+	bump, err := ParseJsonRpcPendingTransaction(second, time.UnixMilli(2000))
+	if err != nil {
+		t.Fatalf("Failed to parse pending transaction: %v", err)
+	}
+
+	replaced, replacement, err := ReplacePendingTransaction(original, bump)
+	if err != nil {
+		t.Fatalf("ReplacePendingTransaction() error = %v", err)
+	}
+	if !bytes.Equal(replaced.ReplacedBy, bump.Transaction.Hash) {
+		t.Errorf("Expected replacedBy %x, got %x", bump.Transaction.Hash, replaced.ReplacedBy)
+	}
+	if len(replacement.Replaces) != 1 || !bytes.Equal(replacement.Replaces[0], original.Transaction.Hash) {
+		t.Errorf("Unexpected replacement chain %x", replacement.Replaces)
+	}
+	if original.ReplacedBy != nil || len(bump.Replaces) != 0 {
+		t.Error("Inputs must not be modified")
+	}
+
+	bump.Transaction.Nonce = 10
+	if _, _, err := ReplacePendingTransaction(original, bump); err == nil {
+		t.Error("Expected a different nonce to be rejected")
+	}
+}
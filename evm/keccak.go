@@ -0,0 +1,123 @@
+package evm
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Keccak-256 as used by Ethereum (the original Keccak padding, not NIST SHA3-256).
+
+const keccak256Rate = 136
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+
+var keccakPiLanes = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	for round := 0; round < 24; round++ {
+		// theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+		// rho and pi
+		t := a[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPiLanes[i]
+			t, a[j] = a[j], bits.RotateLeft64(t, keccakRotations[i])
+		}
+		// chi
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				c[x] = a[y+x]
+			}
+			for x := 0; x < 5; x++ {
+				a[y+x] ^= ^c[(x+1)%5] & c[(x+2)%5]
+			}
+		}
+		// iota
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// keccak256State is a streaming Keccak-256 hasher implementing hash.Hash.
+type keccak256State struct {
+	a   [25]uint64
+	buf [keccak256Rate]byte
+	n   int
+}
+
+// NewKeccak256 returns a hash.Hash computing Ethereum's Keccak-256.
+func NewKeccak256() hash.Hash {
+	return &keccak256State{}
+}
+
+// Keccak256 returns the Keccak-256 hash of the concatenation of data.
+func Keccak256(data ...[]byte) []byte {
+	var s keccak256State
+	for _, b := range data {
+		s.Write(b)
+	}
+	return s.Sum(nil)
+}
+
+func (s *keccak256State) absorb() {
+	for i := 0; i < keccak256Rate/8; i++ {
+		s.a[i] ^= binary.LittleEndian.Uint64(s.buf[i*8:])
+	}
+	keccakF1600(&s.a)
+	s.n = 0
+}
+
+func (s *keccak256State) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		n := copy(s.buf[s.n:], p)
+		s.n += n
+		p = p[n:]
+		if s.n == keccak256Rate {
+			s.absorb()
+		}
+	}
+	return written, nil
+}
+
+// Sum appends the hash of the data written so far to b without changing the state.
+func (s *keccak256State) Sum(b []byte) []byte {
+	d := *s
+	for i := d.n; i < keccak256Rate; i++ {
+		d.buf[i] = 0
+	}
+	d.buf[d.n] ^= 0x01
+	d.buf[keccak256Rate-1] ^= 0x80
+	d.absorb()
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], d.a[i])
+	}
+	return append(b, out[:]...)
+}
+
+func (s *keccak256State) Reset() {
+	*s = keccak256State{}
+}
+
+func (s *keccak256State) Size() int { return 32 }
+
+func (s *keccak256State) BlockSize() int { return keccak256Rate }
@@ -0,0 +1,42 @@
+package evm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{"Empty", nil, "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"Abc", []byte("abc"), "0x4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{"TransferSignature", []byte("Transfer(address,address,uint256)"), TransferEventSignature},
+		{"RateBoundary", bytes.Repeat([]byte{'a'}, 136), ""},
+		{"MultiBlock", []byte(strings.Repeat("The quick brown fox jumps over the lazy dog", 10)), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BytesToHex(Keccak256(tt.input))
+			if tt.want != "" && got != tt.want {
+				t.Errorf("Keccak256() = %s, want %s", got, tt.want)
+			}
+
+			// Streaming writes in uneven chunks must agree with the one-shot hash
+			h := NewKeccak256()
+			for i := 0; i < len(tt.input); i += 7 {
+				end := i + 7
+				if end > len(tt.input) {
+					end = len(tt.input)
+				}
+				h.Write(tt.input[i:end])
+			}
+			if streamed := BytesToHex(h.Sum(nil)); streamed != got {
+				t.Errorf("Streaming hash %s differs from one-shot hash %s", streamed, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package evm
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// RetractLogs returns copies of logs marked as removed, for delivery to subscribers when a
+// reorg drops the blocks that emitted them. The copies are in reverse order so consumers undo
+// the most recent effects first; the input logs are not modified.
+func RetractLogs(logs []*Log) []*Log {
+	retracted := make([]*Log, 0, len(logs))
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i] == nil {
+			continue
+		}
+		l := proto.Clone(logs[i]).(*Log)
+		l.Removed = true
+		retracted = append(retracted, l)
+	}
+	return retracted
+}
+
+// LogMatchesFilter reports whether a log matches an address and topic filter with eth_getLogs
+// semantics: an empty address list matches any address, and each topic position matches when its
+// TopicFilter is nil or empty, or contains the log's topic at that position.
+func LogMatchesFilter(l *Log, addresses [][]byte, topics []*TopicFilter) bool {
+	if l == nil {
+		return false
+	}
+	if len(addresses) > 0 {
+		found := false
+		for _, a := range addresses {
+			if bytes.Equal(a, l.Address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for i, filter := range topics {
+		if filter == nil || len(filter.Values) == 0 {
+			continue
+		}
+		if i >= len(l.Topics) {
+			return false
+		}
+		found := false
+		for _, v := range filter.Values {
+			if bytes.Equal(v, l.Topics[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package evm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRemovedLogs(t *testing.T) {
+	t.Run("ParseAndEmit", func(t *testing.T) {
+		raw := `{"address":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","blockHash":"0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890","blockNumber":"0x121eac0","data":"0x","logIndex":"0x3","removed":true,"topics":[],"transactionHash":"0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef","transactionIndex":"0x1"}`
+		var jl JsonRpcLog
+		if err := json.Unmarshal([]byte(raw), &jl); err != nil {
+			t.Fatalf("Failed to unmarshal log: %v", err)
+		}
+		l, err := jl.ToProto()
+		if err != nil {
+			t.Fatalf("Failed to convert log: %v", err)
+		}
+		if !l.Removed {
+			t.Error("Expected removed log")
+		}
+		if len(l.Extensions) != 0 {
+			t.Errorf("removed should not be captured as an extension: %v", l.Extensions)
+		}
+		if LogToJsonRpc(l)["removed"] != true {
+			t.Error("Expected removed to be emitted as true")
+		}
+		assertSameAsMap(t, LogToJsonRpc(l), AppendLogJsonRpc(nil, l))
+	})
+
+	t.Run("RetractLogs", func(t *testing.T) {
+		first := encodeTestLog()
+		second := encodeTestLog()
+		second.LogIndex = first.LogIndex + 1
+
+		retracted := RetractLogs([]*Log{first, nil, second})
+		if len(retracted) != 2 {
+			t.Fatalf("Expected 2 retracted logs, got %d", len(retracted))
+		}
+		if retracted[0].LogIndex != second.LogIndex || retracted[1].LogIndex != first.LogIndex {
+			t.Errorf("Expected retractions in reverse order, got %d, %d", retracted[0].LogIndex, retracted[1].LogIndex)
+		}
+		if !retracted[0].Removed || !retracted[1].Removed {
+			t.Error("Expected retracted logs to be marked removed")
+		}
+		if first.Removed || second.Removed {
+			t.Error("Input logs must not be modified")
+		}
+	})
+}
+
+func TestLogMatchesFilter(t *testing.T) {
+	l := encodeTestLog()
+	other := MustHexToBytes("0x0000000000000000000000000000000000000001")
+
+	tests := []struct {
+		name      string
+		addresses [][]byte
+		topics    []*TopicFilter
+		want      bool
+	}{
+		{"NoFilter", nil, nil, true},
+		{"Address", [][]byte{other, l.Address}, nil, true},
+		{"OtherAddress", [][]byte{other}, nil, false},
+		{"FirstTopic", nil, []*TopicFilter{MustNewTopicFilter(TransferEventSignature)}, true},
+		{"WildcardThenTopic", nil, []*TopicFilter{nil, {Values: [][]byte{l.Topics[1]}}}, true},
+		{"TopicMismatch", nil, []*TopicFilter{{Values: [][]byte{other}}}, false},
+		{"TooManyTopics", nil, []*TopicFilter{nil, nil, {Values: [][]byte{other}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LogMatchesFilter(l, tt.addresses, tt.topics); got != tt.want {
+				t.Errorf("LogMatchesFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
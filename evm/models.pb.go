@@ -35,23 +35,51 @@ const (
 	TransactionType_BLOB TransactionType = 3
 	// EIP-7702
 	TransactionType_SET_CODE TransactionType = 4
+	// Arbitrum L1-to-L2 ETH deposit, minted by the bridge without a signature
+	TransactionType_ARBITRUM_DEPOSIT TransactionType = 100
+	// Arbitrum unsigned transaction sent from L1 on behalf of an L2 account
+	TransactionType_ARBITRUM_UNSIGNED TransactionType = 101
+	// Arbitrum contract transaction sent from L1 by a contract (aliased sender)
+	TransactionType_ARBITRUM_CONTRACT TransactionType = 102
+	// Arbitrum redeem of a retryable ticket, automatic or manual
+	TransactionType_ARBITRUM_RETRY TransactionType = 104
+	// Arbitrum creation of a retryable ticket from an L1 message
+	TransactionType_ARBITRUM_SUBMIT_RETRYABLE TransactionType = 105
+	// Arbitrum internal transaction issued by ArbOS, e.g. to record the L1 block number
+	TransactionType_ARBITRUM_INTERNAL TransactionType = 106
+	// OP stack deposit transaction derived from L1 (user deposits, L1 attributes and network upgrades)
+	TransactionType_OPTIMISM_DEPOSIT TransactionType = 126
 )
 
 // Enum value maps for TransactionType.
 var (
 	TransactionType_name = map[int32]string{
-		0: "LEGACY",
-		1: "ACCESS_LIST",
-		2: "DYNAMIC_FEE",
-		3: "BLOB",
-		4: "SET_CODE",
+		0:   "LEGACY",
+		1:   "ACCESS_LIST",
+		2:   "DYNAMIC_FEE",
+		3:   "BLOB",
+		4:   "SET_CODE",
+		100: "ARBITRUM_DEPOSIT",
+		101: "ARBITRUM_UNSIGNED",
+		102: "ARBITRUM_CONTRACT",
+		104: "ARBITRUM_RETRY",
+		105: "ARBITRUM_SUBMIT_RETRYABLE",
+		106: "ARBITRUM_INTERNAL",
+		126: "OPTIMISM_DEPOSIT",
 	}
 	TransactionType_value = map[string]int32{
-		"LEGACY":      0,
-		"ACCESS_LIST": 1,
-		"DYNAMIC_FEE": 2,
-		"BLOB":        3,
-		"SET_CODE":    4,
+		"LEGACY":                    0,
+		"ACCESS_LIST":               1,
+		"DYNAMIC_FEE":               2,
+		"BLOB":                      3,
+		"SET_CODE":                  4,
+		"ARBITRUM_DEPOSIT":          100,
+		"ARBITRUM_UNSIGNED":         101,
+		"ARBITRUM_CONTRACT":         102,
+		"ARBITRUM_RETRY":            104,
+		"ARBITRUM_SUBMIT_RETRYABLE": 105,
+		"ARBITRUM_INTERNAL":         106,
+		"OPTIMISM_DEPOSIT":          126,
 	}
 )
 
@@ -82,6 +110,128 @@ func (TransactionType) EnumDescriptor() ([]byte, []int) {
 	return file_models_proto_rawDescGZIP(), []int{0}
 }
 
+// The kind of frame in an execution trace, normalised across geth callTracer and parity/erigon trace_* output
+type CallType int32
+
+const (
+	// Message call (CALL opcode) or the top-level call of a transaction
+	CallType_CALL CallType = 0
+	// Read-only message call (STATICCALL opcode, EIP-214)
+	CallType_STATICCALL CallType = 1
+	// Call executing the callee's code in the caller's context (DELEGATECALL opcode, EIP-7)
+	CallType_DELEGATECALL CallType = 2
+	// Legacy call executing the callee's code with the caller's storage (CALLCODE opcode)
+	CallType_CALLCODE CallType = 3
+	// Contract creation (CREATE opcode or a transaction without a recipient)
+	CallType_CREATE CallType = 4
+	// Contract creation at a deterministic address (CREATE2 opcode, EIP-1014)
+	CallType_CREATE2 CallType = 5
+	// Contract destruction sending the remaining balance to a beneficiary (SELFDESTRUCT opcode)
+	CallType_SELFDESTRUCT CallType = 6
+	// Block or uncle reward pseudo-frame emitted by parity-style tracers on pre-merge blocks
+	CallType_REWARD CallType = 7
+)
+
+// Enum value maps for CallType.
+var (
+	CallType_name = map[int32]string{
+		0: "CALL",
+		1: "STATICCALL",
+		2: "DELEGATECALL",
+		3: "CALLCODE",
+		4: "CREATE",
+		5: "CREATE2",
+		6: "SELFDESTRUCT",
+		7: "REWARD",
+	}
+	CallType_value = map[string]int32{
+		"CALL":         0,
+		"STATICCALL":   1,
+		"DELEGATECALL": 2,
+		"CALLCODE":     3,
+		"CREATE":       4,
+		"CREATE2":      5,
+		"SELFDESTRUCT": 6,
+		"REWARD":       7,
+	}
+)
+
+func (x CallType) Enum() *CallType {
+	p := new(CallType)
+	*p = x
+	return p
+}
+
+func (x CallType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CallType) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[1].Descriptor()
+}
+
+func (CallType) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[1]
+}
+
+func (x CallType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CallType.Descriptor instead.
+func (CallType) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{1}
+}
+
+// The sub-pool of a node's transaction pool that holds a pending transaction
+type PendingTransactionStatus int32
+
+const (
+	// Executable: the nonce follows the sender's account nonce and earlier pooled transactions, so it can be included in the next block
+	PendingTransactionStatus_PENDING PendingTransactionStatus = 0
+	// Not yet executable, typically because of a nonce gap or insufficient balance
+	PendingTransactionStatus_QUEUED PendingTransactionStatus = 1
+)
+
+// Enum value maps for PendingTransactionStatus.
+var (
+	PendingTransactionStatus_name = map[int32]string{
+		0: "PENDING",
+		1: "QUEUED",
+	}
+	PendingTransactionStatus_value = map[string]int32{
+		"PENDING": 0,
+		"QUEUED":  1,
+	}
+)
+
+func (x PendingTransactionStatus) Enum() *PendingTransactionStatus {
+	p := new(PendingTransactionStatus)
+	*p = x
+	return p
+}
+
+func (x PendingTransactionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PendingTransactionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[2].Descriptor()
+}
+
+func (PendingTransactionStatus) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[2]
+}
+
+func (x PendingTransactionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PendingTransactionStatus.Descriptor instead.
+func (PendingTransactionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{2}
+}
+
 // A reference to a block on an EVM-compatible blockchain. This is used to identify a block without storing the full block data.
 type BlockRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -215,9 +365,17 @@ type BlockHeader struct {
 	// Array of uncle (ommer) block hashes included in this block. Uncle blocks are valid blocks mined at the same height but not included in the canonical chain. Ethereum rewarded up to 2 uncles per block to reduce mining centralization. Always empty post-merge. Some indexers store as JSON array
 	Uncles [][]byte `protobuf:"bytes,34,rep,name=uncles,proto3" json:"uncles,omitempty"`
 	// Root hash of the requests trie containing consensus layer requests (EIP-7685). Introduced to support validator deposits, withdrawals, and consolidations. Part of block hash calculation after Prague/Electra upgrade. Essential for consensus layer to execution layer communication
-	RequestsHash  []byte `protobuf:"bytes,35,opt,name=requestsHash,proto3,oneof" json:"requestsHash,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	RequestsHash []byte `protobuf:"bytes,35,opt,name=requestsHash,proto3,oneof" json:"requestsHash,omitempty"`
+	// JSON-RPC fields not modelled above (new fork fields or chain-specific extras such as zkSync l1BatchNumber), keyed by field name with the raw JSON text of each value. Captured when converting from JSON-RPC and emitted again when converting back, so unknown fields survive a round trip
+	Extensions map[string]string `protobuf:"bytes,36,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Same as baseFeePerGas, as a 32-byte big-endian unsigned integer. Converters set both; baseFeePerGas is kept until consumers have migrated to this field
+	BaseFeePerGasU256 []byte `protobuf:"bytes,37,opt,name=baseFeePerGasU256,proto3,oneof" json:"baseFeePerGasU256,omitempty"`
+	// Same as difficulty, as a 32-byte big-endian unsigned integer. Converters set both; difficulty is kept until consumers have migrated to this field
+	DifficultyU256 []byte `protobuf:"bytes,38,opt,name=difficultyU256,proto3,oneof" json:"difficultyU256,omitempty"`
+	// Same as totalDifficulty, as a 32-byte big-endian unsigned integer. Converters set both; totalDifficulty is kept until consumers have migrated to this field
+	TotalDifficultyU256 []byte `protobuf:"bytes,39,opt,name=totalDifficultyU256,proto3,oneof" json:"totalDifficultyU256,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *BlockHeader) Reset() {
@@ -495,6 +653,34 @@ func (x *BlockHeader) GetRequestsHash() []byte {
 	return nil
 }
 
+func (x *BlockHeader) GetExtensions() map[string]string {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetBaseFeePerGasU256() []byte {
+	if x != nil {
+		return x.BaseFeePerGasU256
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetDifficultyU256() []byte {
+	if x != nil {
+		return x.DifficultyU256
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetTotalDifficultyU256() []byte {
+	if x != nil {
+		return x.TotalDifficultyU256
+	}
+	return nil
+}
+
 // A full block with its header, transactions, and logs.
 type Block struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -656,7 +842,7 @@ type Transaction struct {
 	Value string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
 	// Arbitrary byte data payload. For EOA transfers, typically empty. For contract calls, contains ABI-encoded function selector and parameters. For contract creation, contains the contract deployment bytecode + constructor parameters. Limited by block gas limit. Critical for all smart contract interactions
 	Input []byte `protobuf:"bytes,6,opt,name=input,proto3" json:"input,omitempty"`
-	// Transaction type identifier following EIP-2718. 0=Legacy (pre-EIP-1559), 1=EIP-2930 (access list), 2=EIP-1559 (dynamic fees), 3=EIP-4844 (blob transactions), 4=EIP-7702 (set code). L2s may define custom types (e.g., Arbitrum 100-120, Optimism 126). Determines transaction format and features available
+	// Transaction type identifier following EIP-2718. 0=Legacy (pre-EIP-1559), 1=EIP-2930 (access list), 2=EIP-1559 (dynamic fees), 3=EIP-4844 (blob transactions), 4=EIP-7702 (set code). L2s define custom types (Arbitrum 100-106, Optimism 126). Known values are listed in TransactionType; kept as uint32 so types not yet listed still round trip. Determines transaction format and features available
 	Type uint32 `protobuf:"varint,7,opt,name=type,proto3" json:"type,omitempty"`
 	// Maximum gas units this transaction can consume. Set by sender as a cap on computation/storage operations. Unused gas is refunded. Must be >= 21000 (base transaction cost) plus additional for data/computation. If execution exceeds this limit, transaction reverts but gas is still paid
 	GasLimit uint64 `protobuf:"varint,8,opt,name=gasLimit,proto3" json:"gasLimit,omitempty"`
@@ -746,8 +932,30 @@ type Transaction struct {
 	IsSystemTx *bool `protobuf:"varint,50,opt,name=isSystemTx,proto3,oneof" json:"isSystemTx,omitempty"`
 	// Version of the deposit receipt for this transaction. Base chain specific field present when the transaction is a deposit transaction from L1 to L2
 	DepositReceiptVersion *string `protobuf:"bytes,51,opt,name=depositReceiptVersion,proto3,oneof" json:"depositReceiptVersion,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// OP stack (Optimism, Base and other OP chains) fields: deposit transaction flags and the L1 data fee breakdown. Set for deposit transactions (type 0x7e) and transactions carrying L1 fee fields
+	Optimism *OptimismTransactionExtension `protobuf:"bytes,52,opt,name=optimism,proto3,oneof" json:"optimism,omitempty"`
+	// Arbitrum fields: retryable ticket parameters. Set for Arbitrum transaction types (0x64-0x6a) and transactions carrying retryable fields
+	Arbitrum *ArbitrumTransactionExtension `protobuf:"bytes,53,opt,name=arbitrum,proto3,oneof" json:"arbitrum,omitempty"`
+	// Celo fields: fee currency and gateway fee. Set for Celo transaction types (0x7a-0x7c) and transactions paying fees in an ERC-20 token. Celo is an OP stack chain, so optimism may be set as well
+	Celo *CeloTransactionExtension `protobuf:"bytes,54,opt,name=celo,proto3,oneof" json:"celo,omitempty"`
+	// zkSync Era fields: the L1 batch the transaction was committed in. Set for zkSync transaction types (0x71, 0xff) and transactions carrying batch fields
+	ZkSync *ZkSyncTransactionExtension `protobuf:"bytes,55,opt,name=zkSync,proto3,oneof" json:"zkSync,omitempty"`
+	// Polygon PoS fields. Only set when converting with the Polygon dialect, since Bor transactions carry no distinguishing JSON-RPC fields
+	Polygon *PolygonTransactionExtension `protobuf:"bytes,56,opt,name=polygon,proto3,oneof" json:"polygon,omitempty"`
+	// Same as value, as a 32-byte big-endian unsigned integer. Converters set both; value is kept until consumers have migrated to this field
+	ValueU256 []byte `protobuf:"bytes,57,opt,name=valueU256,proto3,oneof" json:"valueU256,omitempty"`
+	// Same as gasPrice, as a 32-byte big-endian unsigned integer. Converters set both; gasPrice is kept until consumers have migrated to this field
+	GasPriceU256 []byte `protobuf:"bytes,58,opt,name=gasPriceU256,proto3,oneof" json:"gasPriceU256,omitempty"`
+	// Same as maxFeePerGas, as a 32-byte big-endian unsigned integer. Converters set both; maxFeePerGas is kept until consumers have migrated to this field
+	MaxFeePerGasU256 []byte `protobuf:"bytes,59,opt,name=maxFeePerGasU256,proto3,oneof" json:"maxFeePerGasU256,omitempty"`
+	// Same as maxPriorityFeePerGas, as a 32-byte big-endian unsigned integer. Converters set both; maxPriorityFeePerGas is kept until consumers have migrated to this field
+	MaxPriorityFeePerGasU256 []byte `protobuf:"bytes,60,opt,name=maxPriorityFeePerGasU256,proto3,oneof" json:"maxPriorityFeePerGasU256,omitempty"`
+	// Same as effectiveGasPrice, as a 32-byte big-endian unsigned integer. Converters set both; effectiveGasPrice is kept until consumers have migrated to this field
+	EffectiveGasPriceU256 []byte `protobuf:"bytes,61,opt,name=effectiveGasPriceU256,proto3,oneof" json:"effectiveGasPriceU256,omitempty"`
+	// Same as maxFeePerBlobGas, as a 32-byte big-endian unsigned integer. Converters set both; maxFeePerBlobGas is kept until consumers have migrated to this field
+	MaxFeePerBlobGasU256 []byte `protobuf:"bytes,62,opt,name=maxFeePerBlobGasU256,proto3,oneof" json:"maxFeePerBlobGasU256,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *Transaction) Reset() {
@@ -1137,6 +1345,83 @@ func (x *Transaction) GetDepositReceiptVersion() string {
 	return ""
 }
 
+func (x *Transaction) GetOptimism() *OptimismTransactionExtension {
+	if x != nil {
+		return x.Optimism
+	}
+	return nil
+}
+
+func (x *Transaction) GetArbitrum() *ArbitrumTransactionExtension {
+	if x != nil {
+		return x.Arbitrum
+	}
+	return nil
+}
+
+func (x *Transaction) GetCelo() *CeloTransactionExtension {
+	if x != nil {
+		return x.Celo
+	}
+	return nil
+}
+
+func (x *Transaction) GetZkSync() *ZkSyncTransactionExtension {
+	if x != nil {
+		return x.ZkSync
+	}
+	return nil
+}
+
+func (x *Transaction) GetPolygon() *PolygonTransactionExtension {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *Transaction) GetValueU256() []byte {
+	if x != nil {
+		return x.ValueU256
+	}
+	return nil
+}
+
+func (x *Transaction) GetGasPriceU256() []byte {
+	if x != nil {
+		return x.GasPriceU256
+	}
+	return nil
+}
+
+func (x *Transaction) GetMaxFeePerGasU256() []byte {
+	if x != nil {
+		return x.MaxFeePerGasU256
+	}
+	return nil
+}
+
+func (x *Transaction) GetMaxPriorityFeePerGasU256() []byte {
+	if x != nil {
+		return x.MaxPriorityFeePerGasU256
+	}
+	return nil
+}
+
+func (x *Transaction) GetEffectiveGasPriceU256() []byte {
+	if x != nil {
+		return x.EffectiveGasPriceU256
+	}
+	return nil
+}
+
+func (x *Transaction) GetMaxFeePerBlobGasU256() []byte {
+	if x != nil {
+		return x.MaxFeePerBlobGasU256
+	}
+	return nil
+}
+
 // Represents an entry in an EIP-2930 access list. Pre-declares addresses and storage slots that will be accessed during transaction execution, enabling gas savings through reduced cold access costs
 type AccessListItem struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1213,8 +1498,12 @@ type Log struct {
 	LogIndex uint32 `protobuf:"varint,8,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
 	// Unix timestamp of the block containing this log. Denormalized from block data for query convenience. Enables time-based filtering and analysis without joining block data. Same timestamp for all logs in a block
 	BlockTimestamp *uint64 `protobuf:"varint,9,opt,name=blockTimestamp,proto3,oneof" json:"blockTimestamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// JSON-RPC fields not modelled above, keyed by field name with the raw JSON text of each value. Captured when converting from JSON-RPC and emitted again when converting back, so unknown fields survive a round trip
+	Extensions map[string]string `protobuf:"bytes,10,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// True when a chain reorganization retracted this log after it was delivered. Retractions carry the same fields as the original log so consumers can locate and undo its effects. Always false for logs returned by range queries such as eth_getLogs; set on subscription and filter-change deliveries
+	Removed       bool `protobuf:"varint,11,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Log) Reset() {
@@ -1310,6 +1599,20 @@ func (x *Log) GetBlockTimestamp() uint64 {
 	return 0
 }
 
+func (x *Log) GetExtensions() map[string]string {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+func (x *Log) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
 // Represents an authorization item for EIP-7702 Set Code transactions. Allows an EOA to authorize setting specific contract code to their account, enabling smart contract functionality without deployment
 type AuthorizationListItem struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1494,7 +1797,7 @@ type Receipt struct {
 	BlockHash []byte `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	// Transaction index
 	TransactionIndex uint32 `protobuf:"varint,4,opt,name=transactionIndex,proto3" json:"transactionIndex,omitempty"`
-	// Transaction type (EIP-2718). 0=Legacy, 1=EIP-2930, 2=EIP-1559, 3=EIP-4844, 4=EIP-7702. L2s may have custom types. Denormalized from transaction for easier filtering and processing. Determines receipt format
+	// Transaction type (EIP-2718). 0=Legacy, 1=EIP-2930, 2=EIP-1559, 3=EIP-4844, 4=EIP-7702. L2s may have custom types; known values are listed in TransactionType. Denormalized from transaction for easier filtering and processing. Determines receipt format
 	Type uint32 `protobuf:"varint,5,opt,name=type,proto3" json:"type,omitempty"`
 	// The 20-byte address of the account that created and signed the transaction. Denormalized from transaction for query convenience.
 	From []byte `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
@@ -1548,8 +1851,24 @@ type Receipt struct {
 	L1BlobBaseFee *string `protobuf:"bytes,30,opt,name=l1BlobBaseFee,proto3,oneof" json:"l1BlobBaseFee,omitempty"`
 	// Scalar for L1 blob base fee calculations on L2s. Similar to l1BaseFeeScalar but for blob data costs. Adjustable by L2 operators. Used after EIP-4844 activation to calculate data availability costs via blobs
 	L1BlobBaseFeeScalar *uint64 `protobuf:"varint,31,opt,name=l1BlobBaseFeeScalar,proto3,oneof" json:"l1BlobBaseFeeScalar,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// JSON-RPC fields not modelled above (new fork fields or chain-specific extras such as zkSync l1BatchNumber), keyed by field name with the raw JSON text of each value. Captured when converting from JSON-RPC and emitted again when converting back, so unknown fields survive a round trip
+	Extensions map[string]string `protobuf:"bytes,32,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// OP stack fields: the L1 data fee breakdown and deposit nonce. Set for deposit receipts (type 0x7e) and receipts carrying L1 fee fields
+	Optimism *OptimismReceiptExtension `protobuf:"bytes,33,opt,name=optimism,proto3,oneof" json:"optimism,omitempty"`
+	// Arbitrum fields: L1 gas accounting and timeboost. Set for Arbitrum transaction types (0x64-0x6a) and receipts carrying Arbitrum fields
+	Arbitrum *ArbitrumReceiptExtension `protobuf:"bytes,34,opt,name=arbitrum,proto3,oneof" json:"arbitrum,omitempty"`
+	// Celo fields: the gateway fee. Set for Celo transaction types (0x7a-0x7c) and receipts carrying a gateway fee. Celo is an OP stack chain, so optimism may be set as well
+	Celo *CeloReceiptExtension `protobuf:"bytes,35,opt,name=celo,proto3,oneof" json:"celo,omitempty"`
+	// zkSync Era fields: the L1 batch the transaction was committed in. Set for zkSync transaction types (0x71, 0xff) and receipts carrying batch fields
+	ZkSync *ZkSyncReceiptExtension `protobuf:"bytes,36,opt,name=zkSync,proto3,oneof" json:"zkSync,omitempty"`
+	// Same as effectiveGasPrice, as a 32-byte big-endian unsigned integer. Converters set both; effectiveGasPrice is kept until consumers have migrated to this field
+	EffectiveGasPriceU256 []byte `protobuf:"bytes,37,opt,name=effectiveGasPriceU256,proto3,oneof" json:"effectiveGasPriceU256,omitempty"`
+	// Same as blobGasPrice, as a 32-byte big-endian unsigned integer. Converters set both; blobGasPrice is kept until consumers have migrated to this field
+	BlobGasPriceU256 []byte `protobuf:"bytes,38,opt,name=blobGasPriceU256,proto3,oneof" json:"blobGasPriceU256,omitempty"`
+	// Polygon PoS fields. Only set when converting with the Polygon dialect, since Bor receipts carry no distinguishing JSON-RPC fields
+	Polygon       *PolygonReceiptExtension `protobuf:"bytes,39,opt,name=polygon,proto3,oneof" json:"polygon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Receipt) Reset() {
@@ -1799,115 +2118,1994 @@ func (x *Receipt) GetL1BlobBaseFeeScalar() uint64 {
 	return 0
 }
 
-var File_models_proto protoreflect.FileDescriptor
+func (x *Receipt) GetExtensions() map[string]string {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
 
-const file_models_proto_rawDesc = "" +
-	"\n" +
-	"\fmodels.proto\x12\abds.evm\"V\n" +
-	"\bBlockRef\x12\x16\n" +
-	"\x06number\x18\x01 \x01(\x04R\x06number\x12\x12\n" +
-	"\x04hash\x18\x02 \x01(\fR\x04hash\x12\x1e\n" +
-	"\n" +
-	"parentHash\x18\x03 \x01(\fR\n" +
-	"parentHash\"\xa3\f\n" +
-	"\vBlockHeader\x12\x16\n" +
-	"\x06number\x18\x01 \x01(\x04R\x06number\x12\x1c\n" +
-	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\x12\x1a\n" +
-	"\bgasLimit\x18\x03 \x01(\x04R\bgasLimit\x12\x18\n" +
-	"\agasUsed\x18\x04 \x01(\x04R\agasUsed\x12\x12\n" +
-	"\x04size\x18\x05 \x01(\x04R\x04size\x12\x12\n" +
-	"\x04hash\x18\x06 \x01(\fR\x04hash\x12\x1e\n" +
-	"\n" +
-	"parentHash\x18\a \x01(\fR\n" +
-	"parentHash\x12\x1c\n" +
-	"\tstateRoot\x18\b \x01(\fR\tstateRoot\x12*\n" +
-	"\x10transactionsRoot\x18\t \x01(\fR\x10transactionsRoot\x12\"\n" +
-	"\freceiptsRoot\x18\n" +
-	" \x01(\fR\freceiptsRoot\x12\x1e\n" +
-	"\n" +
-	"sha3Uncles\x18\v \x01(\fR\n" +
-	"sha3Uncles\x12\x14\n" +
-	"\x05miner\x18\f \x01(\fR\x05miner\x12\x1c\n" +
-	"\tlogsBloom\x18\r \x01(\fR\tlogsBloom\x12\x1c\n" +
-	"\textraData\x18\x0e \x01(\fR\textraData\x12\x19\n" +
-	"\x05nonce\x18\x0f \x01(\x04H\x00R\x05nonce\x88\x01\x01\x12%\n" +
-	"\vblobGasUsed\x18\x10 \x01(\x04H\x01R\vblobGasUsed\x88\x01\x01\x12)\n" +
-	"\rexcessBlobGas\x18\x11 \x01(\x04H\x02R\rexcessBlobGas\x88\x01\x01\x12)\n" +
-	"\rl1BlockNumber\x18\x12 \x01(\x04H\x03R\rl1BlockNumber\x88\x01\x01\x12\x19\n" +
-	"\x05epoch\x18\x13 \x01(\x04H\x04R\x05epoch\x88\x01\x01\x12\x17\n" +
-	"\x04slot\x18\x14 \x01(\x04H\x05R\x04slot\x88\x01\x01\x12)\n" +
-	"\rproposerIndex\x18\x15 \x01(\x04H\x06R\rproposerIndex\x88\x01\x01\x12!\n" +
-	"\tsendCount\x18\x16 \x01(\x04H\aR\tsendCount\x88\x01\x01\x12/\n" +
-	"\x10transactionCount\x18\x17 \x01(\rH\bR\x10transactionCount\x88\x01\x01\x12\x1d\n" +
-	"\amixHash\x18\x18 \x01(\fH\tR\amixHash\x88\x01\x01\x129\n" +
-	"\x15parentBeaconBlockRoot\x18\x19 \x01(\fH\n" +
-	"R\x15parentBeaconBlockRoot\x88\x01\x01\x12-\n" +
-	"\x0fwithdrawalsRoot\x18\x1a \x01(\fH\vR\x0fwithdrawalsRoot\x88\x01\x01\x12\x1f\n" +
-	"\bsendRoot\x18\x1b \x01(\fH\fR\bsendRoot\x88\x01\x01\x12)\n" +
-	"\rbaseFeePerGas\x18\x1c \x01(\tH\rR\rbaseFeePerGas\x88\x01\x01\x12#\n" +
-	"\n" +
-	"difficulty\x18\x1d \x01(\tH\x0eR\n" +
-	"difficulty\x88\x01\x01\x12-\n" +
-	"\x0ftotalDifficulty\x18\x1e \x01(\tH\x0fR\x0ftotalDifficulty\x88\x01\x01\x121\n" +
-	"\x11proposerPublicKey\x18\x1f \x01(\tH\x10R\x11proposerPublicKey\x88\x01\x01\x12%\n" +
-	"\vwithdrawals\x18  \x01(\fH\x11R\vwithdrawals\x88\x01\x01\x12'\n" +
-	"\fcanonicalRlp\x18! \x01(\fH\x12R\fcanonicalRlp\x88\x01\x01\x12\x16\n" +
-	"\x06uncles\x18\" \x03(\fR\x06uncles\x12'\n" +
-	"\frequestsHash\x18# \x01(\fH\x13R\frequestsHash\x88\x01\x01B\b\n" +
-	"\x06_nonceB\x0e\n" +
-	"\f_blobGasUsedB\x10\n" +
-	"\x0e_excessBlobGasB\x10\n" +
-	"\x0e_l1BlockNumberB\b\n" +
-	"\x06_epochB\a\n" +
-	"\x05_slotB\x10\n" +
-	"\x0e_proposerIndexB\f\n" +
-	"\n" +
-	"_sendCountB\x13\n" +
-	"\x11_transactionCountB\n" +
-	"\n" +
-	"\b_mixHashB\x18\n" +
-	"\x16_parentBeaconBlockRootB\x12\n" +
-	"\x10_withdrawalsRootB\v\n" +
-	"\t_sendRootB\x10\n" +
-	"\x0e_baseFeePerGasB\r\n" +
-	"\v_difficultyB\x12\n" +
-	"\x10_totalDifficultyB\x14\n" +
-	"\x12_proposerPublicKeyB\x0e\n" +
-	"\f_withdrawalsB\x0f\n" +
-	"\r_canonicalRlpB\x0f\n" +
-	"\r_requestsHash\"\xfe\x01\n" +
-	"\x05Block\x12,\n" +
-	"\x06header\x18\x01 \x01(\v2\x14.bds.evm.BlockHeaderR\x06header\x12,\n" +
-	"\x11transactionHashes\x18\x02 \x03(\fR\x11transactionHashes\x12@\n" +
-	"\x10fullTransactions\x18\x03 \x03(\v2\x14.bds.evm.TransactionR\x10fullTransactions\x12 \n" +
-	"\x04logs\x18\x04 \x03(\v2\f.bds.evm.LogR\x04logs\x125\n" +
-	"\vwithdrawals\x18\x05 \x03(\v2\x13.bds.evm.WithdrawalR\vwithdrawals\"\x8f\x01\n" +
-	"\x0eTransactionRef\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.bds.evm.BlockRefR\x05block\x12*\n" +
-	"\x10transactionIndex\x18\x02 \x01(\rR\x10transactionIndex\x12(\n" +
-	"\x0ftransactionHash\x18\x03 \x01(\fR\x0ftransactionHash\"\xef\x13\n" +
-	"\vTransaction\x12\x12\n" +
-	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x14\n" +
-	"\x05nonce\x18\x02 \x01(\x04R\x05nonce\x12\x12\n" +
-	"\x04from\x18\x03 \x01(\fR\x04from\x12\x13\n" +
-	"\x02to\x18\x04 \x01(\fH\x00R\x02to\x88\x01\x01\x12\x14\n" +
-	"\x05value\x18\x05 \x01(\tR\x05value\x12\x14\n" +
-	"\x05input\x18\x06 \x01(\fR\x05input\x12\x12\n" +
-	"\x04type\x18\a \x01(\rR\x04type\x12\x1a\n" +
-	"\bgasLimit\x18\b \x01(\x04R\bgasLimit\x12\x1f\n" +
-	"\bgasPrice\x18\t \x01(\tH\x01R\bgasPrice\x88\x01\x01\x12'\n" +
-	"\fmaxFeePerGas\x18\n" +
-	" \x01(\tH\x02R\fmaxFeePerGas\x88\x01\x01\x127\n" +
-	"\x14maxPriorityFeePerGas\x18\v \x01(\tH\x03R\x14maxPriorityFeePerGas\x88\x01\x01\x12\x1d\n" +
-	"\agasUsed\x18\f \x01(\x04H\x04R\agasUsed\x88\x01\x01\x121\n" +
-	"\x11effectiveGasPrice\x18\r \x01(\tH\x05R\x11effectiveGasPrice\x88\x01\x01\x12\f\n" +
-	"\x01r\x18\x0e \x01(\fR\x01r\x12\f\n" +
-	"\x01s\x18\x0f \x01(\fR\x01s\x12\x11\n" +
-	"\x01v\x18\x10 \x01(\fH\x06R\x01v\x88\x01\x01\x12\x1d\n" +
-	"\ayParity\x18\x11 \x01(\rH\aR\ayParity\x88\x01\x01\x12\x1d\n" +
-	"\achainId\x18\x12 \x01(\x04H\bR\achainId\x88\x01\x01\x12%\n" +
-	"\vblockNumber\x18\x13 \x01(\x04H\tR\vblockNumber\x88\x01\x01\x12!\n" +
-	"\tblockHash\x18\x14 \x01(\fH\n" +
+func (x *Receipt) GetOptimism() *OptimismReceiptExtension {
+	if x != nil {
+		return x.Optimism
+	}
+	return nil
+}
+
+func (x *Receipt) GetArbitrum() *ArbitrumReceiptExtension {
+	if x != nil {
+		return x.Arbitrum
+	}
+	return nil
+}
+
+func (x *Receipt) GetCelo() *CeloReceiptExtension {
+	if x != nil {
+		return x.Celo
+	}
+	return nil
+}
+
+func (x *Receipt) GetZkSync() *ZkSyncReceiptExtension {
+	if x != nil {
+		return x.ZkSync
+	}
+	return nil
+}
+
+func (x *Receipt) GetEffectiveGasPriceU256() []byte {
+	if x != nil {
+		return x.EffectiveGasPriceU256
+	}
+	return nil
+}
+
+func (x *Receipt) GetBlobGasPriceU256() []byte {
+	if x != nil {
+		return x.BlobGasPriceU256
+	}
+	return nil
+}
+
+func (x *Receipt) GetPolygon() *PolygonReceiptExtension {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+// OP stack specific transaction fields. Replaces the flat l1Fee..l1BlobBaseFeeScalar, isSystemTx and depositReceiptVersion fields of Transaction
+type OptimismTransactionExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fee paid for L1 data availability, in wei. Calculated by the L1 fee formula of the active fork from the L1 base fee, blob base fee and scalars
+	L1Fee *string `protobuf:"bytes,1,opt,name=l1Fee,proto3,oneof" json:"l1Fee,omitempty"`
+	// L1 base fee used to calculate the L1 fee, in wei
+	L1GasPrice *string `protobuf:"bytes,2,opt,name=l1GasPrice,proto3,oneof" json:"l1GasPrice,omitempty"`
+	// Estimated L1 gas used by the transaction's compressed data
+	L1GasUsed *string `protobuf:"bytes,3,opt,name=l1GasUsed,proto3,oneof" json:"l1GasUsed,omitempty"`
+	// Pre-Ecotone scalar multiplier of the L1 fee, e.g. 0.684
+	L1FeeScalar *float64 `protobuf:"fixed64,4,opt,name=l1FeeScalar,proto3,oneof" json:"l1FeeScalar,omitempty"`
+	// L1 blob base fee used to calculate the L1 fee since Ecotone, in wei
+	L1BlobBaseFee *string `protobuf:"bytes,5,opt,name=l1BlobBaseFee,proto3,oneof" json:"l1BlobBaseFee,omitempty"`
+	// Scalar applied to the L1 blob base fee since Ecotone
+	L1BlobBaseFeeScalar *uint64 `protobuf:"varint,6,opt,name=l1BlobBaseFeeScalar,proto3,oneof" json:"l1BlobBaseFeeScalar,omitempty"`
+	// Whether the deposit is a system transaction (pre-Regolith deposits that do not consume gas)
+	IsSystemTx *bool `protobuf:"varint,7,opt,name=isSystemTx,proto3,oneof" json:"isSystemTx,omitempty"`
+	// Version of the deposit receipt, present on deposit transactions since Canyon
+	DepositReceiptVersion *string `protobuf:"bytes,8,opt,name=depositReceiptVersion,proto3,oneof" json:"depositReceiptVersion,omitempty"`
+	// Unique identifier of a deposit transaction, derived from the L1 block hash and the log index of the deposit event (or the upgrade or L1 attributes sequence). Only set for deposit transactions
+	SourceHash []byte `protobuf:"bytes,9,opt,name=sourceHash,proto3,oneof" json:"sourceHash,omitempty"`
+	// ETH minted on L2 by a deposit transaction and credited to its sender before execution, in wei. Unset for deposits that mint nothing, such as L1 attributes deposits
+	Mint *string `protobuf:"bytes,10,opt,name=mint,proto3,oneof" json:"mint,omitempty"`
+	// Same as l1Fee, as a 32-byte big-endian unsigned integer. Converters set both; l1Fee is kept until consumers have migrated to this field
+	L1FeeU256 []byte `protobuf:"bytes,11,opt,name=l1FeeU256,proto3,oneof" json:"l1FeeU256,omitempty"`
+	// Same as l1GasPrice, as a 32-byte big-endian unsigned integer. Converters set both; l1GasPrice is kept until consumers have migrated to this field
+	L1GasPriceU256 []byte `protobuf:"bytes,12,opt,name=l1GasPriceU256,proto3,oneof" json:"l1GasPriceU256,omitempty"`
+	// Same as l1BlobBaseFee, as a 32-byte big-endian unsigned integer. Converters set both; l1BlobBaseFee is kept until consumers have migrated to this field
+	L1BlobBaseFeeU256 []byte `protobuf:"bytes,13,opt,name=l1BlobBaseFeeU256,proto3,oneof" json:"l1BlobBaseFeeU256,omitempty"`
+	// Same as mint, as a 32-byte big-endian unsigned integer. Converters set both; mint is kept until consumers have migrated to this field
+	MintU256      []byte `protobuf:"bytes,14,opt,name=mintU256,proto3,oneof" json:"mintU256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimismTransactionExtension) Reset() {
+	*x = OptimismTransactionExtension{}
+	mi := &file_models_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimismTransactionExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimismTransactionExtension) ProtoMessage() {}
+
+func (x *OptimismTransactionExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimismTransactionExtension.ProtoReflect.Descriptor instead.
+func (*OptimismTransactionExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OptimismTransactionExtension) GetL1Fee() string {
+	if x != nil && x.L1Fee != nil {
+		return *x.L1Fee
+	}
+	return ""
+}
+
+func (x *OptimismTransactionExtension) GetL1GasPrice() string {
+	if x != nil && x.L1GasPrice != nil {
+		return *x.L1GasPrice
+	}
+	return ""
+}
+
+func (x *OptimismTransactionExtension) GetL1GasUsed() string {
+	if x != nil && x.L1GasUsed != nil {
+		return *x.L1GasUsed
+	}
+	return ""
+}
+
+func (x *OptimismTransactionExtension) GetL1FeeScalar() float64 {
+	if x != nil && x.L1FeeScalar != nil {
+		return *x.L1FeeScalar
+	}
+	return 0
+}
+
+func (x *OptimismTransactionExtension) GetL1BlobBaseFee() string {
+	if x != nil && x.L1BlobBaseFee != nil {
+		return *x.L1BlobBaseFee
+	}
+	return ""
+}
+
+func (x *OptimismTransactionExtension) GetL1BlobBaseFeeScalar() uint64 {
+	if x != nil && x.L1BlobBaseFeeScalar != nil {
+		return *x.L1BlobBaseFeeScalar
+	}
+	return 0
+}
+
+func (x *OptimismTransactionExtension) GetIsSystemTx() bool {
+	if x != nil && x.IsSystemTx != nil {
+		return *x.IsSystemTx
+	}
+	return false
+}
+
+func (x *OptimismTransactionExtension) GetDepositReceiptVersion() string {
+	if x != nil && x.DepositReceiptVersion != nil {
+		return *x.DepositReceiptVersion
+	}
+	return ""
+}
+
+func (x *OptimismTransactionExtension) GetSourceHash() []byte {
+	if x != nil {
+		return x.SourceHash
+	}
+	return nil
+}
+
+func (x *OptimismTransactionExtension) GetMint() string {
+	if x != nil && x.Mint != nil {
+		return *x.Mint
+	}
+	return ""
+}
+
+func (x *OptimismTransactionExtension) GetL1FeeU256() []byte {
+	if x != nil {
+		return x.L1FeeU256
+	}
+	return nil
+}
+
+func (x *OptimismTransactionExtension) GetL1GasPriceU256() []byte {
+	if x != nil {
+		return x.L1GasPriceU256
+	}
+	return nil
+}
+
+func (x *OptimismTransactionExtension) GetL1BlobBaseFeeU256() []byte {
+	if x != nil {
+		return x.L1BlobBaseFeeU256
+	}
+	return nil
+}
+
+func (x *OptimismTransactionExtension) GetMintU256() []byte {
+	if x != nil {
+		return x.MintU256
+	}
+	return nil
+}
+
+// Arbitrum specific transaction fields, mostly the parameters of retryable tickets (L1-to-L2 messages). Replaces the flat beneficiary..ticketId fields of Transaction
+type ArbitrumTransactionExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address receiving any excess deposit when the retryable ticket is created
+	Beneficiary []byte `protobuf:"bytes,1,opt,name=beneficiary,proto3,oneof" json:"beneficiary,omitempty"`
+	// Amount of ETH deposited from L1 to L2, in wei
+	DepositValue *string `protobuf:"bytes,2,opt,name=depositValue,proto3,oneof" json:"depositValue,omitempty"`
+	// L1 base fee at the time the retryable ticket was created, in wei
+	L1BaseFee *string `protobuf:"bytes,3,opt,name=l1BaseFee,proto3,oneof" json:"l1BaseFee,omitempty"`
+	// Maximum fee paid for submitting the retryable ticket, in wei
+	MaxSubmissionFee *string `protobuf:"bytes,4,opt,name=maxSubmissionFee,proto3,oneof" json:"maxSubmissionFee,omitempty"`
+	// Address receiving refunds of unused submission fee and gas
+	RefundTo []byte `protobuf:"bytes,5,opt,name=refundTo,proto3,oneof" json:"refundTo,omitempty"`
+	// Identifier of the L1-to-L2 message request
+	RequestId []byte `protobuf:"bytes,6,opt,name=requestId,proto3,oneof" json:"requestId,omitempty"`
+	// Calldata of the retryable ticket's L2 call
+	RetryData []byte `protobuf:"bytes,7,opt,name=retryData,proto3,oneof" json:"retryData,omitempty"`
+	// Target of the retryable ticket's L2 call
+	RetryTo []byte `protobuf:"bytes,8,opt,name=retryTo,proto3,oneof" json:"retryTo,omitempty"`
+	// ETH value of the retryable ticket's L2 call, in wei
+	RetryValue *string `protobuf:"bytes,9,opt,name=retryValue,proto3,oneof" json:"retryValue,omitempty"`
+	// Maximum refund for unused gas of the redeem, in wei
+	MaxRefund *string `protobuf:"bytes,10,opt,name=maxRefund,proto3,oneof" json:"maxRefund,omitempty"`
+	// Refund of unused submission fee, in wei
+	SubmissionFeeRefund *string `protobuf:"bytes,11,opt,name=submissionFeeRefund,proto3,oneof" json:"submissionFeeRefund,omitempty"`
+	// Identifier of the retryable ticket being redeemed
+	TicketId []byte `protobuf:"bytes,12,opt,name=ticketId,proto3,oneof" json:"ticketId,omitempty"`
+	// Same as depositValue, as a 32-byte big-endian unsigned integer. Converters set both; depositValue is kept until consumers have migrated to this field
+	DepositValueU256 []byte `protobuf:"bytes,13,opt,name=depositValueU256,proto3,oneof" json:"depositValueU256,omitempty"`
+	// Same as l1BaseFee, as a 32-byte big-endian unsigned integer. Converters set both; l1BaseFee is kept until consumers have migrated to this field
+	L1BaseFeeU256 []byte `protobuf:"bytes,14,opt,name=l1BaseFeeU256,proto3,oneof" json:"l1BaseFeeU256,omitempty"`
+	// Same as maxSubmissionFee, as a 32-byte big-endian unsigned integer. Converters set both; maxSubmissionFee is kept until consumers have migrated to this field
+	MaxSubmissionFeeU256 []byte `protobuf:"bytes,15,opt,name=maxSubmissionFeeU256,proto3,oneof" json:"maxSubmissionFeeU256,omitempty"`
+	// Same as retryValue, as a 32-byte big-endian unsigned integer. Converters set both; retryValue is kept until consumers have migrated to this field
+	RetryValueU256 []byte `protobuf:"bytes,16,opt,name=retryValueU256,proto3,oneof" json:"retryValueU256,omitempty"`
+	// Same as maxRefund, as a 32-byte big-endian unsigned integer. Converters set both; maxRefund is kept until consumers have migrated to this field
+	MaxRefundU256 []byte `protobuf:"bytes,17,opt,name=maxRefundU256,proto3,oneof" json:"maxRefundU256,omitempty"`
+	// Same as submissionFeeRefund, as a 32-byte big-endian unsigned integer. Converters set both; submissionFeeRefund is kept until consumers have migrated to this field
+	SubmissionFeeRefundU256 []byte `protobuf:"bytes,18,opt,name=submissionFeeRefundU256,proto3,oneof" json:"submissionFeeRefundU256,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ArbitrumTransactionExtension) Reset() {
+	*x = ArbitrumTransactionExtension{}
+	mi := &file_models_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArbitrumTransactionExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArbitrumTransactionExtension) ProtoMessage() {}
+
+func (x *ArbitrumTransactionExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArbitrumTransactionExtension.ProtoReflect.Descriptor instead.
+func (*ArbitrumTransactionExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ArbitrumTransactionExtension) GetBeneficiary() []byte {
+	if x != nil {
+		return x.Beneficiary
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetDepositValue() string {
+	if x != nil && x.DepositValue != nil {
+		return *x.DepositValue
+	}
+	return ""
+}
+
+func (x *ArbitrumTransactionExtension) GetL1BaseFee() string {
+	if x != nil && x.L1BaseFee != nil {
+		return *x.L1BaseFee
+	}
+	return ""
+}
+
+func (x *ArbitrumTransactionExtension) GetMaxSubmissionFee() string {
+	if x != nil && x.MaxSubmissionFee != nil {
+		return *x.MaxSubmissionFee
+	}
+	return ""
+}
+
+func (x *ArbitrumTransactionExtension) GetRefundTo() []byte {
+	if x != nil {
+		return x.RefundTo
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetRetryData() []byte {
+	if x != nil {
+		return x.RetryData
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetRetryTo() []byte {
+	if x != nil {
+		return x.RetryTo
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetRetryValue() string {
+	if x != nil && x.RetryValue != nil {
+		return *x.RetryValue
+	}
+	return ""
+}
+
+func (x *ArbitrumTransactionExtension) GetMaxRefund() string {
+	if x != nil && x.MaxRefund != nil {
+		return *x.MaxRefund
+	}
+	return ""
+}
+
+func (x *ArbitrumTransactionExtension) GetSubmissionFeeRefund() string {
+	if x != nil && x.SubmissionFeeRefund != nil {
+		return *x.SubmissionFeeRefund
+	}
+	return ""
+}
+
+func (x *ArbitrumTransactionExtension) GetTicketId() []byte {
+	if x != nil {
+		return x.TicketId
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetDepositValueU256() []byte {
+	if x != nil {
+		return x.DepositValueU256
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetL1BaseFeeU256() []byte {
+	if x != nil {
+		return x.L1BaseFeeU256
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetMaxSubmissionFeeU256() []byte {
+	if x != nil {
+		return x.MaxSubmissionFeeU256
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetRetryValueU256() []byte {
+	if x != nil {
+		return x.RetryValueU256
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetMaxRefundU256() []byte {
+	if x != nil {
+		return x.MaxRefundU256
+	}
+	return nil
+}
+
+func (x *ArbitrumTransactionExtension) GetSubmissionFeeRefundU256() []byte {
+	if x != nil {
+		return x.SubmissionFeeRefundU256
+	}
+	return nil
+}
+
+// Celo specific transaction fields. Replaces the flat feeCurrency, gatewayFee and gatewayFeeRecipient fields of Transaction
+type CeloTransactionExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address of the ERC-20 token (or its adapter) the fees were paid in; unset when paid in CELO
+	FeeCurrency []byte `protobuf:"bytes,1,opt,name=feeCurrency,proto3,oneof" json:"feeCurrency,omitempty"`
+	// Fee paid to the gateway fee recipient, in wei of the fee currency (pre-L2 Celo only)
+	GatewayFee *string `protobuf:"bytes,2,opt,name=gatewayFee,proto3,oneof" json:"gatewayFee,omitempty"`
+	// Address receiving the gateway fee (pre-L2 Celo only)
+	GatewayFeeRecipient []byte `protobuf:"bytes,3,opt,name=gatewayFeeRecipient,proto3,oneof" json:"gatewayFeeRecipient,omitempty"`
+	// Same as gatewayFee, as a 32-byte big-endian unsigned integer. Converters set both; gatewayFee is kept until consumers have migrated to this field
+	GatewayFeeU256 []byte `protobuf:"bytes,4,opt,name=gatewayFeeU256,proto3,oneof" json:"gatewayFeeU256,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CeloTransactionExtension) Reset() {
+	*x = CeloTransactionExtension{}
+	mi := &file_models_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CeloTransactionExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeloTransactionExtension) ProtoMessage() {}
+
+func (x *CeloTransactionExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeloTransactionExtension.ProtoReflect.Descriptor instead.
+func (*CeloTransactionExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CeloTransactionExtension) GetFeeCurrency() []byte {
+	if x != nil {
+		return x.FeeCurrency
+	}
+	return nil
+}
+
+func (x *CeloTransactionExtension) GetGatewayFee() string {
+	if x != nil && x.GatewayFee != nil {
+		return *x.GatewayFee
+	}
+	return ""
+}
+
+func (x *CeloTransactionExtension) GetGatewayFeeRecipient() []byte {
+	if x != nil {
+		return x.GatewayFeeRecipient
+	}
+	return nil
+}
+
+func (x *CeloTransactionExtension) GetGatewayFeeU256() []byte {
+	if x != nil {
+		return x.GatewayFeeU256
+	}
+	return nil
+}
+
+// zkSync Era specific transaction fields
+type ZkSyncTransactionExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of the L1 batch the transaction was committed in; unset until the batch is sealed
+	L1BatchNumber *uint64 `protobuf:"varint,1,opt,name=l1BatchNumber,proto3,oneof" json:"l1BatchNumber,omitempty"`
+	// Index of the transaction within its L1 batch
+	L1BatchTxIndex *uint64 `protobuf:"varint,2,opt,name=l1BatchTxIndex,proto3,oneof" json:"l1BatchTxIndex,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ZkSyncTransactionExtension) Reset() {
+	*x = ZkSyncTransactionExtension{}
+	mi := &file_models_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZkSyncTransactionExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZkSyncTransactionExtension) ProtoMessage() {}
+
+func (x *ZkSyncTransactionExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZkSyncTransactionExtension.ProtoReflect.Descriptor instead.
+func (*ZkSyncTransactionExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ZkSyncTransactionExtension) GetL1BatchNumber() uint64 {
+	if x != nil && x.L1BatchNumber != nil {
+		return *x.L1BatchNumber
+	}
+	return 0
+}
+
+func (x *ZkSyncTransactionExtension) GetL1BatchTxIndex() uint64 {
+	if x != nil && x.L1BatchTxIndex != nil {
+		return *x.L1BatchTxIndex
+	}
+	return 0
+}
+
+// Polygon PoS specific transaction fields
+type PolygonTransactionExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether this is a Bor state-sync transaction, the synthetic zero-address transaction that carries the block's state-sync events from Ethereum
+	StateSync     bool `protobuf:"varint,1,opt,name=stateSync,proto3" json:"stateSync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolygonTransactionExtension) Reset() {
+	*x = PolygonTransactionExtension{}
+	mi := &file_models_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolygonTransactionExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolygonTransactionExtension) ProtoMessage() {}
+
+func (x *PolygonTransactionExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolygonTransactionExtension.ProtoReflect.Descriptor instead.
+func (*PolygonTransactionExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PolygonTransactionExtension) GetStateSync() bool {
+	if x != nil {
+		return x.StateSync
+	}
+	return false
+}
+
+// Polygon PoS specific receipt fields
+type PolygonReceiptExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether this is the receipt of a Bor state-sync transaction. Bor serves it separately from the block's consensus receipts and excludes it from the receipts root
+	StateSync     bool `protobuf:"varint,1,opt,name=stateSync,proto3" json:"stateSync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolygonReceiptExtension) Reset() {
+	*x = PolygonReceiptExtension{}
+	mi := &file_models_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolygonReceiptExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolygonReceiptExtension) ProtoMessage() {}
+
+func (x *PolygonReceiptExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolygonReceiptExtension.ProtoReflect.Descriptor instead.
+func (*PolygonReceiptExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PolygonReceiptExtension) GetStateSync() bool {
+	if x != nil {
+		return x.StateSync
+	}
+	return false
+}
+
+// OP stack specific receipt fields. Replaces the flat l1Fee..l1BlobBaseFeeScalar, depositNonce and depositReceiptVersion fields of Receipt
+type OptimismReceiptExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fee paid for L1 data availability, in wei
+	L1Fee *string `protobuf:"bytes,1,opt,name=l1Fee,proto3,oneof" json:"l1Fee,omitempty"`
+	// L1 base fee used to calculate the L1 fee, in wei
+	L1GasPrice *string `protobuf:"bytes,2,opt,name=l1GasPrice,proto3,oneof" json:"l1GasPrice,omitempty"`
+	// Estimated L1 gas used by the transaction's compressed data
+	L1GasUsed *string `protobuf:"bytes,3,opt,name=l1GasUsed,proto3,oneof" json:"l1GasUsed,omitempty"`
+	// Pre-Ecotone scalar multiplier of the L1 fee, e.g. 0.684
+	L1FeeScalar *float64 `protobuf:"fixed64,4,opt,name=l1FeeScalar,proto3,oneof" json:"l1FeeScalar,omitempty"`
+	// Scalar applied to the L1 base fee since Ecotone
+	L1BaseFeeScalar *uint64 `protobuf:"varint,5,opt,name=l1BaseFeeScalar,proto3,oneof" json:"l1BaseFeeScalar,omitempty"`
+	// L1 blob base fee used to calculate the L1 fee since Ecotone, in wei
+	L1BlobBaseFee *string `protobuf:"bytes,6,opt,name=l1BlobBaseFee,proto3,oneof" json:"l1BlobBaseFee,omitempty"`
+	// Scalar applied to the L1 blob base fee since Ecotone
+	L1BlobBaseFeeScalar *uint64 `protobuf:"varint,7,opt,name=l1BlobBaseFeeScalar,proto3,oneof" json:"l1BlobBaseFeeScalar,omitempty"`
+	// Nonce of the deposit transaction's sender, present on deposit receipts since Regolith
+	DepositNonce *string `protobuf:"bytes,8,opt,name=depositNonce,proto3,oneof" json:"depositNonce,omitempty"`
+	// Version of the deposit receipt, present on deposit receipts since Canyon
+	DepositReceiptVersion *string `protobuf:"bytes,9,opt,name=depositReceiptVersion,proto3,oneof" json:"depositReceiptVersion,omitempty"`
+	// Same as l1Fee, as a 32-byte big-endian unsigned integer. Converters set both; l1Fee is kept until consumers have migrated to this field
+	L1FeeU256 []byte `protobuf:"bytes,10,opt,name=l1FeeU256,proto3,oneof" json:"l1FeeU256,omitempty"`
+	// Same as l1GasPrice, as a 32-byte big-endian unsigned integer. Converters set both; l1GasPrice is kept until consumers have migrated to this field
+	L1GasPriceU256 []byte `protobuf:"bytes,11,opt,name=l1GasPriceU256,proto3,oneof" json:"l1GasPriceU256,omitempty"`
+	// Same as l1BlobBaseFee, as a 32-byte big-endian unsigned integer. Converters set both; l1BlobBaseFee is kept until consumers have migrated to this field
+	L1BlobBaseFeeU256 []byte `protobuf:"bytes,12,opt,name=l1BlobBaseFeeU256,proto3,oneof" json:"l1BlobBaseFeeU256,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OptimismReceiptExtension) Reset() {
+	*x = OptimismReceiptExtension{}
+	mi := &file_models_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimismReceiptExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimismReceiptExtension) ProtoMessage() {}
+
+func (x *OptimismReceiptExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimismReceiptExtension.ProtoReflect.Descriptor instead.
+func (*OptimismReceiptExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OptimismReceiptExtension) GetL1Fee() string {
+	if x != nil && x.L1Fee != nil {
+		return *x.L1Fee
+	}
+	return ""
+}
+
+func (x *OptimismReceiptExtension) GetL1GasPrice() string {
+	if x != nil && x.L1GasPrice != nil {
+		return *x.L1GasPrice
+	}
+	return ""
+}
+
+func (x *OptimismReceiptExtension) GetL1GasUsed() string {
+	if x != nil && x.L1GasUsed != nil {
+		return *x.L1GasUsed
+	}
+	return ""
+}
+
+func (x *OptimismReceiptExtension) GetL1FeeScalar() float64 {
+	if x != nil && x.L1FeeScalar != nil {
+		return *x.L1FeeScalar
+	}
+	return 0
+}
+
+func (x *OptimismReceiptExtension) GetL1BaseFeeScalar() uint64 {
+	if x != nil && x.L1BaseFeeScalar != nil {
+		return *x.L1BaseFeeScalar
+	}
+	return 0
+}
+
+func (x *OptimismReceiptExtension) GetL1BlobBaseFee() string {
+	if x != nil && x.L1BlobBaseFee != nil {
+		return *x.L1BlobBaseFee
+	}
+	return ""
+}
+
+func (x *OptimismReceiptExtension) GetL1BlobBaseFeeScalar() uint64 {
+	if x != nil && x.L1BlobBaseFeeScalar != nil {
+		return *x.L1BlobBaseFeeScalar
+	}
+	return 0
+}
+
+func (x *OptimismReceiptExtension) GetDepositNonce() string {
+	if x != nil && x.DepositNonce != nil {
+		return *x.DepositNonce
+	}
+	return ""
+}
+
+func (x *OptimismReceiptExtension) GetDepositReceiptVersion() string {
+	if x != nil && x.DepositReceiptVersion != nil {
+		return *x.DepositReceiptVersion
+	}
+	return ""
+}
+
+func (x *OptimismReceiptExtension) GetL1FeeU256() []byte {
+	if x != nil {
+		return x.L1FeeU256
+	}
+	return nil
+}
+
+func (x *OptimismReceiptExtension) GetL1GasPriceU256() []byte {
+	if x != nil {
+		return x.L1GasPriceU256
+	}
+	return nil
+}
+
+func (x *OptimismReceiptExtension) GetL1BlobBaseFeeU256() []byte {
+	if x != nil {
+		return x.L1BlobBaseFeeU256
+	}
+	return nil
+}
+
+// Arbitrum specific receipt fields. Replaces the flat gasUsedForL1, l1BlockNumber and timeboosted fields of Receipt
+type ArbitrumReceiptExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Part of gasUsed, in L2 gas units, that paid for posting the transaction's data to L1
+	GasUsedForL1 *uint64 `protobuf:"varint,1,opt,name=gasUsedForL1,proto3,oneof" json:"gasUsedForL1,omitempty"`
+	// Number of the L1 block the sequencer saw when it included the transaction
+	L1BlockNumber *uint64 `protobuf:"varint,2,opt,name=l1BlockNumber,proto3,oneof" json:"l1BlockNumber,omitempty"`
+	// Whether the transaction won the express lane of the timeboost auction
+	Timeboosted   *bool `protobuf:"varint,3,opt,name=timeboosted,proto3,oneof" json:"timeboosted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArbitrumReceiptExtension) Reset() {
+	*x = ArbitrumReceiptExtension{}
+	mi := &file_models_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArbitrumReceiptExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArbitrumReceiptExtension) ProtoMessage() {}
+
+func (x *ArbitrumReceiptExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArbitrumReceiptExtension.ProtoReflect.Descriptor instead.
+func (*ArbitrumReceiptExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ArbitrumReceiptExtension) GetGasUsedForL1() uint64 {
+	if x != nil && x.GasUsedForL1 != nil {
+		return *x.GasUsedForL1
+	}
+	return 0
+}
+
+func (x *ArbitrumReceiptExtension) GetL1BlockNumber() uint64 {
+	if x != nil && x.L1BlockNumber != nil {
+		return *x.L1BlockNumber
+	}
+	return 0
+}
+
+func (x *ArbitrumReceiptExtension) GetTimeboosted() bool {
+	if x != nil && x.Timeboosted != nil {
+		return *x.Timeboosted
+	}
+	return false
+}
+
+// Celo specific receipt fields. Replaces the flat gatewayFee field of Receipt
+type CeloReceiptExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fee paid to the gateway fee recipient, in wei of the fee currency (pre-L2 Celo only)
+	GatewayFee *string `protobuf:"bytes,1,opt,name=gatewayFee,proto3,oneof" json:"gatewayFee,omitempty"`
+	// Same as gatewayFee, as a 32-byte big-endian unsigned integer. Converters set both; gatewayFee is kept until consumers have migrated to this field
+	GatewayFeeU256 []byte `protobuf:"bytes,2,opt,name=gatewayFeeU256,proto3,oneof" json:"gatewayFeeU256,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CeloReceiptExtension) Reset() {
+	*x = CeloReceiptExtension{}
+	mi := &file_models_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CeloReceiptExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeloReceiptExtension) ProtoMessage() {}
+
+func (x *CeloReceiptExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeloReceiptExtension.ProtoReflect.Descriptor instead.
+func (*CeloReceiptExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CeloReceiptExtension) GetGatewayFee() string {
+	if x != nil && x.GatewayFee != nil {
+		return *x.GatewayFee
+	}
+	return ""
+}
+
+func (x *CeloReceiptExtension) GetGatewayFeeU256() []byte {
+	if x != nil {
+		return x.GatewayFeeU256
+	}
+	return nil
+}
+
+// zkSync Era specific receipt fields
+type ZkSyncReceiptExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of the L1 batch the transaction was committed in; unset until the batch is sealed
+	L1BatchNumber *uint64 `protobuf:"varint,1,opt,name=l1BatchNumber,proto3,oneof" json:"l1BatchNumber,omitempty"`
+	// Index of the transaction within its L1 batch
+	L1BatchTxIndex *uint64 `protobuf:"varint,2,opt,name=l1BatchTxIndex,proto3,oneof" json:"l1BatchTxIndex,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ZkSyncReceiptExtension) Reset() {
+	*x = ZkSyncReceiptExtension{}
+	mi := &file_models_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZkSyncReceiptExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZkSyncReceiptExtension) ProtoMessage() {}
+
+func (x *ZkSyncReceiptExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZkSyncReceiptExtension.ProtoReflect.Descriptor instead.
+func (*ZkSyncReceiptExtension) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ZkSyncReceiptExtension) GetL1BatchNumber() uint64 {
+	if x != nil && x.L1BatchNumber != nil {
+		return *x.L1BatchNumber
+	}
+	return 0
+}
+
+func (x *ZkSyncReceiptExtension) GetL1BatchTxIndex() uint64 {
+	if x != nil && x.L1BatchTxIndex != nil {
+		return *x.L1BatchTxIndex
+	}
+	return 0
+}
+
+// A single frame of an execution trace representing one internal call, creation, self-destruct or reward. Frames form a tree rooted at the top-level call of a transaction
+type CallFrame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The kind of frame (CALL, STATICCALL, DELEGATECALL, CREATE, ...). Determines how from/to/value should be interpreted
+	Type CallType `protobuf:"varint,1,opt,name=type,proto3,enum=bds.evm.CallType" json:"type,omitempty"`
+	// The 20-byte address that initiated this frame. For SELFDESTRUCT this is the destroyed contract. Empty for REWARD frames
+	From []byte `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// The 20-byte address that received this frame. For CREATE/CREATE2 this is the created contract address (empty if creation failed), for SELFDESTRUCT the beneficiary and for REWARD the rewarded author
+	To []byte `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Amount of wei transferred by this frame, as a string to support 256-bit values. Absent for STATICCALL and DELEGATECALL frames which cannot transfer value
+	Value *string `protobuf:"bytes,4,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	// Gas made available to this frame. Zero for SELFDESTRUCT and REWARD frames
+	Gas uint64 `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	// Gas actually consumed by this frame including all nested frames. Zero when the tracer did not report a result (e.g. failed parity frames)
+	GasUsed uint64 `protobuf:"varint,6,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	// Call data for calls or init code for creations. Empty for SELFDESTRUCT and REWARD frames
+	Input []byte `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	// Return data for calls or deployed runtime code for creations. Contains the raw revert data when the frame reverted
+	Output []byte `protobuf:"bytes,8,opt,name=output,proto3" json:"output,omitempty"`
+	// Error message when the frame failed (e.g. 'execution reverted', 'out of gas'). Wording is client-specific and kept as reported by the tracer
+	Error *string `protobuf:"bytes,9,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	// Decoded Solidity revert reason when the frame reverted with Error(string). Only reported by geth-style callTracer
+	RevertReason *string `protobuf:"bytes,10,opt,name=revertReason,proto3,oneof" json:"revertReason,omitempty"`
+	// Nested frames issued by this frame in execution order
+	Calls []*CallFrame `protobuf:"bytes,11,rep,name=calls,proto3" json:"calls,omitempty"`
+	// Position of this frame in the call tree as a list of child indices from the root (empty for the root frame). Matches parity's traceAddress and allows frames to be addressed without walking the tree
+	TraceAddress []uint32 `protobuf:"varint,12,rep,packed,name=traceAddress,proto3" json:"traceAddress,omitempty"`
+	// Kind of reward for REWARD frames ('block', 'uncle', 'emptyStep', 'external'). Absent for all other frame types
+	RewardType    *string `protobuf:"bytes,13,opt,name=rewardType,proto3,oneof" json:"rewardType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallFrame) Reset() {
+	*x = CallFrame{}
+	mi := &file_models_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallFrame) ProtoMessage() {}
+
+func (x *CallFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallFrame.ProtoReflect.Descriptor instead.
+func (*CallFrame) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CallFrame) GetType() CallType {
+	if x != nil {
+		return x.Type
+	}
+	return CallType_CALL
+}
+
+func (x *CallFrame) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CallFrame) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *CallFrame) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *CallFrame) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *CallFrame) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *CallFrame) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *CallFrame) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *CallFrame) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *CallFrame) GetRevertReason() string {
+	if x != nil && x.RevertReason != nil {
+		return *x.RevertReason
+	}
+	return ""
+}
+
+func (x *CallFrame) GetCalls() []*CallFrame {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *CallFrame) GetTraceAddress() []uint32 {
+	if x != nil {
+		return x.TraceAddress
+	}
+	return nil
+}
+
+func (x *CallFrame) GetRewardType() string {
+	if x != nil && x.RewardType != nil {
+		return *x.RewardType
+	}
+	return ""
+}
+
+// The execution trace of a single transaction (or a block reward) as a tree of call frames
+type Trace struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the traced transaction. Empty for block and uncle reward traces which are not attached to a transaction
+	TransactionHash []byte `protobuf:"bytes,1,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	// The block number containing the traced transaction when known. geth debug_trace* output does not include block context, so this is only populated when a block header is supplied or for parity-style traces
+	BlockNumber *uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3,oneof" json:"blockNumber,omitempty"`
+	// The hash of the block containing the traced transaction when known
+	BlockHash []byte `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	// The zero-based index of the traced transaction within its block when known
+	TransactionIndex *uint32 `protobuf:"varint,4,opt,name=transactionIndex,proto3,oneof" json:"transactionIndex,omitempty"`
+	// The top-level frame of the transaction. All internal calls are reachable through root.calls
+	Root          *CallFrame `protobuf:"bytes,5,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trace) Reset() {
+	*x = Trace{}
+	mi := &file_models_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trace) ProtoMessage() {}
+
+func (x *Trace) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trace.ProtoReflect.Descriptor instead.
+func (*Trace) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Trace) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *Trace) GetBlockNumber() uint64 {
+	if x != nil && x.BlockNumber != nil {
+		return *x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Trace) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *Trace) GetTransactionIndex() uint32 {
+	if x != nil && x.TransactionIndex != nil {
+		return *x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Trace) GetRoot() *CallFrame {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+// A change of a single storage slot of an account caused by a transaction
+type StorageChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 32-byte storage slot key
+	Slot []byte `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	// The 32-byte value of the slot before the transaction. Slots that did not exist are represented as 32 zero bytes
+	Before []byte `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// The 32-byte value of the slot after the transaction. Cleared slots (including all slots of destroyed accounts) are represented as 32 zero bytes
+	After         []byte `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageChange) Reset() {
+	*x = StorageChange{}
+	mi := &file_models_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageChange) ProtoMessage() {}
+
+func (x *StorageChange) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageChange.ProtoReflect.Descriptor instead.
+func (*StorageChange) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StorageChange) GetSlot() []byte {
+	if x != nil {
+		return x.Slot
+	}
+	return nil
+}
+
+func (x *StorageChange) GetBefore() []byte {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *StorageChange) GetAfter() []byte {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// The changes applied to a single account by a transaction. Only fields that changed carry before/after values; for created accounts only the after values are set and for deleted accounts only the before values are set
+type AccountDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 20-byte address of the changed account
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Balance in wei before the transaction, as a string to support 256-bit values. Absent when the balance did not change or the account was created
+	BalanceBefore *string `protobuf:"bytes,2,opt,name=balanceBefore,proto3,oneof" json:"balanceBefore,omitempty"`
+	// Balance in wei after the transaction, as a string to support 256-bit values. Absent when the balance did not change or the account was deleted
+	BalanceAfter *string `protobuf:"bytes,3,opt,name=balanceAfter,proto3,oneof" json:"balanceAfter,omitempty"`
+	// Account nonce before the transaction. Absent when the nonce did not change or the account was created
+	NonceBefore *uint64 `protobuf:"varint,4,opt,name=nonceBefore,proto3,oneof" json:"nonceBefore,omitempty"`
+	// Account nonce after the transaction. Absent when the nonce did not change or the account was deleted
+	NonceAfter *uint64 `protobuf:"varint,5,opt,name=nonceAfter,proto3,oneof" json:"nonceAfter,omitempty"`
+	// Runtime code before the transaction. Absent when the code did not change or the account was created
+	CodeBefore []byte `protobuf:"bytes,6,opt,name=codeBefore,proto3,oneof" json:"codeBefore,omitempty"`
+	// Runtime code after the transaction. Absent when the code did not change or the account was deleted
+	CodeAfter []byte `protobuf:"bytes,7,opt,name=codeAfter,proto3,oneof" json:"codeAfter,omitempty"`
+	// Storage slots whose value changed, sorted by slot key
+	Storage []*StorageChange `protobuf:"bytes,8,rep,name=storage,proto3" json:"storage,omitempty"`
+	// True when the account did not exist before the transaction (contract deployment or first value transfer to an empty address)
+	Created bool `protobuf:"varint,9,opt,name=created,proto3" json:"created,omitempty"`
+	// True when the account no longer exists after the transaction (SELFDESTRUCT in the creating transaction or pre-Cancun self-destruct)
+	Deleted       bool `protobuf:"varint,10,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDiff) Reset() {
+	*x = AccountDiff{}
+	mi := &file_models_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDiff) ProtoMessage() {}
+
+func (x *AccountDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDiff.ProtoReflect.Descriptor instead.
+func (*AccountDiff) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AccountDiff) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AccountDiff) GetBalanceBefore() string {
+	if x != nil && x.BalanceBefore != nil {
+		return *x.BalanceBefore
+	}
+	return ""
+}
+
+func (x *AccountDiff) GetBalanceAfter() string {
+	if x != nil && x.BalanceAfter != nil {
+		return *x.BalanceAfter
+	}
+	return ""
+}
+
+func (x *AccountDiff) GetNonceBefore() uint64 {
+	if x != nil && x.NonceBefore != nil {
+		return *x.NonceBefore
+	}
+	return 0
+}
+
+func (x *AccountDiff) GetNonceAfter() uint64 {
+	if x != nil && x.NonceAfter != nil {
+		return *x.NonceAfter
+	}
+	return 0
+}
+
+func (x *AccountDiff) GetCodeBefore() []byte {
+	if x != nil {
+		return x.CodeBefore
+	}
+	return nil
+}
+
+func (x *AccountDiff) GetCodeAfter() []byte {
+	if x != nil {
+		return x.CodeAfter
+	}
+	return nil
+}
+
+func (x *AccountDiff) GetStorage() []*StorageChange {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+func (x *AccountDiff) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *AccountDiff) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// The state changes caused by a single transaction, normalised across geth prestateTracer diff mode and parity stateDiff output
+type StateDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the transaction that caused these changes
+	TransactionHash []byte `protobuf:"bytes,1,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	// The block number containing the transaction when known
+	BlockNumber *uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3,oneof" json:"blockNumber,omitempty"`
+	// The hash of the block containing the transaction when known
+	BlockHash []byte `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	// The zero-based index of the transaction within its block when known
+	TransactionIndex *uint32 `protobuf:"varint,4,opt,name=transactionIndex,proto3,oneof" json:"transactionIndex,omitempty"`
+	// The changed accounts sorted by address
+	Accounts      []*AccountDiff `protobuf:"bytes,5,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateDiff) Reset() {
+	*x = StateDiff{}
+	mi := &file_models_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateDiff) ProtoMessage() {}
+
+func (x *StateDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateDiff.ProtoReflect.Descriptor instead.
+func (*StateDiff) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StateDiff) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *StateDiff) GetBlockNumber() uint64 {
+	if x != nil && x.BlockNumber != nil {
+		return *x.BlockNumber
+	}
+	return 0
+}
+
+func (x *StateDiff) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *StateDiff) GetTransactionIndex() uint32 {
+	if x != nil && x.TransactionIndex != nil {
+		return *x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *StateDiff) GetAccounts() []*AccountDiff {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+// A Merkle-Patricia proof for one storage slot of an account, as returned in the storageProof entries of eth_getProof
+type StorageProof struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The storage slot key as requested. The trie path is the Keccak-256 hash of the key left-padded to 32 bytes
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The 32-byte value stored in the slot; all zeros when the slot is empty and the proof shows its absence
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// RLP-encoded trie nodes from the account's storage root down to the slot
+	Proof         [][]byte `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageProof) Reset() {
+	*x = StorageProof{}
+	mi := &file_models_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageProof) ProtoMessage() {}
+
+func (x *StorageProof) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageProof.ProtoReflect.Descriptor instead.
+func (*StorageProof) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StorageProof) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StorageProof) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *StorageProof) GetProof() [][]byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// A Merkle-Patricia proof of an account's state and selected storage slots against a block's state root (eth_getProof). An account that does not exist is proven absent with zero nonce and balance and empty code and storage
+type AccountProof struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 20-byte address of the proven account
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Account balance in wei
+	Balance string `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// Keccak-256 hash of the account's runtime code
+	CodeHash []byte `protobuf:"bytes,3,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
+	// Account nonce
+	Nonce uint64 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Root hash of the account's storage trie, against which the storage proofs are verified
+	StorageHash []byte `protobuf:"bytes,5,opt,name=storageHash,proto3" json:"storageHash,omitempty"`
+	// RLP-encoded trie nodes from the state root down to the account
+	AccountProof [][]byte `protobuf:"bytes,6,rep,name=accountProof,proto3" json:"accountProof,omitempty"`
+	// Proofs for the requested storage slots, in request order
+	StorageProof []*StorageProof `protobuf:"bytes,7,rep,name=storageProof,proto3" json:"storageProof,omitempty"`
+	// The block number whose state root the proof was produced against, when known
+	BlockNumber *uint64 `protobuf:"varint,8,opt,name=blockNumber,proto3,oneof" json:"blockNumber,omitempty"`
+	// The hash of the block whose state root the proof was produced against, when known
+	BlockHash     []byte `protobuf:"bytes,9,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountProof) Reset() {
+	*x = AccountProof{}
+	mi := &file_models_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountProof) ProtoMessage() {}
+
+func (x *AccountProof) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountProof.ProtoReflect.Descriptor instead.
+func (*AccountProof) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AccountProof) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AccountProof) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *AccountProof) GetCodeHash() []byte {
+	if x != nil {
+		return x.CodeHash
+	}
+	return nil
+}
+
+func (x *AccountProof) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *AccountProof) GetStorageHash() []byte {
+	if x != nil {
+		return x.StorageHash
+	}
+	return nil
+}
+
+func (x *AccountProof) GetAccountProof() [][]byte {
+	if x != nil {
+		return x.AccountProof
+	}
+	return nil
+}
+
+func (x *AccountProof) GetStorageProof() []*StorageProof {
+	if x != nil {
+		return x.StorageProof
+	}
+	return nil
+}
+
+func (x *AccountProof) GetBlockNumber() uint64 {
+	if x != nil && x.BlockNumber != nil {
+		return *x.BlockNumber
+	}
+	return 0
+}
+
+func (x *AccountProof) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+// The priority fees paid at the requested reward percentiles in one block of a fee history
+type FeeHistoryReward struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Effective priority fee per gas at each requested percentile, weighted by gas used, in wei
+	Values        []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeHistoryReward) Reset() {
+	*x = FeeHistoryReward{}
+	mi := &file_models_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeHistoryReward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeHistoryReward) ProtoMessage() {}
+
+func (x *FeeHistoryReward) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeHistoryReward.ProtoReflect.Descriptor instead.
+func (*FeeHistoryReward) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FeeHistoryReward) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Base fees, gas usage and priority fee percentiles for a range of consecutive blocks (eth_feeHistory). Per-block lists are ordered from oldestBlock upwards; the base fee lists hold one extra entry for the block after the range
+type FeeHistory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of the first block in the range
+	OldestBlock uint64 `protobuf:"varint,1,opt,name=oldestBlock,proto3" json:"oldestBlock,omitempty"`
+	// Base fee per gas of each block in the range and of the next block, in wei. Zero before EIP-1559
+	BaseFeePerGas []string `protobuf:"bytes,2,rep,name=baseFeePerGas,proto3" json:"baseFeePerGas,omitempty"`
+	// Ratio of gasUsed to gasLimit for each block in the range
+	GasUsedRatio []float64 `protobuf:"fixed64,3,rep,packed,name=gasUsedRatio,proto3" json:"gasUsedRatio,omitempty"`
+	// Priority fees at the requested percentiles for each block in the range; empty when no percentiles were requested
+	Reward []*FeeHistoryReward `protobuf:"bytes,4,rep,name=reward,proto3" json:"reward,omitempty"`
+	// Blob base fee per gas of each block in the range and of the next block, in wei. Zero before EIP-4844
+	BaseFeePerBlobGas []string `protobuf:"bytes,5,rep,name=baseFeePerBlobGas,proto3" json:"baseFeePerBlobGas,omitempty"`
+	// Ratio of blobGasUsed to the maximum blob gas per block for each block in the range
+	BlobGasUsedRatio []float64 `protobuf:"fixed64,6,rep,packed,name=blobGasUsedRatio,proto3" json:"blobGasUsedRatio,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FeeHistory) Reset() {
+	*x = FeeHistory{}
+	mi := &file_models_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeHistory) ProtoMessage() {}
+
+func (x *FeeHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeHistory.ProtoReflect.Descriptor instead.
+func (*FeeHistory) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FeeHistory) GetOldestBlock() uint64 {
+	if x != nil {
+		return x.OldestBlock
+	}
+	return 0
+}
+
+func (x *FeeHistory) GetBaseFeePerGas() []string {
+	if x != nil {
+		return x.BaseFeePerGas
+	}
+	return nil
+}
+
+func (x *FeeHistory) GetGasUsedRatio() []float64 {
+	if x != nil {
+		return x.GasUsedRatio
+	}
+	return nil
+}
+
+func (x *FeeHistory) GetReward() []*FeeHistoryReward {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+func (x *FeeHistory) GetBaseFeePerBlobGas() []string {
+	if x != nil {
+		return x.BaseFeePerBlobGas
+	}
+	return nil
+}
+
+func (x *FeeHistory) GetBlobGasUsedRatio() []float64 {
+	if x != nil {
+		return x.BlobGasUsedRatio
+	}
+	return nil
+}
+
+// A transaction observed in a node's transaction pool before inclusion in a block (txpool_content, txpool_inspect or a newPendingTransactions subscription)
+type PendingTransaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The transaction body. Block fields are unset; from txpool_inspect only from, to, nonce, value, gasLimit and gasPrice are known and hash is empty
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// The pool the transaction was in when observed
+	Status PendingTransactionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=bds.evm.PendingTransactionStatus" json:"status,omitempty"`
+	// Unix time in milliseconds when the observer first saw the transaction. Nodes do not report this, so it is set by whoever collects the pool
+	FirstSeenTimestampMs *uint64 `protobuf:"varint,3,opt,name=firstSeenTimestampMs,proto3,oneof" json:"firstSeenTimestampMs,omitempty"`
+	// Hashes of the transactions with the same sender and nonce that this transaction replaced (e.g. by bumping the fee), oldest first
+	Replaces [][]byte `protobuf:"bytes,4,rep,name=replaces,proto3" json:"replaces,omitempty"`
+	// Hash of the transaction that replaced this one, if it was replaced before inclusion
+	ReplacedBy    []byte `protobuf:"bytes,5,opt,name=replacedBy,proto3,oneof" json:"replacedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingTransaction) Reset() {
+	*x = PendingTransaction{}
+	mi := &file_models_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingTransaction) ProtoMessage() {}
+
+func (x *PendingTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingTransaction.ProtoReflect.Descriptor instead.
+func (*PendingTransaction) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PendingTransaction) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *PendingTransaction) GetStatus() PendingTransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PendingTransactionStatus_PENDING
+}
+
+func (x *PendingTransaction) GetFirstSeenTimestampMs() uint64 {
+	if x != nil && x.FirstSeenTimestampMs != nil {
+		return *x.FirstSeenTimestampMs
+	}
+	return 0
+}
+
+func (x *PendingTransaction) GetReplaces() [][]byte {
+	if x != nil {
+		return x.Replaces
+	}
+	return nil
+}
+
+func (x *PendingTransaction) GetReplacedBy() []byte {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return nil
+}
+
+var File_models_proto protoreflect.FileDescriptor
+
+const file_models_proto_rawDesc = "" +
+	"\n" +
+	"\fmodels.proto\x12\abds.evm\"V\n" +
+	"\bBlockRef\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\fR\x04hash\x12\x1e\n" +
+	"\n" +
+	"parentHash\x18\x03 \x01(\fR\n" +
+	"parentHash\"\x80\x0f\n" +
+	"\vBlockHeader\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\x12\x1a\n" +
+	"\bgasLimit\x18\x03 \x01(\x04R\bgasLimit\x12\x18\n" +
+	"\agasUsed\x18\x04 \x01(\x04R\agasUsed\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x04R\x04size\x12\x12\n" +
+	"\x04hash\x18\x06 \x01(\fR\x04hash\x12\x1e\n" +
+	"\n" +
+	"parentHash\x18\a \x01(\fR\n" +
+	"parentHash\x12\x1c\n" +
+	"\tstateRoot\x18\b \x01(\fR\tstateRoot\x12*\n" +
+	"\x10transactionsRoot\x18\t \x01(\fR\x10transactionsRoot\x12\"\n" +
+	"\freceiptsRoot\x18\n" +
+	" \x01(\fR\freceiptsRoot\x12\x1e\n" +
+	"\n" +
+	"sha3Uncles\x18\v \x01(\fR\n" +
+	"sha3Uncles\x12\x14\n" +
+	"\x05miner\x18\f \x01(\fR\x05miner\x12\x1c\n" +
+	"\tlogsBloom\x18\r \x01(\fR\tlogsBloom\x12\x1c\n" +
+	"\textraData\x18\x0e \x01(\fR\textraData\x12\x19\n" +
+	"\x05nonce\x18\x0f \x01(\x04H\x00R\x05nonce\x88\x01\x01\x12%\n" +
+	"\vblobGasUsed\x18\x10 \x01(\x04H\x01R\vblobGasUsed\x88\x01\x01\x12)\n" +
+	"\rexcessBlobGas\x18\x11 \x01(\x04H\x02R\rexcessBlobGas\x88\x01\x01\x12)\n" +
+	"\rl1BlockNumber\x18\x12 \x01(\x04H\x03R\rl1BlockNumber\x88\x01\x01\x12\x19\n" +
+	"\x05epoch\x18\x13 \x01(\x04H\x04R\x05epoch\x88\x01\x01\x12\x17\n" +
+	"\x04slot\x18\x14 \x01(\x04H\x05R\x04slot\x88\x01\x01\x12)\n" +
+	"\rproposerIndex\x18\x15 \x01(\x04H\x06R\rproposerIndex\x88\x01\x01\x12!\n" +
+	"\tsendCount\x18\x16 \x01(\x04H\aR\tsendCount\x88\x01\x01\x12/\n" +
+	"\x10transactionCount\x18\x17 \x01(\rH\bR\x10transactionCount\x88\x01\x01\x12\x1d\n" +
+	"\amixHash\x18\x18 \x01(\fH\tR\amixHash\x88\x01\x01\x129\n" +
+	"\x15parentBeaconBlockRoot\x18\x19 \x01(\fH\n" +
+	"R\x15parentBeaconBlockRoot\x88\x01\x01\x12-\n" +
+	"\x0fwithdrawalsRoot\x18\x1a \x01(\fH\vR\x0fwithdrawalsRoot\x88\x01\x01\x12\x1f\n" +
+	"\bsendRoot\x18\x1b \x01(\fH\fR\bsendRoot\x88\x01\x01\x12)\n" +
+	"\rbaseFeePerGas\x18\x1c \x01(\tH\rR\rbaseFeePerGas\x88\x01\x01\x12#\n" +
+	"\n" +
+	"difficulty\x18\x1d \x01(\tH\x0eR\n" +
+	"difficulty\x88\x01\x01\x12-\n" +
+	"\x0ftotalDifficulty\x18\x1e \x01(\tH\x0fR\x0ftotalDifficulty\x88\x01\x01\x121\n" +
+	"\x11proposerPublicKey\x18\x1f \x01(\tH\x10R\x11proposerPublicKey\x88\x01\x01\x12%\n" +
+	"\vwithdrawals\x18  \x01(\fH\x11R\vwithdrawals\x88\x01\x01\x12'\n" +
+	"\fcanonicalRlp\x18! \x01(\fH\x12R\fcanonicalRlp\x88\x01\x01\x12\x16\n" +
+	"\x06uncles\x18\" \x03(\fR\x06uncles\x12'\n" +
+	"\frequestsHash\x18# \x01(\fH\x13R\frequestsHash\x88\x01\x01\x12D\n" +
+	"\n" +
+	"extensions\x18$ \x03(\v2$.bds.evm.BlockHeader.ExtensionsEntryR\n" +
+	"extensions\x121\n" +
+	"\x11baseFeePerGasU256\x18% \x01(\fH\x14R\x11baseFeePerGasU256\x88\x01\x01\x12+\n" +
+	"\x0edifficultyU256\x18& \x01(\fH\x15R\x0edifficultyU256\x88\x01\x01\x125\n" +
+	"\x13totalDifficultyU256\x18' \x01(\fH\x16R\x13totalDifficultyU256\x88\x01\x01\x1a=\n" +
+	"\x0fExtensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_nonceB\x0e\n" +
+	"\f_blobGasUsedB\x10\n" +
+	"\x0e_excessBlobGasB\x10\n" +
+	"\x0e_l1BlockNumberB\b\n" +
+	"\x06_epochB\a\n" +
+	"\x05_slotB\x10\n" +
+	"\x0e_proposerIndexB\f\n" +
+	"\n" +
+	"_sendCountB\x13\n" +
+	"\x11_transactionCountB\n" +
+	"\n" +
+	"\b_mixHashB\x18\n" +
+	"\x16_parentBeaconBlockRootB\x12\n" +
+	"\x10_withdrawalsRootB\v\n" +
+	"\t_sendRootB\x10\n" +
+	"\x0e_baseFeePerGasB\r\n" +
+	"\v_difficultyB\x12\n" +
+	"\x10_totalDifficultyB\x14\n" +
+	"\x12_proposerPublicKeyB\x0e\n" +
+	"\f_withdrawalsB\x0f\n" +
+	"\r_canonicalRlpB\x0f\n" +
+	"\r_requestsHashB\x14\n" +
+	"\x12_baseFeePerGasU256B\x11\n" +
+	"\x0f_difficultyU256B\x16\n" +
+	"\x14_totalDifficultyU256\"\xfe\x01\n" +
+	"\x05Block\x12,\n" +
+	"\x06header\x18\x01 \x01(\v2\x14.bds.evm.BlockHeaderR\x06header\x12,\n" +
+	"\x11transactionHashes\x18\x02 \x03(\fR\x11transactionHashes\x12@\n" +
+	"\x10fullTransactions\x18\x03 \x03(\v2\x14.bds.evm.TransactionR\x10fullTransactions\x12 \n" +
+	"\x04logs\x18\x04 \x03(\v2\f.bds.evm.LogR\x04logs\x125\n" +
+	"\vwithdrawals\x18\x05 \x03(\v2\x13.bds.evm.WithdrawalR\vwithdrawals\"\x8f\x01\n" +
+	"\x0eTransactionRef\x12'\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.bds.evm.BlockRefR\x05block\x12*\n" +
+	"\x10transactionIndex\x18\x02 \x01(\rR\x10transactionIndex\x12(\n" +
+	"\x0ftransactionHash\x18\x03 \x01(\fR\x0ftransactionHash\"\xb2\x1a\n" +
+	"\vTransaction\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\x04R\x05nonce\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\fR\x04from\x12\x13\n" +
+	"\x02to\x18\x04 \x01(\fH\x00R\x02to\x88\x01\x01\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x14\n" +
+	"\x05input\x18\x06 \x01(\fR\x05input\x12\x12\n" +
+	"\x04type\x18\a \x01(\rR\x04type\x12\x1a\n" +
+	"\bgasLimit\x18\b \x01(\x04R\bgasLimit\x12\x1f\n" +
+	"\bgasPrice\x18\t \x01(\tH\x01R\bgasPrice\x88\x01\x01\x12'\n" +
+	"\fmaxFeePerGas\x18\n" +
+	" \x01(\tH\x02R\fmaxFeePerGas\x88\x01\x01\x127\n" +
+	"\x14maxPriorityFeePerGas\x18\v \x01(\tH\x03R\x14maxPriorityFeePerGas\x88\x01\x01\x12\x1d\n" +
+	"\agasUsed\x18\f \x01(\x04H\x04R\agasUsed\x88\x01\x01\x121\n" +
+	"\x11effectiveGasPrice\x18\r \x01(\tH\x05R\x11effectiveGasPrice\x88\x01\x01\x12\f\n" +
+	"\x01r\x18\x0e \x01(\fR\x01r\x12\f\n" +
+	"\x01s\x18\x0f \x01(\fR\x01s\x12\x11\n" +
+	"\x01v\x18\x10 \x01(\fH\x06R\x01v\x88\x01\x01\x12\x1d\n" +
+	"\ayParity\x18\x11 \x01(\rH\aR\ayParity\x88\x01\x01\x12\x1d\n" +
+	"\achainId\x18\x12 \x01(\x04H\bR\achainId\x88\x01\x01\x12%\n" +
+	"\vblockNumber\x18\x13 \x01(\x04H\tR\vblockNumber\x88\x01\x01\x12!\n" +
+	"\tblockHash\x18\x14 \x01(\fH\n" +
 	"R\tblockHash\x88\x01\x01\x12/\n" +
 	"\x10transactionIndex\x18\x15 \x01(\rH\vR\x10transactionIndex\x88\x01\x01\x12+\n" +
 	"\x0eblockTimestamp\x18\x16 \x01(\x04H\fR\x0eblockTimestamp\x88\x01\x01\x127\n" +
@@ -1949,7 +4147,18 @@ const file_models_proto_rawDesc = "" +
 	"\n" +
 	"isSystemTx\x182 \x01(\bH%R\n" +
 	"isSystemTx\x88\x01\x01\x129\n" +
-	"\x15depositReceiptVersion\x183 \x01(\tH&R\x15depositReceiptVersion\x88\x01\x01B\x05\n" +
+	"\x15depositReceiptVersion\x183 \x01(\tH&R\x15depositReceiptVersion\x88\x01\x01\x12F\n" +
+	"\boptimism\x184 \x01(\v2%.bds.evm.OptimismTransactionExtensionH'R\boptimism\x88\x01\x01\x12F\n" +
+	"\barbitrum\x185 \x01(\v2%.bds.evm.ArbitrumTransactionExtensionH(R\barbitrum\x88\x01\x01\x12:\n" +
+	"\x04celo\x186 \x01(\v2!.bds.evm.CeloTransactionExtensionH)R\x04celo\x88\x01\x01\x12@\n" +
+	"\x06zkSync\x187 \x01(\v2#.bds.evm.ZkSyncTransactionExtensionH*R\x06zkSync\x88\x01\x01\x12C\n" +
+	"\apolygon\x188 \x01(\v2$.bds.evm.PolygonTransactionExtensionH+R\apolygon\x88\x01\x01\x12!\n" +
+	"\tvalueU256\x189 \x01(\fH,R\tvalueU256\x88\x01\x01\x12'\n" +
+	"\fgasPriceU256\x18: \x01(\fH-R\fgasPriceU256\x88\x01\x01\x12/\n" +
+	"\x10maxFeePerGasU256\x18; \x01(\fH.R\x10maxFeePerGasU256\x88\x01\x01\x12?\n" +
+	"\x18maxPriorityFeePerGasU256\x18< \x01(\fH/R\x18maxPriorityFeePerGasU256\x88\x01\x01\x129\n" +
+	"\x15effectiveGasPriceU256\x18= \x01(\fH0R\x15effectiveGasPriceU256\x88\x01\x01\x127\n" +
+	"\x14maxFeePerBlobGasU256\x18> \x01(\fH1R\x14maxFeePerBlobGasU256\x88\x01\x01B\x05\n" +
 	"\x03_toB\v\n" +
 	"\t_gasPriceB\x0f\n" +
 	"\r_maxFeePerGasB\x17\n" +
@@ -1998,10 +4207,23 @@ const file_models_proto_rawDesc = "" +
 	"\x14_submissionFeeRefundB\v\n" +
 	"\t_ticketIdB\r\n" +
 	"\v_isSystemTxB\x18\n" +
-	"\x16_depositReceiptVersion\"L\n" +
+	"\x16_depositReceiptVersionB\v\n" +
+	"\t_optimismB\v\n" +
+	"\t_arbitrumB\a\n" +
+	"\x05_celoB\t\n" +
+	"\a_zkSyncB\n" +
+	"\n" +
+	"\b_polygonB\f\n" +
+	"\n" +
+	"_valueU256B\x0f\n" +
+	"\r_gasPriceU256B\x13\n" +
+	"\x11_maxFeePerGasU256B\x1b\n" +
+	"\x19_maxPriorityFeePerGasU256B\x18\n" +
+	"\x16_effectiveGasPriceU256B\x17\n" +
+	"\x15_maxFeePerBlobGasU256\"L\n" +
 	"\x0eAccessListItem\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\fR\aaddress\x12 \n" +
-	"\vstorageKeys\x18\x02 \x03(\fR\vstorageKeys\"\xbd\x02\n" +
+	"\vstorageKeys\x18\x02 \x03(\fR\vstorageKeys\"\xd4\x03\n" +
 	"\x03Log\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x16\n" +
 	"\x06topics\x18\x02 \x03(\fR\x06topics\x12\x12\n" +
@@ -2011,7 +4233,15 @@ const file_models_proto_rawDesc = "" +
 	"\x0ftransactionHash\x18\x06 \x01(\fR\x0ftransactionHash\x12*\n" +
 	"\x10transactionIndex\x18\a \x01(\rR\x10transactionIndex\x12\x1a\n" +
 	"\blogIndex\x18\b \x01(\rR\blogIndex\x12+\n" +
-	"\x0eblockTimestamp\x18\t \x01(\x04H\x00R\x0eblockTimestamp\x88\x01\x01B\x11\n" +
+	"\x0eblockTimestamp\x18\t \x01(\x04H\x00R\x0eblockTimestamp\x88\x01\x01\x12<\n" +
+	"\n" +
+	"extensions\x18\n" +
+	" \x03(\v2\x1c.bds.evm.Log.ExtensionsEntryR\n" +
+	"extensions\x12\x18\n" +
+	"\aremoved\x18\v \x01(\bR\aremoved\x1a=\n" +
+	"\x0fExtensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x11\n" +
 	"\x0f_blockTimestamp\"\xb5\x01\n" +
 	"\x15AuthorizationListItem\x12\x18\n" +
 	"\achainId\x18\x01 \x01(\x04R\achainId\x12\x18\n" +
@@ -2026,7 +4256,7 @@ const file_models_proto_rawDesc = "" +
 	"\x05index\x18\x01 \x01(\x04R\x05index\x12&\n" +
 	"\x0evalidatorIndex\x18\x02 \x01(\x04R\x0evalidatorIndex\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x04R\x06amount\"\xd8\v\n" +
+	"\x06amount\x18\x04 \x01(\x04R\x06amount\"\xed\x10\n" +
 	"\aReceipt\x12(\n" +
 	"\x0ftransactionHash\x18\x01 \x01(\fR\x0ftransactionHash\x12 \n" +
 	"\vblockNumber\x18\x02 \x01(\x04R\vblockNumber\x12\x1c\n" +
@@ -2064,7 +4294,20 @@ const file_models_proto_rawDesc = "" +
 	"\fdepositNonce\x18\x1c \x01(\tH\x10R\fdepositNonce\x88\x01\x01\x129\n" +
 	"\x15depositReceiptVersion\x18\x1d \x01(\tH\x11R\x15depositReceiptVersion\x88\x01\x01\x12)\n" +
 	"\rl1BlobBaseFee\x18\x1e \x01(\tH\x12R\rl1BlobBaseFee\x88\x01\x01\x125\n" +
-	"\x13l1BlobBaseFeeScalar\x18\x1f \x01(\x04H\x13R\x13l1BlobBaseFeeScalar\x88\x01\x01B\x05\n" +
+	"\x13l1BlobBaseFeeScalar\x18\x1f \x01(\x04H\x13R\x13l1BlobBaseFeeScalar\x88\x01\x01\x12@\n" +
+	"\n" +
+	"extensions\x18  \x03(\v2 .bds.evm.Receipt.ExtensionsEntryR\n" +
+	"extensions\x12B\n" +
+	"\boptimism\x18! \x01(\v2!.bds.evm.OptimismReceiptExtensionH\x14R\boptimism\x88\x01\x01\x12B\n" +
+	"\barbitrum\x18\" \x01(\v2!.bds.evm.ArbitrumReceiptExtensionH\x15R\barbitrum\x88\x01\x01\x126\n" +
+	"\x04celo\x18# \x01(\v2\x1d.bds.evm.CeloReceiptExtensionH\x16R\x04celo\x88\x01\x01\x12<\n" +
+	"\x06zkSync\x18$ \x01(\v2\x1f.bds.evm.ZkSyncReceiptExtensionH\x17R\x06zkSync\x88\x01\x01\x129\n" +
+	"\x15effectiveGasPriceU256\x18% \x01(\fH\x18R\x15effectiveGasPriceU256\x88\x01\x01\x12/\n" +
+	"\x10blobGasPriceU256\x18& \x01(\fH\x19R\x10blobGasPriceU256\x88\x01\x01\x12?\n" +
+	"\apolygon\x18' \x01(\v2 .bds.evm.PolygonReceiptExtensionH\x1aR\apolygon\x88\x01\x01\x1a=\n" +
+	"\x0fExtensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x05\n" +
 	"\x03_toB\t\n" +
 	"\a_statusB\x12\n" +
 	"\x10_contractAddressB\a\n" +
@@ -2085,14 +4328,299 @@ const file_models_proto_rawDesc = "" +
 	"\r_depositNonceB\x18\n" +
 	"\x16_depositReceiptVersionB\x10\n" +
 	"\x0e_l1BlobBaseFeeB\x16\n" +
-	"\x14_l1BlobBaseFeeScalar*W\n" +
+	"\x14_l1BlobBaseFeeScalarB\v\n" +
+	"\t_optimismB\v\n" +
+	"\t_arbitrumB\a\n" +
+	"\x05_celoB\t\n" +
+	"\a_zkSyncB\x18\n" +
+	"\x16_effectiveGasPriceU256B\x13\n" +
+	"\x11_blobGasPriceU256B\n" +
+	"\n" +
+	"\b_polygon\"\xb2\x06\n" +
+	"\x1cOptimismTransactionExtension\x12\x19\n" +
+	"\x05l1Fee\x18\x01 \x01(\tH\x00R\x05l1Fee\x88\x01\x01\x12#\n" +
+	"\n" +
+	"l1GasPrice\x18\x02 \x01(\tH\x01R\n" +
+	"l1GasPrice\x88\x01\x01\x12!\n" +
+	"\tl1GasUsed\x18\x03 \x01(\tH\x02R\tl1GasUsed\x88\x01\x01\x12%\n" +
+	"\vl1FeeScalar\x18\x04 \x01(\x01H\x03R\vl1FeeScalar\x88\x01\x01\x12)\n" +
+	"\rl1BlobBaseFee\x18\x05 \x01(\tH\x04R\rl1BlobBaseFee\x88\x01\x01\x125\n" +
+	"\x13l1BlobBaseFeeScalar\x18\x06 \x01(\x04H\x05R\x13l1BlobBaseFeeScalar\x88\x01\x01\x12#\n" +
+	"\n" +
+	"isSystemTx\x18\a \x01(\bH\x06R\n" +
+	"isSystemTx\x88\x01\x01\x129\n" +
+	"\x15depositReceiptVersion\x18\b \x01(\tH\aR\x15depositReceiptVersion\x88\x01\x01\x12#\n" +
+	"\n" +
+	"sourceHash\x18\t \x01(\fH\bR\n" +
+	"sourceHash\x88\x01\x01\x12\x17\n" +
+	"\x04mint\x18\n" +
+	" \x01(\tH\tR\x04mint\x88\x01\x01\x12!\n" +
+	"\tl1FeeU256\x18\v \x01(\fH\n" +
+	"R\tl1FeeU256\x88\x01\x01\x12+\n" +
+	"\x0el1GasPriceU256\x18\f \x01(\fH\vR\x0el1GasPriceU256\x88\x01\x01\x121\n" +
+	"\x11l1BlobBaseFeeU256\x18\r \x01(\fH\fR\x11l1BlobBaseFeeU256\x88\x01\x01\x12\x1f\n" +
+	"\bmintU256\x18\x0e \x01(\fH\rR\bmintU256\x88\x01\x01B\b\n" +
+	"\x06_l1FeeB\r\n" +
+	"\v_l1GasPriceB\f\n" +
+	"\n" +
+	"_l1GasUsedB\x0e\n" +
+	"\f_l1FeeScalarB\x10\n" +
+	"\x0e_l1BlobBaseFeeB\x16\n" +
+	"\x14_l1BlobBaseFeeScalarB\r\n" +
+	"\v_isSystemTxB\x18\n" +
+	"\x16_depositReceiptVersionB\r\n" +
+	"\v_sourceHashB\a\n" +
+	"\x05_mintB\f\n" +
+	"\n" +
+	"_l1FeeU256B\x11\n" +
+	"\x0f_l1GasPriceU256B\x14\n" +
+	"\x12_l1BlobBaseFeeU256B\v\n" +
+	"\t_mintU256\"\xd0\b\n" +
+	"\x1cArbitrumTransactionExtension\x12%\n" +
+	"\vbeneficiary\x18\x01 \x01(\fH\x00R\vbeneficiary\x88\x01\x01\x12'\n" +
+	"\fdepositValue\x18\x02 \x01(\tH\x01R\fdepositValue\x88\x01\x01\x12!\n" +
+	"\tl1BaseFee\x18\x03 \x01(\tH\x02R\tl1BaseFee\x88\x01\x01\x12/\n" +
+	"\x10maxSubmissionFee\x18\x04 \x01(\tH\x03R\x10maxSubmissionFee\x88\x01\x01\x12\x1f\n" +
+	"\brefundTo\x18\x05 \x01(\fH\x04R\brefundTo\x88\x01\x01\x12!\n" +
+	"\trequestId\x18\x06 \x01(\fH\x05R\trequestId\x88\x01\x01\x12!\n" +
+	"\tretryData\x18\a \x01(\fH\x06R\tretryData\x88\x01\x01\x12\x1d\n" +
+	"\aretryTo\x18\b \x01(\fH\aR\aretryTo\x88\x01\x01\x12#\n" +
+	"\n" +
+	"retryValue\x18\t \x01(\tH\bR\n" +
+	"retryValue\x88\x01\x01\x12!\n" +
+	"\tmaxRefund\x18\n" +
+	" \x01(\tH\tR\tmaxRefund\x88\x01\x01\x125\n" +
+	"\x13submissionFeeRefund\x18\v \x01(\tH\n" +
+	"R\x13submissionFeeRefund\x88\x01\x01\x12\x1f\n" +
+	"\bticketId\x18\f \x01(\fH\vR\bticketId\x88\x01\x01\x12/\n" +
+	"\x10depositValueU256\x18\r \x01(\fH\fR\x10depositValueU256\x88\x01\x01\x12)\n" +
+	"\rl1BaseFeeU256\x18\x0e \x01(\fH\rR\rl1BaseFeeU256\x88\x01\x01\x127\n" +
+	"\x14maxSubmissionFeeU256\x18\x0f \x01(\fH\x0eR\x14maxSubmissionFeeU256\x88\x01\x01\x12+\n" +
+	"\x0eretryValueU256\x18\x10 \x01(\fH\x0fR\x0eretryValueU256\x88\x01\x01\x12)\n" +
+	"\rmaxRefundU256\x18\x11 \x01(\fH\x10R\rmaxRefundU256\x88\x01\x01\x12=\n" +
+	"\x17submissionFeeRefundU256\x18\x12 \x01(\fH\x11R\x17submissionFeeRefundU256\x88\x01\x01B\x0e\n" +
+	"\f_beneficiaryB\x0f\n" +
+	"\r_depositValueB\f\n" +
+	"\n" +
+	"_l1BaseFeeB\x13\n" +
+	"\x11_maxSubmissionFeeB\v\n" +
+	"\t_refundToB\f\n" +
+	"\n" +
+	"_requestIdB\f\n" +
+	"\n" +
+	"_retryDataB\n" +
+	"\n" +
+	"\b_retryToB\r\n" +
+	"\v_retryValueB\f\n" +
+	"\n" +
+	"_maxRefundB\x16\n" +
+	"\x14_submissionFeeRefundB\v\n" +
+	"\t_ticketIdB\x13\n" +
+	"\x11_depositValueU256B\x10\n" +
+	"\x0e_l1BaseFeeU256B\x17\n" +
+	"\x15_maxSubmissionFeeU256B\x11\n" +
+	"\x0f_retryValueU256B\x10\n" +
+	"\x0e_maxRefundU256B\x1a\n" +
+	"\x18_submissionFeeRefundU256\"\x94\x02\n" +
+	"\x18CeloTransactionExtension\x12%\n" +
+	"\vfeeCurrency\x18\x01 \x01(\fH\x00R\vfeeCurrency\x88\x01\x01\x12#\n" +
+	"\n" +
+	"gatewayFee\x18\x02 \x01(\tH\x01R\n" +
+	"gatewayFee\x88\x01\x01\x125\n" +
+	"\x13gatewayFeeRecipient\x18\x03 \x01(\fH\x02R\x13gatewayFeeRecipient\x88\x01\x01\x12+\n" +
+	"\x0egatewayFeeU256\x18\x04 \x01(\fH\x03R\x0egatewayFeeU256\x88\x01\x01B\x0e\n" +
+	"\f_feeCurrencyB\r\n" +
+	"\v_gatewayFeeB\x16\n" +
+	"\x14_gatewayFeeRecipientB\x11\n" +
+	"\x0f_gatewayFeeU256\"\x99\x01\n" +
+	"\x1aZkSyncTransactionExtension\x12)\n" +
+	"\rl1BatchNumber\x18\x01 \x01(\x04H\x00R\rl1BatchNumber\x88\x01\x01\x12+\n" +
+	"\x0el1BatchTxIndex\x18\x02 \x01(\x04H\x01R\x0el1BatchTxIndex\x88\x01\x01B\x10\n" +
+	"\x0e_l1BatchNumberB\x11\n" +
+	"\x0f_l1BatchTxIndex\";\n" +
+	"\x1bPolygonTransactionExtension\x12\x1c\n" +
+	"\tstateSync\x18\x01 \x01(\bR\tstateSync\"7\n" +
+	"\x17PolygonReceiptExtension\x12\x1c\n" +
+	"\tstateSync\x18\x01 \x01(\bR\tstateSync\"\xf3\x05\n" +
+	"\x18OptimismReceiptExtension\x12\x19\n" +
+	"\x05l1Fee\x18\x01 \x01(\tH\x00R\x05l1Fee\x88\x01\x01\x12#\n" +
+	"\n" +
+	"l1GasPrice\x18\x02 \x01(\tH\x01R\n" +
+	"l1GasPrice\x88\x01\x01\x12!\n" +
+	"\tl1GasUsed\x18\x03 \x01(\tH\x02R\tl1GasUsed\x88\x01\x01\x12%\n" +
+	"\vl1FeeScalar\x18\x04 \x01(\x01H\x03R\vl1FeeScalar\x88\x01\x01\x12-\n" +
+	"\x0fl1BaseFeeScalar\x18\x05 \x01(\x04H\x04R\x0fl1BaseFeeScalar\x88\x01\x01\x12)\n" +
+	"\rl1BlobBaseFee\x18\x06 \x01(\tH\x05R\rl1BlobBaseFee\x88\x01\x01\x125\n" +
+	"\x13l1BlobBaseFeeScalar\x18\a \x01(\x04H\x06R\x13l1BlobBaseFeeScalar\x88\x01\x01\x12'\n" +
+	"\fdepositNonce\x18\b \x01(\tH\aR\fdepositNonce\x88\x01\x01\x129\n" +
+	"\x15depositReceiptVersion\x18\t \x01(\tH\bR\x15depositReceiptVersion\x88\x01\x01\x12!\n" +
+	"\tl1FeeU256\x18\n" +
+	" \x01(\fH\tR\tl1FeeU256\x88\x01\x01\x12+\n" +
+	"\x0el1GasPriceU256\x18\v \x01(\fH\n" +
+	"R\x0el1GasPriceU256\x88\x01\x01\x121\n" +
+	"\x11l1BlobBaseFeeU256\x18\f \x01(\fH\vR\x11l1BlobBaseFeeU256\x88\x01\x01B\b\n" +
+	"\x06_l1FeeB\r\n" +
+	"\v_l1GasPriceB\f\n" +
+	"\n" +
+	"_l1GasUsedB\x0e\n" +
+	"\f_l1FeeScalarB\x12\n" +
+	"\x10_l1BaseFeeScalarB\x10\n" +
+	"\x0e_l1BlobBaseFeeB\x16\n" +
+	"\x14_l1BlobBaseFeeScalarB\x0f\n" +
+	"\r_depositNonceB\x18\n" +
+	"\x16_depositReceiptVersionB\f\n" +
+	"\n" +
+	"_l1FeeU256B\x11\n" +
+	"\x0f_l1GasPriceU256B\x14\n" +
+	"\x12_l1BlobBaseFeeU256\"\xc8\x01\n" +
+	"\x18ArbitrumReceiptExtension\x12'\n" +
+	"\fgasUsedForL1\x18\x01 \x01(\x04H\x00R\fgasUsedForL1\x88\x01\x01\x12)\n" +
+	"\rl1BlockNumber\x18\x02 \x01(\x04H\x01R\rl1BlockNumber\x88\x01\x01\x12%\n" +
+	"\vtimeboosted\x18\x03 \x01(\bH\x02R\vtimeboosted\x88\x01\x01B\x0f\n" +
+	"\r_gasUsedForL1B\x10\n" +
+	"\x0e_l1BlockNumberB\x0e\n" +
+	"\f_timeboosted\"\x8a\x01\n" +
+	"\x14CeloReceiptExtension\x12#\n" +
+	"\n" +
+	"gatewayFee\x18\x01 \x01(\tH\x00R\n" +
+	"gatewayFee\x88\x01\x01\x12+\n" +
+	"\x0egatewayFeeU256\x18\x02 \x01(\fH\x01R\x0egatewayFeeU256\x88\x01\x01B\r\n" +
+	"\v_gatewayFeeB\x11\n" +
+	"\x0f_gatewayFeeU256\"\x95\x01\n" +
+	"\x16ZkSyncReceiptExtension\x12)\n" +
+	"\rl1BatchNumber\x18\x01 \x01(\x04H\x00R\rl1BatchNumber\x88\x01\x01\x12+\n" +
+	"\x0el1BatchTxIndex\x18\x02 \x01(\x04H\x01R\x0el1BatchTxIndex\x88\x01\x01B\x10\n" +
+	"\x0e_l1BatchNumberB\x11\n" +
+	"\x0f_l1BatchTxIndex\"\xb6\x03\n" +
+	"\tCallFrame\x12%\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x11.bds.evm.CallTypeR\x04type\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\fR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\fR\x02to\x12\x19\n" +
+	"\x05value\x18\x04 \x01(\tH\x00R\x05value\x88\x01\x01\x12\x10\n" +
+	"\x03gas\x18\x05 \x01(\x04R\x03gas\x12\x18\n" +
+	"\agasUsed\x18\x06 \x01(\x04R\agasUsed\x12\x14\n" +
+	"\x05input\x18\a \x01(\fR\x05input\x12\x16\n" +
+	"\x06output\x18\b \x01(\fR\x06output\x12\x19\n" +
+	"\x05error\x18\t \x01(\tH\x01R\x05error\x88\x01\x01\x12'\n" +
+	"\frevertReason\x18\n" +
+	" \x01(\tH\x02R\frevertReason\x88\x01\x01\x12(\n" +
+	"\x05calls\x18\v \x03(\v2\x12.bds.evm.CallFrameR\x05calls\x12\"\n" +
+	"\ftraceAddress\x18\f \x03(\rR\ftraceAddress\x12#\n" +
+	"\n" +
+	"rewardType\x18\r \x01(\tH\x03R\n" +
+	"rewardType\x88\x01\x01B\b\n" +
+	"\x06_valueB\b\n" +
+	"\x06_errorB\x0f\n" +
+	"\r_revertReasonB\r\n" +
+	"\v_rewardType\"\xf4\x01\n" +
+	"\x05Trace\x12(\n" +
+	"\x0ftransactionHash\x18\x01 \x01(\fR\x0ftransactionHash\x12%\n" +
+	"\vblockNumber\x18\x02 \x01(\x04H\x00R\vblockNumber\x88\x01\x01\x12\x1c\n" +
+	"\tblockHash\x18\x03 \x01(\fR\tblockHash\x12/\n" +
+	"\x10transactionIndex\x18\x04 \x01(\rH\x01R\x10transactionIndex\x88\x01\x01\x12&\n" +
+	"\x04root\x18\x05 \x01(\v2\x12.bds.evm.CallFrameR\x04rootB\x0e\n" +
+	"\f_blockNumberB\x13\n" +
+	"\x11_transactionIndex\"Q\n" +
+	"\rStorageChange\x12\x12\n" +
+	"\x04slot\x18\x01 \x01(\fR\x04slot\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\fR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\fR\x05after\"\xd4\x03\n" +
+	"\vAccountDiff\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12)\n" +
+	"\rbalanceBefore\x18\x02 \x01(\tH\x00R\rbalanceBefore\x88\x01\x01\x12'\n" +
+	"\fbalanceAfter\x18\x03 \x01(\tH\x01R\fbalanceAfter\x88\x01\x01\x12%\n" +
+	"\vnonceBefore\x18\x04 \x01(\x04H\x02R\vnonceBefore\x88\x01\x01\x12#\n" +
+	"\n" +
+	"nonceAfter\x18\x05 \x01(\x04H\x03R\n" +
+	"nonceAfter\x88\x01\x01\x12#\n" +
+	"\n" +
+	"codeBefore\x18\x06 \x01(\fH\x04R\n" +
+	"codeBefore\x88\x01\x01\x12!\n" +
+	"\tcodeAfter\x18\a \x01(\fH\x05R\tcodeAfter\x88\x01\x01\x120\n" +
+	"\astorage\x18\b \x03(\v2\x16.bds.evm.StorageChangeR\astorage\x12\x18\n" +
+	"\acreated\x18\t \x01(\bR\acreated\x12\x18\n" +
+	"\adeleted\x18\n" +
+	" \x01(\bR\adeletedB\x10\n" +
+	"\x0e_balanceBeforeB\x0f\n" +
+	"\r_balanceAfterB\x0e\n" +
+	"\f_nonceBeforeB\r\n" +
+	"\v_nonceAfterB\r\n" +
+	"\v_codeBeforeB\f\n" +
+	"\n" +
+	"_codeAfter\"\x82\x02\n" +
+	"\tStateDiff\x12(\n" +
+	"\x0ftransactionHash\x18\x01 \x01(\fR\x0ftransactionHash\x12%\n" +
+	"\vblockNumber\x18\x02 \x01(\x04H\x00R\vblockNumber\x88\x01\x01\x12\x1c\n" +
+	"\tblockHash\x18\x03 \x01(\fR\tblockHash\x12/\n" +
+	"\x10transactionIndex\x18\x04 \x01(\rH\x01R\x10transactionIndex\x88\x01\x01\x120\n" +
+	"\baccounts\x18\x05 \x03(\v2\x14.bds.evm.AccountDiffR\baccountsB\x0e\n" +
+	"\f_blockNumberB\x13\n" +
+	"\x11_transactionIndex\"L\n" +
+	"\fStorageProof\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12\x14\n" +
+	"\x05proof\x18\x03 \x03(\fR\x05proof\"\xca\x02\n" +
+	"\fAccountProof\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12\x1a\n" +
+	"\bcodeHash\x18\x03 \x01(\fR\bcodeHash\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\x04R\x05nonce\x12 \n" +
+	"\vstorageHash\x18\x05 \x01(\fR\vstorageHash\x12\"\n" +
+	"\faccountProof\x18\x06 \x03(\fR\faccountProof\x129\n" +
+	"\fstorageProof\x18\a \x03(\v2\x15.bds.evm.StorageProofR\fstorageProof\x12%\n" +
+	"\vblockNumber\x18\b \x01(\x04H\x00R\vblockNumber\x88\x01\x01\x12\x1c\n" +
+	"\tblockHash\x18\t \x01(\fR\tblockHashB\x0e\n" +
+	"\f_blockNumber\"*\n" +
+	"\x10FeeHistoryReward\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x85\x02\n" +
+	"\n" +
+	"FeeHistory\x12 \n" +
+	"\voldestBlock\x18\x01 \x01(\x04R\voldestBlock\x12$\n" +
+	"\rbaseFeePerGas\x18\x02 \x03(\tR\rbaseFeePerGas\x12\"\n" +
+	"\fgasUsedRatio\x18\x03 \x03(\x01R\fgasUsedRatio\x121\n" +
+	"\x06reward\x18\x04 \x03(\v2\x19.bds.evm.FeeHistoryRewardR\x06reward\x12,\n" +
+	"\x11baseFeePerBlobGas\x18\x05 \x03(\tR\x11baseFeePerBlobGas\x12*\n" +
+	"\x10blobGasUsedRatio\x18\x06 \x03(\x01R\x10blobGasUsedRatio\"\xa9\x02\n" +
+	"\x12PendingTransaction\x126\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x14.bds.evm.TransactionR\vtransaction\x129\n" +
+	"\x06status\x18\x02 \x01(\x0e2!.bds.evm.PendingTransactionStatusR\x06status\x127\n" +
+	"\x14firstSeenTimestampMs\x18\x03 \x01(\x04H\x00R\x14firstSeenTimestampMs\x88\x01\x01\x12\x1a\n" +
+	"\breplaces\x18\x04 \x03(\fR\breplaces\x12#\n" +
+	"\n" +
+	"replacedBy\x18\x05 \x01(\fH\x01R\n" +
+	"replacedBy\x88\x01\x01B\x17\n" +
+	"\x15_firstSeenTimestampMsB\r\n" +
+	"\v_replacedBy*\xfb\x01\n" +
 	"\x0fTransactionType\x12\n" +
 	"\n" +
 	"\x06LEGACY\x10\x00\x12\x0f\n" +
 	"\vACCESS_LIST\x10\x01\x12\x0f\n" +
 	"\vDYNAMIC_FEE\x10\x02\x12\b\n" +
 	"\x04BLOB\x10\x03\x12\f\n" +
-	"\bSET_CODE\x10\x04B4Z2github.com/blockchain-data-standards/manifesto/evmb\x06proto3"
+	"\bSET_CODE\x10\x04\x12\x14\n" +
+	"\x10ARBITRUM_DEPOSIT\x10d\x12\x15\n" +
+	"\x11ARBITRUM_UNSIGNED\x10e\x12\x15\n" +
+	"\x11ARBITRUM_CONTRACT\x10f\x12\x12\n" +
+	"\x0eARBITRUM_RETRY\x10h\x12\x1d\n" +
+	"\x19ARBITRUM_SUBMIT_RETRYABLE\x10i\x12\x15\n" +
+	"\x11ARBITRUM_INTERNAL\x10j\x12\x14\n" +
+	"\x10OPTIMISM_DEPOSIT\x10~*{\n" +
+	"\bCallType\x12\b\n" +
+	"\x04CALL\x10\x00\x12\x0e\n" +
+	"\n" +
+	"STATICCALL\x10\x01\x12\x10\n" +
+	"\fDELEGATECALL\x10\x02\x12\f\n" +
+	"\bCALLCODE\x10\x03\x12\n" +
+	"\n" +
+	"\x06CREATE\x10\x04\x12\v\n" +
+	"\aCREATE2\x10\x05\x12\x10\n" +
+	"\fSELFDESTRUCT\x10\x06\x12\n" +
+	"\n" +
+	"\x06REWARD\x10\a*3\n" +
+	"\x18PendingTransactionStatus\x12\v\n" +
+	"\aPENDING\x10\x00\x12\n" +
+	"\n" +
+	"\x06QUEUED\x10\x01B4Z2github.com/blockchain-data-standards/manifesto/evmb\x06proto3"
 
 var (
 	file_models_proto_rawDescOnce sync.Once
@@ -2106,35 +4634,82 @@ func file_models_proto_rawDescGZIP() []byte {
 	return file_models_proto_rawDescData
 }
 
-var file_models_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_models_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_models_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_models_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_models_proto_goTypes = []any{
-	(TransactionType)(0),          // 0: bds.evm.TransactionType
-	(*BlockRef)(nil),              // 1: bds.evm.BlockRef
-	(*BlockHeader)(nil),           // 2: bds.evm.BlockHeader
-	(*Block)(nil),                 // 3: bds.evm.Block
-	(*TransactionRef)(nil),        // 4: bds.evm.TransactionRef
-	(*Transaction)(nil),           // 5: bds.evm.Transaction
-	(*AccessListItem)(nil),        // 6: bds.evm.AccessListItem
-	(*Log)(nil),                   // 7: bds.evm.Log
-	(*AuthorizationListItem)(nil), // 8: bds.evm.AuthorizationListItem
-	(*Withdrawal)(nil),            // 9: bds.evm.Withdrawal
-	(*Receipt)(nil),               // 10: bds.evm.Receipt
+	(TransactionType)(0),                 // 0: bds.evm.TransactionType
+	(CallType)(0),                        // 1: bds.evm.CallType
+	(PendingTransactionStatus)(0),        // 2: bds.evm.PendingTransactionStatus
+	(*BlockRef)(nil),                     // 3: bds.evm.BlockRef
+	(*BlockHeader)(nil),                  // 4: bds.evm.BlockHeader
+	(*Block)(nil),                        // 5: bds.evm.Block
+	(*TransactionRef)(nil),               // 6: bds.evm.TransactionRef
+	(*Transaction)(nil),                  // 7: bds.evm.Transaction
+	(*AccessListItem)(nil),               // 8: bds.evm.AccessListItem
+	(*Log)(nil),                          // 9: bds.evm.Log
+	(*AuthorizationListItem)(nil),        // 10: bds.evm.AuthorizationListItem
+	(*Withdrawal)(nil),                   // 11: bds.evm.Withdrawal
+	(*Receipt)(nil),                      // 12: bds.evm.Receipt
+	(*OptimismTransactionExtension)(nil), // 13: bds.evm.OptimismTransactionExtension
+	(*ArbitrumTransactionExtension)(nil), // 14: bds.evm.ArbitrumTransactionExtension
+	(*CeloTransactionExtension)(nil),     // 15: bds.evm.CeloTransactionExtension
+	(*ZkSyncTransactionExtension)(nil),   // 16: bds.evm.ZkSyncTransactionExtension
+	(*PolygonTransactionExtension)(nil),  // 17: bds.evm.PolygonTransactionExtension
+	(*PolygonReceiptExtension)(nil),      // 18: bds.evm.PolygonReceiptExtension
+	(*OptimismReceiptExtension)(nil),     // 19: bds.evm.OptimismReceiptExtension
+	(*ArbitrumReceiptExtension)(nil),     // 20: bds.evm.ArbitrumReceiptExtension
+	(*CeloReceiptExtension)(nil),         // 21: bds.evm.CeloReceiptExtension
+	(*ZkSyncReceiptExtension)(nil),       // 22: bds.evm.ZkSyncReceiptExtension
+	(*CallFrame)(nil),                    // 23: bds.evm.CallFrame
+	(*Trace)(nil),                        // 24: bds.evm.Trace
+	(*StorageChange)(nil),                // 25: bds.evm.StorageChange
+	(*AccountDiff)(nil),                  // 26: bds.evm.AccountDiff
+	(*StateDiff)(nil),                    // 27: bds.evm.StateDiff
+	(*StorageProof)(nil),                 // 28: bds.evm.StorageProof
+	(*AccountProof)(nil),                 // 29: bds.evm.AccountProof
+	(*FeeHistoryReward)(nil),             // 30: bds.evm.FeeHistoryReward
+	(*FeeHistory)(nil),                   // 31: bds.evm.FeeHistory
+	(*PendingTransaction)(nil),           // 32: bds.evm.PendingTransaction
+	nil,                                  // 33: bds.evm.BlockHeader.ExtensionsEntry
+	nil,                                  // 34: bds.evm.Log.ExtensionsEntry
+	nil,                                  // 35: bds.evm.Receipt.ExtensionsEntry
 }
 var file_models_proto_depIdxs = []int32{
-	2, // 0: bds.evm.Block.header:type_name -> bds.evm.BlockHeader
-	5, // 1: bds.evm.Block.fullTransactions:type_name -> bds.evm.Transaction
-	7, // 2: bds.evm.Block.logs:type_name -> bds.evm.Log
-	9, // 3: bds.evm.Block.withdrawals:type_name -> bds.evm.Withdrawal
-	1, // 4: bds.evm.TransactionRef.block:type_name -> bds.evm.BlockRef
-	6, // 5: bds.evm.Transaction.accessList:type_name -> bds.evm.AccessListItem
-	8, // 6: bds.evm.Transaction.authorizationList:type_name -> bds.evm.AuthorizationListItem
-	7, // 7: bds.evm.Receipt.logs:type_name -> bds.evm.Log
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	33, // 0: bds.evm.BlockHeader.extensions:type_name -> bds.evm.BlockHeader.ExtensionsEntry
+	4,  // 1: bds.evm.Block.header:type_name -> bds.evm.BlockHeader
+	7,  // 2: bds.evm.Block.fullTransactions:type_name -> bds.evm.Transaction
+	9,  // 3: bds.evm.Block.logs:type_name -> bds.evm.Log
+	11, // 4: bds.evm.Block.withdrawals:type_name -> bds.evm.Withdrawal
+	3,  // 5: bds.evm.TransactionRef.block:type_name -> bds.evm.BlockRef
+	8,  // 6: bds.evm.Transaction.accessList:type_name -> bds.evm.AccessListItem
+	10, // 7: bds.evm.Transaction.authorizationList:type_name -> bds.evm.AuthorizationListItem
+	13, // 8: bds.evm.Transaction.optimism:type_name -> bds.evm.OptimismTransactionExtension
+	14, // 9: bds.evm.Transaction.arbitrum:type_name -> bds.evm.ArbitrumTransactionExtension
+	15, // 10: bds.evm.Transaction.celo:type_name -> bds.evm.CeloTransactionExtension
+	16, // 11: bds.evm.Transaction.zkSync:type_name -> bds.evm.ZkSyncTransactionExtension
+	17, // 12: bds.evm.Transaction.polygon:type_name -> bds.evm.PolygonTransactionExtension
+	34, // 13: bds.evm.Log.extensions:type_name -> bds.evm.Log.ExtensionsEntry
+	9,  // 14: bds.evm.Receipt.logs:type_name -> bds.evm.Log
+	35, // 15: bds.evm.Receipt.extensions:type_name -> bds.evm.Receipt.ExtensionsEntry
+	19, // 16: bds.evm.Receipt.optimism:type_name -> bds.evm.OptimismReceiptExtension
+	20, // 17: bds.evm.Receipt.arbitrum:type_name -> bds.evm.ArbitrumReceiptExtension
+	21, // 18: bds.evm.Receipt.celo:type_name -> bds.evm.CeloReceiptExtension
+	22, // 19: bds.evm.Receipt.zkSync:type_name -> bds.evm.ZkSyncReceiptExtension
+	18, // 20: bds.evm.Receipt.polygon:type_name -> bds.evm.PolygonReceiptExtension
+	1,  // 21: bds.evm.CallFrame.type:type_name -> bds.evm.CallType
+	23, // 22: bds.evm.CallFrame.calls:type_name -> bds.evm.CallFrame
+	23, // 23: bds.evm.Trace.root:type_name -> bds.evm.CallFrame
+	25, // 24: bds.evm.AccountDiff.storage:type_name -> bds.evm.StorageChange
+	26, // 25: bds.evm.StateDiff.accounts:type_name -> bds.evm.AccountDiff
+	28, // 26: bds.evm.AccountProof.storageProof:type_name -> bds.evm.StorageProof
+	30, // 27: bds.evm.FeeHistory.reward:type_name -> bds.evm.FeeHistoryReward
+	7,  // 28: bds.evm.PendingTransaction.transaction:type_name -> bds.evm.Transaction
+	2,  // 29: bds.evm.PendingTransaction.status:type_name -> bds.evm.PendingTransactionStatus
+	30, // [30:30] is the sub-list for method output_type
+	30, // [30:30] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_models_proto_init() }
@@ -2146,13 +4721,27 @@ func file_models_proto_init() {
 	file_models_proto_msgTypes[4].OneofWrappers = []any{}
 	file_models_proto_msgTypes[6].OneofWrappers = []any{}
 	file_models_proto_msgTypes[9].OneofWrappers = []any{}
+	file_models_proto_msgTypes[10].OneofWrappers = []any{}
+	file_models_proto_msgTypes[11].OneofWrappers = []any{}
+	file_models_proto_msgTypes[12].OneofWrappers = []any{}
+	file_models_proto_msgTypes[13].OneofWrappers = []any{}
+	file_models_proto_msgTypes[16].OneofWrappers = []any{}
+	file_models_proto_msgTypes[17].OneofWrappers = []any{}
+	file_models_proto_msgTypes[18].OneofWrappers = []any{}
+	file_models_proto_msgTypes[19].OneofWrappers = []any{}
+	file_models_proto_msgTypes[20].OneofWrappers = []any{}
+	file_models_proto_msgTypes[21].OneofWrappers = []any{}
+	file_models_proto_msgTypes[23].OneofWrappers = []any{}
+	file_models_proto_msgTypes[24].OneofWrappers = []any{}
+	file_models_proto_msgTypes[26].OneofWrappers = []any{}
+	file_models_proto_msgTypes[29].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_models_proto_rawDesc), len(file_models_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      3,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,235 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// EmptyTrieRoot is the root hash of an empty Merkle-Patricia trie (Keccak-256 of RLP("")).
+	EmptyTrieRoot = MustHexToBytes("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+	// EmptyCodeHash is the code hash of an account without code (Keccak-256 of no bytes).
+	EmptyCodeHash = MustHexToBytes("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+)
+
+// VerifyAccountProof checks an eth_getProof result against header.StateRoot: the account
+// proof must lead to exactly the reported nonce, balance, storageHash and codeHash (or prove
+// the account absent when they are empty), and every storage proof must lead from storageHash
+// to the reported slot value.
+func VerifyAccountProof(header *BlockHeader, proof *AccountProof) error {
+	if header == nil {
+		return errors.New("block header is required")
+	}
+	if proof == nil {
+		return errors.New("account proof is required")
+	}
+	if len(proof.BlockHash) > 0 && len(header.Hash) > 0 && !bytes.Equal(proof.BlockHash, header.Hash) {
+		return fmt.Errorf("proof is for block %s, not %s", BytesToHex(proof.BlockHash), BytesToHex(header.Hash))
+	}
+
+	leaf, err := verifyTrieProof(header.StateRoot, proof.Address, proof.AccountProof)
+	if err != nil {
+		return fmt.Errorf("invalid account proof for %s: %w", BytesToHex(proof.Address), err)
+	}
+	balance, ok := parseWei(proof.Balance)
+	if !ok {
+		return fmt.Errorf("invalid balance %q", proof.Balance)
+	}
+	storageRoot := proof.StorageHash
+	if leaf == nil {
+		if proof.Nonce != 0 || balance.Sign() != 0 || !isEmptyHash(proof.CodeHash, EmptyCodeHash) || !isEmptyHash(proof.StorageHash, EmptyTrieRoot) {
+			return fmt.Errorf("account %s is absent from the state trie but the proof reports state for it", BytesToHex(proof.Address))
+		}
+		storageRoot = EmptyTrieRoot
+	} else {
+		var payload []byte
+		payload = rlpAppendUint(payload, proof.Nonce)
+		payload = rlpAppendBigInt(payload, balance)
+		payload = rlpAppendBytes(payload, proof.StorageHash)
+		payload = rlpAppendBytes(payload, proof.CodeHash)
+		if !bytes.Equal(leaf, rlpAppendList(nil, payload)) {
+			return fmt.Errorf("account %s in the state trie does not match the reported nonce, balance, storageHash and codeHash", BytesToHex(proof.Address))
+		}
+	}
+
+	for i, sp := range proof.StorageProof {
+		if err := verifyStorageProof(storageRoot, sp); err != nil {
+			return fmt.Errorf("invalid storageProof[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func verifyStorageProof(storageRoot []byte, sp *StorageProof) error {
+	if sp == nil {
+		return errors.New("storage proof is nil")
+	}
+	if len(sp.Key) > 32 || len(sp.Value) > 32 {
+		return errors.New("storage key and value must be at most 32 bytes")
+	}
+	key := make([]byte, 32)
+	copy(key[32-len(sp.Key):], sp.Key)
+	leaf, err := verifyTrieProof(storageRoot, key, sp.Proof)
+	if err != nil {
+		return err
+	}
+	want := bytes.TrimLeft(sp.Value, "\x00")
+	if leaf == nil {
+		if len(want) != 0 {
+			return fmt.Errorf("slot %s is absent from the storage trie but the proof reports a value", BytesToHex(sp.Key))
+		}
+		return nil
+	}
+	got, err := rlpDecodeBytes(leaf)
+	if err != nil {
+		return fmt.Errorf("invalid storage leaf: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("slot %s holds %s in the storage trie, not %s", BytesToHex(sp.Key), BytesToQuantityHex(got), BytesToQuantityHex(want))
+	}
+	return nil
+}
+
+// verifyTrieProof follows the secure-trie path Keccak256(key) from root through the proof
+// nodes and returns the leaf value, or nil when the proof shows that the key is absent.
+// Proof nodes are looked up by hash, so their order does not matter and extra nodes are ignored.
+func verifyTrieProof(root, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[string][]byte, len(proof))
+	for _, n := range proof {
+		nodes[string(Keccak256(n))] = n
+	}
+	path := keyNibbles(Keccak256(key))
+
+	ref := root
+	for depth := 0; ; depth++ {
+		var node []byte
+		if len(ref) == 32 {
+			n, ok := nodes[string(ref)]
+			if !ok {
+				if depth == 0 && bytes.Equal(ref, EmptyTrieRoot) {
+					return nil, nil
+				}
+				return nil, fmt.Errorf("missing trie node %s", BytesToHex(ref))
+			}
+			node = n
+		} else {
+			// Nodes shorter than 32 bytes are embedded in their parent
+			node = ref
+		}
+
+		items, err := rlpDecodeList(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trie node at depth %d: %w", depth, err)
+		}
+		switch len(items) {
+		case 17:
+			if len(path) == 0 {
+				value, err := rlpDecodeBytes(items[16])
+				if err != nil || len(value) == 0 {
+					return nil, err
+				}
+				return value, nil
+			}
+			ref, err = trieChildRef(items[path[0]])
+			if err != nil {
+				return nil, err
+			}
+			path = path[1:]
+		case 2:
+			encoded, err := rlpDecodeBytes(items[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid trie node path: %w", err)
+			}
+			nibbles, isLeaf, err := compactToNibbles(encoded)
+			if err != nil {
+				return nil, err
+			}
+			if isLeaf {
+				if !bytes.Equal(nibbles, path) {
+					return nil, nil
+				}
+				return rlpDecodeBytes(items[1])
+			}
+			if !bytes.HasPrefix(path, nibbles) {
+				return nil, nil
+			}
+			path = path[len(nibbles):]
+			ref, err = trieChildRef(items[1])
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("trie node at depth %d has %d items", depth, len(items))
+		}
+		if ref == nil {
+			return nil, nil
+		}
+	}
+}
+
+// trieChildRef returns the reference held in a branch slot or extension: a 32-byte hash, the
+// encoding of an embedded node, or nil for an empty slot.
+func trieChildRef(item []byte) ([]byte, error) {
+	isList, content, _, err := rlpSplit(item)
+	if err != nil {
+		return nil, err
+	}
+	if isList {
+		return item, nil
+	}
+	if len(content) != 0 && len(content) != 32 {
+		return nil, fmt.Errorf("invalid trie child reference of %d bytes", len(content))
+	}
+	if len(content) == 0 {
+		return nil, nil
+	}
+	return content, nil
+}
+
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[2*i] = b >> 4
+		nibbles[2*i+1] = b & 0x0f
+	}
+	return nibbles
+}
+
+// compactToNibbles decodes the hex-prefix encoding of a leaf or extension path.
+func compactToNibbles(encoded []byte) (nibbles []byte, isLeaf bool, err error) {
+	if len(encoded) == 0 {
+		return nil, false, errors.New("empty hex-prefix path")
+	}
+	flag := encoded[0] >> 4
+	if flag > 3 {
+		return nil, false, fmt.Errorf("invalid hex-prefix flag %d", flag)
+	}
+	isLeaf = flag >= 2
+	all := keyNibbles(encoded)
+	if flag&1 == 1 {
+		return all[1:], isLeaf, nil
+	}
+	return all[2:], isLeaf, nil
+}
+
+func isEmptyHash(h, empty []byte) bool {
+	return len(h) == 0 || bytes.Equal(h, empty) || isZeroWord(h)
+}
+
+// parseWei parses a decimal or 0x-prefixed hex amount; an empty string is zero.
+func parseWei(s string) (*big.Int, bool) {
+	if s == "" {
+		return new(big.Int), true
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		if RemoveHexPrefix(s) == "" {
+			return new(big.Int), true
+		}
+		return new(big.Int).SetString(RemoveHexPrefix(s), 16)
+	}
+	return new(big.Int).SetString(s, 10)
+}
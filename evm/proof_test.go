@@ -0,0 +1,293 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// testTrie builds a secure Merkle-Patricia trie in memory and records every hashed node, which
+// is a valid (if oversized) proof for any key since the verifier ignores unused nodes.
+type testTrie struct {
+	entries map[string][]byte
+	nodes   [][]byte
+}
+
+func newTestTrie() *testTrie {
+	return &testTrie{entries: map[string][]byte{}}
+}
+
+func (tr *testTrie) put(key, value []byte) {
+	tr.entries[string(keyNibbles(Keccak256(key)))] = value
+}
+
+func (tr *testTrie) root() []byte {
+	tr.nodes = nil
+	if len(tr.entries) == 0 {
+		return EmptyTrieRoot
+	}
+	var paths []string
+	for p := range tr.entries {
+		paths = append(paths, p)
+	}
+	node := tr.build(paths, 0)
+	tr.nodes = append(tr.nodes, node)
+	return Keccak256(node)
+}
+
+func (tr *testTrie) build(paths []string, depth int) []byte {
+	if len(paths) == 1 {
+		var payload []byte
+		payload = rlpAppendBytes(payload, testCompact([]byte(paths[0][depth:]), true))
+		payload = rlpAppendBytes(payload, tr.entries[paths[0]])
+		return rlpAppendList(nil, payload)
+	}
+	prefix := len(paths[0])
+	for _, p := range paths[1:] {
+		n := depth
+		for n < prefix && p[n] == paths[0][n] {
+			n++
+		}
+		prefix = n
+	}
+	if prefix > depth {
+		var payload []byte
+		payload = rlpAppendBytes(payload, testCompact([]byte(paths[0][depth:prefix]), false))
+		payload = append(payload, tr.ref(tr.build(paths, prefix))...)
+		return rlpAppendList(nil, payload)
+	}
+	var groups [16][]string
+	for _, p := range paths {
+		groups[p[depth]] = append(groups[p[depth]], p)
+	}
+	var payload []byte
+	for _, g := range groups {
+		if len(g) == 0 {
+			payload = append(payload, 0x80)
+			continue
+		}
+		payload = append(payload, tr.ref(tr.build(g, depth+1))...)
+	}
+	payload = append(payload, 0x80)
+	return rlpAppendList(nil, payload)
+}
+
+func (tr *testTrie) ref(node []byte) []byte {
+	if len(node) < 32 {
+		return node
+	}
+	tr.nodes = append(tr.nodes, node)
+	return rlpAppendBytes(nil, Keccak256(node))
+}
+
+func testCompact(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		nibbles = append([]byte{flag + 1}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+	out := make([]byte, len(nibbles)/2)
+	for i := range out {
+		out[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return out
+}
+
+func testAccountLeaf(nonce uint64, balance string, storageHash, codeHash []byte) []byte {
+	b, _ := parseWei(balance)
+	var payload []byte
+	payload = rlpAppendUint(payload, nonce)
+	payload = rlpAppendBigInt(payload, b)
+	payload = rlpAppendBytes(payload, storageHash)
+	payload = rlpAppendBytes(payload, codeHash)
+	return rlpAppendList(nil, payload)
+}
+
+// testAccountProof builds a state with several accounts, one of which has storage, and returns
+// the header and the proof for that account and two of its slots (one set, one empty).
+func testAccountProof(t *testing.T) (*BlockHeader, *AccountProof) {
+	t.Helper()
+	address := MustHexToBytes("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	codeHash := Keccak256([]byte{0x60, 0x80, 0x60, 0x40})
+
+	storage := newTestTrie()
+	slot := make([]byte, 32)
+	slot[31] = 0x01
+	storage.put(slot, rlpAppendBytes(nil, []byte{0x12, 0x34}))
+	for i := byte(2); i < 40; i++ {
+		key := make([]byte, 32)
+		key[31] = i
+		storage.put(key, rlpAppendBytes(nil, []byte{i}))
+	}
+	storageRoot := storage.root()
+
+	state := newTestTrie()
+	state.put(address, testAccountLeaf(7, "1000000000000000000", storageRoot, codeHash))
+	for i := byte(0); i < 50; i++ {
+		other := make([]byte, 20)
+		other[0] = i
+		state.put(other, testAccountLeaf(uint64(i), "1", EmptyTrieRoot, EmptyCodeHash))
+	}
+	stateRoot := state.root()
+
+	header := &BlockHeader{
+		Number:    21_000_000,
+		Hash:      MustHexToBytes("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+		StateRoot: stateRoot,
+	}
+	emptySlot := make([]byte, 32)
+	emptySlot[31] = 0xff
+	proof := &AccountProof{
+		Address:      address,
+		Balance:      "1000000000000000000",
+		CodeHash:     codeHash,
+		Nonce:        7,
+		StorageHash:  storageRoot,
+		AccountProof: state.nodes,
+		StorageProof: []*StorageProof{
+			{Key: []byte{0x01}, Value: MustHexToBytes("0x0000000000000000000000000000000000000000000000000000000000001234"), Proof: storage.nodes},
+			{Key: emptySlot, Value: make([]byte, 32), Proof: storage.nodes},
+		},
+	}
+	return header, proof
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		if err := VerifyAccountProof(header, proof); err != nil {
+			t.Fatalf("Expected valid proof, got %v", err)
+		}
+	})
+
+	t.Run("HexBalance", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		proof.Balance = "0xde0b6b3a7640000"
+		if err := VerifyAccountProof(header, proof); err != nil {
+			t.Fatalf("Expected valid proof, got %v", err)
+		}
+	})
+
+	t.Run("TamperedBalance", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		proof.Balance = "1000000000000000001"
+		if err := VerifyAccountProof(header, proof); err == nil {
+			t.Fatal("Expected tampered balance to fail")
+		}
+	})
+
+	t.Run("TamperedStorageValue", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		proof.StorageProof[0].Value = MustHexToBytes("0x1235")
+		err := VerifyAccountProof(header, proof)
+		if err == nil || !strings.Contains(err.Error(), "storageProof[0]") {
+			t.Fatalf("Expected storageProof[0] error, got %v", err)
+		}
+	})
+
+	t.Run("NonZeroAbsentSlot", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		proof.StorageProof[1].Value = []byte{0x01}
+		if err := VerifyAccountProof(header, proof); err == nil {
+			t.Fatal("Expected value for absent slot to fail")
+		}
+	})
+
+	t.Run("WrongStateRoot", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		header.StateRoot = Keccak256([]byte("other"))
+		if err := VerifyAccountProof(header, proof); err == nil {
+			t.Fatal("Expected wrong state root to fail")
+		}
+	})
+
+	t.Run("WrongBlock", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		proof.BlockHash = Keccak256([]byte("other"))
+		if err := VerifyAccountProof(header, proof); err == nil {
+			t.Fatal("Expected mismatched block hash to fail")
+		}
+	})
+
+	t.Run("AbsentAccount", func(t *testing.T) {
+		header, proof := testAccountProof(t)
+		proof.Address = MustHexToBytes("0x0000000000000000000000000000000000000bad")
+		proof.Balance = "0x0"
+		proof.Nonce = 0
+		proof.CodeHash = EmptyCodeHash
+		proof.StorageHash = EmptyTrieRoot
+		proof.StorageProof = []*StorageProof{{Key: []byte{0x01}, Value: make([]byte, 32)}}
+		if err := VerifyAccountProof(header, proof); err != nil {
+			t.Fatalf("Expected valid absence proof, got %v", err)
+		}
+
+		proof.Balance = "0x1"
+		if err := VerifyAccountProof(header, proof); err == nil {
+			t.Fatal("Expected absent account with a balance to fail")
+		}
+	})
+
+	t.Run("EmptyState", func(t *testing.T) {
+		header := &BlockHeader{StateRoot: EmptyTrieRoot}
+		proof := &AccountProof{Address: MustHexToBytes("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")}
+		if err := VerifyAccountProof(header, proof); err != nil {
+			t.Fatalf("Expected empty state to prove absence, got %v", err)
+		}
+	})
+}
+
+func TestJsonRpcAccountProof(t *testing.T) {
+	header, proof := testAccountProof(t)
+	out, err := json.Marshal(AccountProofToJsonRpc(proof))
+	if err != nil {
+		t.Fatalf("Failed to marshal proof: %v", err)
+	}
+
+	var jp JsonRpcAccountProof
+	if err := json.Unmarshal(out, &jp); err != nil {
+		t.Fatalf("Failed to unmarshal proof: %v", err)
+	}
+	if jp.Balance != "0xde0b6b3a7640000" || jp.Nonce != "0x7" {
+		t.Errorf("Unexpected balance/nonce: %s, %s", jp.Balance, jp.Nonce)
+	}
+	if jp.StorageProof[0].Value != "0x1234" || jp.StorageProof[1].Value != "0x0" {
+		t.Errorf("Unexpected storage values: %s, %s", jp.StorageProof[0].Value, jp.StorageProof[1].Value)
+	}
+
+	parsed, err := jp.ToProto(header)
+	if err != nil {
+		t.Fatalf("Failed to convert proof: %v", err)
+	}
+	if parsed.GetBlockNumber() != header.Number || !bytes.Equal(parsed.BlockHash, header.Hash) {
+		t.Error("Expected block context from the header")
+	}
+	if !bytes.Equal(parsed.StorageProof[0].Value, proof.StorageProof[0].Value) {
+		t.Errorf("Expected 32-byte storage value, got %x", parsed.StorageProof[0].Value)
+	}
+	if err := VerifyAccountProof(header, parsed); err != nil {
+		t.Fatalf("Expected round-tripped proof to verify, got %v", err)
+	}
+	if b, _ := parseWei(parsed.Balance); b.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("Unexpected balance %s", parsed.Balance)
+	}
+
+	jp.StorageProof[1].Proof[0] = "0xzz"
+	_, err = jp.ToProto(nil)
+	if err == nil || !strings.Contains(err.Error(), "storageProof[1].proof[0]") {
+		t.Errorf("Expected storageProof[1].proof[0] error, got %v", err)
+	}
+
+	if out := AccountProofToJsonRpc(nil); out != nil {
+		t.Errorf("Expected nil for a nil proof, got %v", out)
+	}
+	empty := AccountProofToJsonRpc(&AccountProof{StorageProof: []*StorageProof{nil}})
+	if sp := empty["storageProof"].([]interface{}); len(sp) != 1 || sp[0].(map[string]interface{})["value"] != "0x0" {
+		t.Errorf("Expected an empty entry for a nil storage proof, got %v", sp)
+	}
+}
@@ -0,0 +1,101 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Receipts are hashed into the receipts root in their consensus encoding, which keeps only the
+// outcome of the transaction:
+//
+//	[postStateOrStatus, cumulativeGasUsed, logsBloom, [[address, [topics...], data]...]]
+//
+// Receipts before Byzantium (EIP-658) carry the 32-byte post-transaction state root, later ones
+// a status of 1 or 0 (encoded as the empty string). Typed receipts are prefixed with the type
+// byte of their transaction, like typed transactions.
+
+// EncodeReceipt returns the consensus encoding of r. The logsBloom is computed from the logs
+// when r has none. Receipts of deposit and other chain-specific types are not supported.
+// Errors are common.BaseError values with code INVALID_PARAMETER listing every missing or
+// invalid field under the paths detail.
+func EncodeReceipt(r *Receipt) ([]byte, error) {
+	if r == nil {
+		return nil, errors.New("receipt is nil")
+	}
+	p := newFieldParser(true)
+	enc := appendReceipt(p, nil, r)
+	return enc, p.err()
+}
+
+func appendReceipt(p *fieldParser, dst []byte, r *Receipt) []byte {
+	typ := r.TransactionType()
+	if !isEncodableTransactionType(typ) {
+		p.fail("type", fmt.Errorf("encoding %s receipts is not supported", typ))
+		return dst
+	}
+
+	var payload []byte
+	switch {
+	case len(r.Root) > 0:
+		payload = appendRlpFixed(p, payload, "root", r.Root, HashLength)
+	case r.Status != nil:
+		if *r.Status > 1 {
+			p.fail("status", fmt.Errorf("expected 0 or 1, got %d", *r.Status))
+		}
+		payload = rlpAppendUint(payload, uint64(*r.Status))
+	default:
+		p.fail("status", errors.New("receipts require status or root"))
+	}
+	payload = rlpAppendUint(payload, r.CumulativeGasUsed)
+	if len(r.LogsBloom) == 0 {
+		payload = rlpAppendBytes(payload, logsBloom(r.Logs))
+	} else {
+		payload = appendRlpFixed(p, payload, "logsBloom", r.LogsBloom, BloomLength)
+	}
+
+	var logs []byte
+	for i, l := range r.Logs {
+		lp := p.item("logs", i)
+		if l == nil {
+			lp.fail("", errors.New("log is nil"))
+			continue
+		}
+		var item, topics []byte
+		item = appendRlpFixed(lp, item, "address", l.Address, AddressLength)
+		for j, topic := range l.Topics {
+			topics = appendRlpFixed(lp, topics, fmt.Sprintf("topics[%d]", j), topic, HashLength)
+		}
+		item = rlpAppendList(item, topics)
+		item = rlpAppendBytes(item, l.Data)
+		logs = rlpAppendList(logs, item)
+	}
+	payload = rlpAppendList(payload, logs)
+
+	if typ != TransactionType_LEGACY {
+		dst = append(dst, byte(typ))
+	}
+	return rlpAppendList(dst, payload)
+}
+
+// logsBloom returns the 2048-bit bloom filter of the addresses and topics of logs: each value
+// sets the three bits selected by the low 11 bits of the first three byte pairs of its hash.
+func logsBloom(logs []*Log) []byte {
+	bloom := make([]byte, BloomLength)
+	add := func(b []byte) {
+		h := Keccak256(b)
+		for i := 0; i < 6; i += 2 {
+			bit := (uint(h[i])<<8 | uint(h[i+1])) & 2047
+			bloom[255-bit/8] |= 1 << (bit % 8)
+		}
+	}
+	for _, l := range logs {
+		if l == nil {
+			continue
+		}
+		add(l.Address)
+		for _, topic := range l.Topics {
+			add(topic)
+		}
+	}
+	return bloom
+}
@@ -0,0 +1,164 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Minimal RLP (Recursive Length Prefix) support for the structures hashed by Ethereum:
+// trie nodes, accounts, headers and transactions.
+
+var errRlpTruncated = errors.New("rlp: value is truncated")
+
+// rlpSplit decodes the first RLP item of b, returning whether it is a list, its payload and
+// the bytes following it.
+func rlpSplit(b []byte) (isList bool, content []byte, rest []byte, err error) {
+	if len(b) == 0 {
+		return false, nil, nil, errRlpTruncated
+	}
+	prefix := b[0]
+	var offset, size uint64
+	switch {
+	case prefix < 0x80:
+		return false, b[:1], b[1:], nil
+	case prefix < 0xb8:
+		offset, size = 1, uint64(prefix-0x80)
+		if size == 1 && len(b) > 1 && b[1] < 0x80 {
+			return false, nil, nil, errors.New("rlp: single byte below 0x80 must not be prefixed")
+		}
+	case prefix < 0xc0:
+		offset, size, err = rlpLongSize(b, int(prefix-0xb7))
+		if err != nil {
+			return false, nil, nil, err
+		}
+	case prefix < 0xf8:
+		isList = true
+		offset, size = 1, uint64(prefix-0xc0)
+	default:
+		isList = true
+		offset, size, err = rlpLongSize(b, int(prefix-0xf7))
+		if err != nil {
+			return false, nil, nil, err
+		}
+	}
+	if size > uint64(len(b))-offset {
+		return false, nil, nil, errRlpTruncated
+	}
+	return isList, b[offset : offset+size], b[offset+size:], nil
+}
+
+func rlpLongSize(b []byte, lenOfLen int) (offset, size uint64, err error) {
+	if len(b) < 1+lenOfLen {
+		return 0, 0, errRlpTruncated
+	}
+	if b[1] == 0 {
+		return 0, 0, errors.New("rlp: length has leading zero bytes")
+	}
+	for _, c := range b[1 : 1+lenOfLen] {
+		if size > (1<<56)-1 {
+			return 0, 0, errors.New("rlp: length overflows")
+		}
+		size = size<<8 | uint64(c)
+	}
+	if size < 56 {
+		return 0, 0, errors.New("rlp: long form used for short value")
+	}
+	return uint64(1 + lenOfLen), size, nil
+}
+
+// rlpListItems splits a list payload into the raw encodings of its items.
+func rlpListItems(content []byte) ([][]byte, error) {
+	var items [][]byte
+	for len(content) > 0 {
+		_, _, rest, err := rlpSplit(content)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, content[:len(content)-len(rest)])
+		content = rest
+	}
+	return items, nil
+}
+
+// rlpDecodeList decodes b as a single RLP list and returns the raw encodings of its items.
+func rlpDecodeList(b []byte) ([][]byte, error) {
+	isList, content, rest, err := rlpSplit(b)
+	if err != nil {
+		return nil, err
+	}
+	if !isList {
+		return nil, errors.New("rlp: expected a list")
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("rlp: %d trailing bytes after list", len(rest))
+	}
+	return rlpListItems(content)
+}
+
+// rlpDecodeBytes decodes b as a single RLP string.
+func rlpDecodeBytes(b []byte) ([]byte, error) {
+	isList, content, rest, err := rlpSplit(b)
+	if err != nil {
+		return nil, err
+	}
+	if isList {
+		return nil, errors.New("rlp: expected a string")
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("rlp: %d trailing bytes after string", len(rest))
+	}
+	return content, nil
+}
+
+// rlpAppendBytes appends the RLP encoding of the string b.
+func rlpAppendBytes(dst []byte, b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return append(dst, b[0])
+	}
+	dst = rlpAppendHeader(dst, 0x80, uint64(len(b)))
+	return append(dst, b...)
+}
+
+// rlpAppendUint appends the RLP encoding of an unsigned integer (big-endian, no leading zeros).
+func rlpAppendUint(dst []byte, v uint64) []byte {
+	if v == 0 {
+		return append(dst, 0x80)
+	}
+	if v < 0x80 {
+		return append(dst, byte(v))
+	}
+	n := (64 - bits.LeadingZeros64(v) + 7) / 8
+	dst = append(dst, 0x80+byte(n))
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, byte(v>>(8*uint(i))))
+	}
+	return dst
+}
+
+// rlpAppendBigInt appends the RLP encoding of a non-negative integer.
+func rlpAppendBigInt(dst []byte, v *big.Int) []byte {
+	if v == nil || v.Sign() == 0 {
+		return append(dst, 0x80)
+	}
+	return rlpAppendBytes(dst, v.Bytes())
+}
+
+// rlpAppendList appends a list header for payload followed by the payload itself.
+func rlpAppendList(dst []byte, payload []byte) []byte {
+	dst = rlpAppendHeader(dst, 0xc0, uint64(len(payload)))
+	return append(dst, payload...)
+}
+
+func rlpAppendHeader(dst []byte, base byte, size uint64) []byte {
+	if size < 56 {
+		return append(dst, base+byte(size))
+	}
+	n := (64 - bits.LeadingZeros64(size) + 7) / 8
+	dst = append(dst, base+55+byte(n))
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, byte(size>>(8*uint(i))))
+	}
+	return dst
+}
@@ -115,7 +115,7 @@ func (x *ChainIdResponse) GetGenesisHash() []byte {
 // Request for getting a block by number
 type GetBlockByNumberRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The block number to retrieve (hex number or "latest", "earliest", "pending" tags)
+	// The block number to retrieve (hex number or "latest", "earliest", "pending", "safe", "finalized" tags)
 	BlockNumber string `protobuf:"bytes,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
 	// Whether to include full transaction details (if false, only transaction hashes are returned)
 	IncludeTransactions bool `protobuf:"varint,2,opt,name=includeTransactions,proto3" json:"includeTransactions,omitempty"`
@@ -545,6 +545,262 @@ func (x *GetLogsResponse) GetLogs() []*Log {
 	return nil
 }
 
+// Request for getting the fee history of a block range
+type GetFeeHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of blocks in the range, ending at newestBlock
+	BlockCount uint64 `protobuf:"varint,1,opt,name=blockCount,proto3" json:"blockCount,omitempty"`
+	// The newest block of the range (hex number or "latest", "earliest", "pending", "safe", "finalized" tags)
+	NewestBlock string `protobuf:"bytes,2,opt,name=newestBlock,proto3" json:"newestBlock,omitempty"`
+	// Ascending percentiles between 0 and 100 at which to sample the priority fees of each block
+	RewardPercentiles []float64 `protobuf:"fixed64,3,rep,packed,name=rewardPercentiles,proto3" json:"rewardPercentiles,omitempty"`
+	// Optional chain ID to use for the request
+	ChainId *uint64 `protobuf:"varint,4,opt,name=chainId,proto3,oneof" json:"chainId,omitempty"`
+	// Optional genesis hash to narrow down identical networks with the same chain ID
+	ChainGenesisHash []byte `protobuf:"bytes,5,opt,name=chainGenesisHash,proto3,oneof" json:"chainGenesisHash,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetFeeHistoryRequest) Reset() {
+	*x = GetFeeHistoryRequest{}
+	mi := &file_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeHistoryRequest) ProtoMessage() {}
+
+func (x *GetFeeHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetFeeHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetFeeHistoryRequest) GetBlockCount() uint64 {
+	if x != nil {
+		return x.BlockCount
+	}
+	return 0
+}
+
+func (x *GetFeeHistoryRequest) GetNewestBlock() string {
+	if x != nil {
+		return x.NewestBlock
+	}
+	return ""
+}
+
+func (x *GetFeeHistoryRequest) GetRewardPercentiles() []float64 {
+	if x != nil {
+		return x.RewardPercentiles
+	}
+	return nil
+}
+
+func (x *GetFeeHistoryRequest) GetChainId() uint64 {
+	if x != nil && x.ChainId != nil {
+		return *x.ChainId
+	}
+	return 0
+}
+
+func (x *GetFeeHistoryRequest) GetChainGenesisHash() []byte {
+	if x != nil {
+		return x.ChainGenesisHash
+	}
+	return nil
+}
+
+// Response containing the fee history
+type GetFeeHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The fee history of the requested range
+	FeeHistory    *FeeHistory `protobuf:"bytes,1,opt,name=feeHistory,proto3" json:"feeHistory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeHistoryResponse) Reset() {
+	*x = GetFeeHistoryResponse{}
+	mi := &file_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeHistoryResponse) ProtoMessage() {}
+
+func (x *GetFeeHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetFeeHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFeeHistoryResponse) GetFeeHistory() *FeeHistory {
+	if x != nil {
+		return x.FeeHistory
+	}
+	return nil
+}
+
+// Request for subscribing to logs
+type SubscribeLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Block to start streaming from (inclusive); omitted means the next block
+	FromBlock *uint64 `protobuf:"varint,1,opt,name=fromBlock,proto3,oneof" json:"fromBlock,omitempty"`
+	// Contract addresses to filter by (empty means all addresses)
+	Addresses [][]byte `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// Topics to filter by (each position can have multiple possible values)
+	Topics []*TopicFilter `protobuf:"bytes,3,rep,name=topics,proto3" json:"topics,omitempty"`
+	// Optional chain ID to use for the request
+	ChainId *uint64 `protobuf:"varint,4,opt,name=chainId,proto3,oneof" json:"chainId,omitempty"`
+	// Optional genesis hash to narrow down identical networks with the same chain ID
+	ChainGenesisHash []byte `protobuf:"bytes,5,opt,name=chainGenesisHash,proto3,oneof" json:"chainGenesisHash,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SubscribeLogsRequest) Reset() {
+	*x = SubscribeLogsRequest{}
+	mi := &file_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeLogsRequest) ProtoMessage() {}
+
+func (x *SubscribeLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeLogsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeLogsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeLogsRequest) GetFromBlock() uint64 {
+	if x != nil && x.FromBlock != nil {
+		return *x.FromBlock
+	}
+	return 0
+}
+
+func (x *SubscribeLogsRequest) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *SubscribeLogsRequest) GetTopics() []*TopicFilter {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *SubscribeLogsRequest) GetChainId() uint64 {
+	if x != nil && x.ChainId != nil {
+		return *x.ChainId
+	}
+	return 0
+}
+
+func (x *SubscribeLogsRequest) GetChainGenesisHash() []byte {
+	if x != nil {
+		return x.ChainGenesisHash
+	}
+	return nil
+}
+
+// A batch of logs delivered on a log subscription
+type SubscribeLogsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Logs of one block in log index order; on a reorg, the retracted logs with removed set to true
+	Logs          []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeLogsResponse) Reset() {
+	*x = SubscribeLogsResponse{}
+	mi := &file_rpc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeLogsResponse) ProtoMessage() {}
+
+func (x *SubscribeLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeLogsResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeLogsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubscribeLogsResponse) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
 // Request for getting a transaction by hash
 type GetTransactionByHashRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -560,7 +816,7 @@ type GetTransactionByHashRequest struct {
 
 func (x *GetTransactionByHashRequest) Reset() {
 	*x = GetTransactionByHashRequest{}
-	mi := &file_rpc_proto_msgTypes[8]
+	mi := &file_rpc_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -572,7 +828,7 @@ func (x *GetTransactionByHashRequest) String() string {
 func (*GetTransactionByHashRequest) ProtoMessage() {}
 
 func (x *GetTransactionByHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[8]
+	mi := &file_rpc_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +841,7 @@ func (x *GetTransactionByHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionByHashRequest.ProtoReflect.Descriptor instead.
 func (*GetTransactionByHashRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{8}
+	return file_rpc_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetTransactionByHashRequest) GetTransactionHash() []byte {
@@ -620,7 +876,7 @@ type GetTransactionByHashResponse struct {
 
 func (x *GetTransactionByHashResponse) Reset() {
 	*x = GetTransactionByHashResponse{}
-	mi := &file_rpc_proto_msgTypes[9]
+	mi := &file_rpc_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -632,7 +888,7 @@ func (x *GetTransactionByHashResponse) String() string {
 func (*GetTransactionByHashResponse) ProtoMessage() {}
 
 func (x *GetTransactionByHashResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[9]
+	mi := &file_rpc_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +901,7 @@ func (x *GetTransactionByHashResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionByHashResponse.ProtoReflect.Descriptor instead.
 func (*GetTransactionByHashResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{9}
+	return file_rpc_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetTransactionByHashResponse) GetTransaction() *Transaction {
@@ -670,7 +926,7 @@ type GetTransactionReceiptRequest struct {
 
 func (x *GetTransactionReceiptRequest) Reset() {
 	*x = GetTransactionReceiptRequest{}
-	mi := &file_rpc_proto_msgTypes[10]
+	mi := &file_rpc_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -682,7 +938,7 @@ func (x *GetTransactionReceiptRequest) String() string {
 func (*GetTransactionReceiptRequest) ProtoMessage() {}
 
 func (x *GetTransactionReceiptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[10]
+	mi := &file_rpc_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,7 +951,7 @@ func (x *GetTransactionReceiptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionReceiptRequest.ProtoReflect.Descriptor instead.
 func (*GetTransactionReceiptRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{10}
+	return file_rpc_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetTransactionReceiptRequest) GetTransactionHash() []byte {
@@ -730,7 +986,7 @@ type GetTransactionReceiptResponse struct {
 
 func (x *GetTransactionReceiptResponse) Reset() {
 	*x = GetTransactionReceiptResponse{}
-	mi := &file_rpc_proto_msgTypes[11]
+	mi := &file_rpc_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -742,7 +998,7 @@ func (x *GetTransactionReceiptResponse) String() string {
 func (*GetTransactionReceiptResponse) ProtoMessage() {}
 
 func (x *GetTransactionReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[11]
+	mi := &file_rpc_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -755,7 +1011,7 @@ func (x *GetTransactionReceiptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionReceiptResponse.ProtoReflect.Descriptor instead.
 func (*GetTransactionReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{11}
+	return file_rpc_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetTransactionReceiptResponse) GetReceipt() *Receipt {
@@ -768,7 +1024,7 @@ func (x *GetTransactionReceiptResponse) GetReceipt() *Receipt {
 // Request for getting all receipts in a block
 type GetBlockReceiptsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Block number as hex string (e.g., "0x14ee0030") or tag ("latest", "earliest", "pending")
+	// Block number as hex string (e.g., "0x14ee0030") or tag ("latest", "earliest", "pending", "safe", "finalized")
 	// Mutually exclusive with blockHash
 	BlockNumber *string `protobuf:"bytes,1,opt,name=blockNumber,proto3,oneof" json:"blockNumber,omitempty"`
 	// Block hash as bytes (32 bytes)
@@ -784,7 +1040,7 @@ type GetBlockReceiptsRequest struct {
 
 func (x *GetBlockReceiptsRequest) Reset() {
 	*x = GetBlockReceiptsRequest{}
-	mi := &file_rpc_proto_msgTypes[12]
+	mi := &file_rpc_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -796,7 +1052,7 @@ func (x *GetBlockReceiptsRequest) String() string {
 func (*GetBlockReceiptsRequest) ProtoMessage() {}
 
 func (x *GetBlockReceiptsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[12]
+	mi := &file_rpc_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,7 +1065,7 @@ func (x *GetBlockReceiptsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockReceiptsRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockReceiptsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{12}
+	return file_rpc_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetBlockReceiptsRequest) GetBlockNumber() string {
@@ -851,7 +1107,7 @@ type GetBlockReceiptsResponse struct {
 
 func (x *GetBlockReceiptsResponse) Reset() {
 	*x = GetBlockReceiptsResponse{}
-	mi := &file_rpc_proto_msgTypes[13]
+	mi := &file_rpc_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -863,7 +1119,7 @@ func (x *GetBlockReceiptsResponse) String() string {
 func (*GetBlockReceiptsResponse) ProtoMessage() {}
 
 func (x *GetBlockReceiptsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[13]
+	mi := &file_rpc_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -876,7 +1132,7 @@ func (x *GetBlockReceiptsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockReceiptsResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockReceiptsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{13}
+	return file_rpc_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetBlockReceiptsResponse) GetReceipts() []*Receipt {
@@ -941,6 +1197,34 @@ const file_rpc_proto_rawDesc = "" +
 	"\vTopicFilter\x12\x16\n" +
 	"\x06values\x18\x01 \x03(\fR\x06values\"3\n" +
 	"\x0fGetLogsResponse\x12 \n" +
+	"\x04logs\x18\x01 \x03(\v2\f.bds.evm.LogR\x04logs\"\xf7\x01\n" +
+	"\x14GetFeeHistoryRequest\x12\x1e\n" +
+	"\n" +
+	"blockCount\x18\x01 \x01(\x04R\n" +
+	"blockCount\x12 \n" +
+	"\vnewestBlock\x18\x02 \x01(\tR\vnewestBlock\x12,\n" +
+	"\x11rewardPercentiles\x18\x03 \x03(\x01R\x11rewardPercentiles\x12\x1d\n" +
+	"\achainId\x18\x04 \x01(\x04H\x00R\achainId\x88\x01\x01\x12/\n" +
+	"\x10chainGenesisHash\x18\x05 \x01(\fH\x01R\x10chainGenesisHash\x88\x01\x01B\n" +
+	"\n" +
+	"\b_chainIdB\x13\n" +
+	"\x11_chainGenesisHash\"L\n" +
+	"\x15GetFeeHistoryResponse\x123\n" +
+	"\n" +
+	"feeHistory\x18\x01 \x01(\v2\x13.bds.evm.FeeHistoryR\n" +
+	"feeHistory\"\x84\x02\n" +
+	"\x14SubscribeLogsRequest\x12!\n" +
+	"\tfromBlock\x18\x01 \x01(\x04H\x00R\tfromBlock\x88\x01\x01\x12\x1c\n" +
+	"\taddresses\x18\x02 \x03(\fR\taddresses\x12,\n" +
+	"\x06topics\x18\x03 \x03(\v2\x14.bds.evm.TopicFilterR\x06topics\x12\x1d\n" +
+	"\achainId\x18\x04 \x01(\x04H\x01R\achainId\x88\x01\x01\x12/\n" +
+	"\x10chainGenesisHash\x18\x05 \x01(\fH\x02R\x10chainGenesisHash\x88\x01\x01B\f\n" +
+	"\n" +
+	"_fromBlockB\n" +
+	"\n" +
+	"\b_chainIdB\x13\n" +
+	"\x11_chainGenesisHash\"9\n" +
+	"\x15SubscribeLogsResponse\x12 \n" +
 	"\x04logs\x18\x01 \x03(\v2\f.bds.evm.LogR\x04logs\"\xb8\x01\n" +
 	"\x1bGetTransactionByHashRequest\x12(\n" +
 	"\x0ftransactionHash\x18\x01 \x01(\fR\x0ftransactionHash\x12\x1d\n" +
@@ -972,7 +1256,7 @@ const file_rpc_proto_rawDesc = "" +
 	"\b_chainIdB\x13\n" +
 	"\x11_chainGenesisHash\"H\n" +
 	"\x18GetBlockReceiptsResponse\x12,\n" +
-	"\breceipts\x18\x01 \x03(\v2\x10.bds.evm.ReceiptR\breceipts2\xd1\x04\n" +
+	"\breceipts\x18\x01 \x03(\v2\x10.bds.evm.ReceiptR\breceipts2\xf3\x05\n" +
 	"\x0fRPCQueryService\x12<\n" +
 	"\aChainId\x12\x17.bds.evm.ChainIdRequest\x1a\x18.bds.evm.ChainIdResponse\x12O\n" +
 	"\x10GetBlockByNumber\x12 .bds.evm.GetBlockByNumberRequest\x1a\x19.bds.evm.GetBlockResponse\x12K\n" +
@@ -980,7 +1264,9 @@ const file_rpc_proto_rawDesc = "" +
 	"\aGetLogs\x12\x17.bds.evm.GetLogsRequest\x1a\x18.bds.evm.GetLogsResponse\x12c\n" +
 	"\x14GetTransactionByHash\x12$.bds.evm.GetTransactionByHashRequest\x1a%.bds.evm.GetTransactionByHashResponse\x12f\n" +
 	"\x15GetTransactionReceipt\x12%.bds.evm.GetTransactionReceiptRequest\x1a&.bds.evm.GetTransactionReceiptResponse\x12W\n" +
-	"\x10GetBlockReceipts\x12 .bds.evm.GetBlockReceiptsRequest\x1a!.bds.evm.GetBlockReceiptsResponseB4Z2github.com/blockchain-data-standards/manifesto/evmb\x06proto3"
+	"\x10GetBlockReceipts\x12 .bds.evm.GetBlockReceiptsRequest\x1a!.bds.evm.GetBlockReceiptsResponse\x12N\n" +
+	"\rGetFeeHistory\x12\x1d.bds.evm.GetFeeHistoryRequest\x1a\x1e.bds.evm.GetFeeHistoryResponse\x12P\n" +
+	"\rSubscribeLogs\x12\x1d.bds.evm.SubscribeLogsRequest\x1a\x1e.bds.evm.SubscribeLogsResponse0\x01B4Z2github.com/blockchain-data-standards/manifesto/evmb\x06proto3"
 
 var (
 	file_rpc_proto_rawDescOnce sync.Once
@@ -994,7 +1280,7 @@ func file_rpc_proto_rawDescGZIP() []byte {
 	return file_rpc_proto_rawDescData
 }
 
-var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_rpc_proto_goTypes = []any{
 	(*ChainIdRequest)(nil),                // 0: bds.evm.ChainIdRequest
 	(*ChainIdResponse)(nil),               // 1: bds.evm.ChainIdResponse
@@ -1004,46 +1290,58 @@ var file_rpc_proto_goTypes = []any{
 	(*GetLogsRequest)(nil),                // 5: bds.evm.GetLogsRequest
 	(*TopicFilter)(nil),                   // 6: bds.evm.TopicFilter
 	(*GetLogsResponse)(nil),               // 7: bds.evm.GetLogsResponse
-	(*GetTransactionByHashRequest)(nil),   // 8: bds.evm.GetTransactionByHashRequest
-	(*GetTransactionByHashResponse)(nil),  // 9: bds.evm.GetTransactionByHashResponse
-	(*GetTransactionReceiptRequest)(nil),  // 10: bds.evm.GetTransactionReceiptRequest
-	(*GetTransactionReceiptResponse)(nil), // 11: bds.evm.GetTransactionReceiptResponse
-	(*GetBlockReceiptsRequest)(nil),       // 12: bds.evm.GetBlockReceiptsRequest
-	(*GetBlockReceiptsResponse)(nil),      // 13: bds.evm.GetBlockReceiptsResponse
-	(*BlockHeader)(nil),                   // 14: bds.evm.BlockHeader
-	(*Transaction)(nil),                   // 15: bds.evm.Transaction
-	(*Withdrawal)(nil),                    // 16: bds.evm.Withdrawal
-	(*Log)(nil),                           // 17: bds.evm.Log
-	(*Receipt)(nil),                       // 18: bds.evm.Receipt
+	(*GetFeeHistoryRequest)(nil),          // 8: bds.evm.GetFeeHistoryRequest
+	(*GetFeeHistoryResponse)(nil),         // 9: bds.evm.GetFeeHistoryResponse
+	(*SubscribeLogsRequest)(nil),          // 10: bds.evm.SubscribeLogsRequest
+	(*SubscribeLogsResponse)(nil),         // 11: bds.evm.SubscribeLogsResponse
+	(*GetTransactionByHashRequest)(nil),   // 12: bds.evm.GetTransactionByHashRequest
+	(*GetTransactionByHashResponse)(nil),  // 13: bds.evm.GetTransactionByHashResponse
+	(*GetTransactionReceiptRequest)(nil),  // 14: bds.evm.GetTransactionReceiptRequest
+	(*GetTransactionReceiptResponse)(nil), // 15: bds.evm.GetTransactionReceiptResponse
+	(*GetBlockReceiptsRequest)(nil),       // 16: bds.evm.GetBlockReceiptsRequest
+	(*GetBlockReceiptsResponse)(nil),      // 17: bds.evm.GetBlockReceiptsResponse
+	(*BlockHeader)(nil),                   // 18: bds.evm.BlockHeader
+	(*Transaction)(nil),                   // 19: bds.evm.Transaction
+	(*Withdrawal)(nil),                    // 20: bds.evm.Withdrawal
+	(*Log)(nil),                           // 21: bds.evm.Log
+	(*FeeHistory)(nil),                    // 22: bds.evm.FeeHistory
+	(*Receipt)(nil),                       // 23: bds.evm.Receipt
 }
 var file_rpc_proto_depIdxs = []int32{
-	14, // 0: bds.evm.GetBlockResponse.block:type_name -> bds.evm.BlockHeader
-	15, // 1: bds.evm.GetBlockResponse.fullTransactions:type_name -> bds.evm.Transaction
-	16, // 2: bds.evm.GetBlockResponse.withdrawals:type_name -> bds.evm.Withdrawal
+	18, // 0: bds.evm.GetBlockResponse.block:type_name -> bds.evm.BlockHeader
+	19, // 1: bds.evm.GetBlockResponse.fullTransactions:type_name -> bds.evm.Transaction
+	20, // 2: bds.evm.GetBlockResponse.withdrawals:type_name -> bds.evm.Withdrawal
 	6,  // 3: bds.evm.GetLogsRequest.topics:type_name -> bds.evm.TopicFilter
-	17, // 4: bds.evm.GetLogsResponse.logs:type_name -> bds.evm.Log
-	15, // 5: bds.evm.GetTransactionByHashResponse.transaction:type_name -> bds.evm.Transaction
-	18, // 6: bds.evm.GetTransactionReceiptResponse.receipt:type_name -> bds.evm.Receipt
-	18, // 7: bds.evm.GetBlockReceiptsResponse.receipts:type_name -> bds.evm.Receipt
-	0,  // 8: bds.evm.RPCQueryService.ChainId:input_type -> bds.evm.ChainIdRequest
-	2,  // 9: bds.evm.RPCQueryService.GetBlockByNumber:input_type -> bds.evm.GetBlockByNumberRequest
-	3,  // 10: bds.evm.RPCQueryService.GetBlockByHash:input_type -> bds.evm.GetBlockByHashRequest
-	5,  // 11: bds.evm.RPCQueryService.GetLogs:input_type -> bds.evm.GetLogsRequest
-	8,  // 12: bds.evm.RPCQueryService.GetTransactionByHash:input_type -> bds.evm.GetTransactionByHashRequest
-	10, // 13: bds.evm.RPCQueryService.GetTransactionReceipt:input_type -> bds.evm.GetTransactionReceiptRequest
-	12, // 14: bds.evm.RPCQueryService.GetBlockReceipts:input_type -> bds.evm.GetBlockReceiptsRequest
-	1,  // 15: bds.evm.RPCQueryService.ChainId:output_type -> bds.evm.ChainIdResponse
-	4,  // 16: bds.evm.RPCQueryService.GetBlockByNumber:output_type -> bds.evm.GetBlockResponse
-	4,  // 17: bds.evm.RPCQueryService.GetBlockByHash:output_type -> bds.evm.GetBlockResponse
-	7,  // 18: bds.evm.RPCQueryService.GetLogs:output_type -> bds.evm.GetLogsResponse
-	9,  // 19: bds.evm.RPCQueryService.GetTransactionByHash:output_type -> bds.evm.GetTransactionByHashResponse
-	11, // 20: bds.evm.RPCQueryService.GetTransactionReceipt:output_type -> bds.evm.GetTransactionReceiptResponse
-	13, // 21: bds.evm.RPCQueryService.GetBlockReceipts:output_type -> bds.evm.GetBlockReceiptsResponse
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	21, // 4: bds.evm.GetLogsResponse.logs:type_name -> bds.evm.Log
+	22, // 5: bds.evm.GetFeeHistoryResponse.feeHistory:type_name -> bds.evm.FeeHistory
+	6,  // 6: bds.evm.SubscribeLogsRequest.topics:type_name -> bds.evm.TopicFilter
+	21, // 7: bds.evm.SubscribeLogsResponse.logs:type_name -> bds.evm.Log
+	19, // 8: bds.evm.GetTransactionByHashResponse.transaction:type_name -> bds.evm.Transaction
+	23, // 9: bds.evm.GetTransactionReceiptResponse.receipt:type_name -> bds.evm.Receipt
+	23, // 10: bds.evm.GetBlockReceiptsResponse.receipts:type_name -> bds.evm.Receipt
+	0,  // 11: bds.evm.RPCQueryService.ChainId:input_type -> bds.evm.ChainIdRequest
+	2,  // 12: bds.evm.RPCQueryService.GetBlockByNumber:input_type -> bds.evm.GetBlockByNumberRequest
+	3,  // 13: bds.evm.RPCQueryService.GetBlockByHash:input_type -> bds.evm.GetBlockByHashRequest
+	5,  // 14: bds.evm.RPCQueryService.GetLogs:input_type -> bds.evm.GetLogsRequest
+	12, // 15: bds.evm.RPCQueryService.GetTransactionByHash:input_type -> bds.evm.GetTransactionByHashRequest
+	14, // 16: bds.evm.RPCQueryService.GetTransactionReceipt:input_type -> bds.evm.GetTransactionReceiptRequest
+	16, // 17: bds.evm.RPCQueryService.GetBlockReceipts:input_type -> bds.evm.GetBlockReceiptsRequest
+	8,  // 18: bds.evm.RPCQueryService.GetFeeHistory:input_type -> bds.evm.GetFeeHistoryRequest
+	10, // 19: bds.evm.RPCQueryService.SubscribeLogs:input_type -> bds.evm.SubscribeLogsRequest
+	1,  // 20: bds.evm.RPCQueryService.ChainId:output_type -> bds.evm.ChainIdResponse
+	4,  // 21: bds.evm.RPCQueryService.GetBlockByNumber:output_type -> bds.evm.GetBlockResponse
+	4,  // 22: bds.evm.RPCQueryService.GetBlockByHash:output_type -> bds.evm.GetBlockResponse
+	7,  // 23: bds.evm.RPCQueryService.GetLogs:output_type -> bds.evm.GetLogsResponse
+	13, // 24: bds.evm.RPCQueryService.GetTransactionByHash:output_type -> bds.evm.GetTransactionByHashResponse
+	15, // 25: bds.evm.RPCQueryService.GetTransactionReceipt:output_type -> bds.evm.GetTransactionReceiptResponse
+	17, // 26: bds.evm.RPCQueryService.GetBlockReceipts:output_type -> bds.evm.GetBlockReceiptsResponse
+	9,  // 27: bds.evm.RPCQueryService.GetFeeHistory:output_type -> bds.evm.GetFeeHistoryResponse
+	11, // 28: bds.evm.RPCQueryService.SubscribeLogs:output_type -> bds.evm.SubscribeLogsResponse
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_rpc_proto_init() }
@@ -1059,13 +1357,15 @@ func file_rpc_proto_init() {
 	file_rpc_proto_msgTypes[8].OneofWrappers = []any{}
 	file_rpc_proto_msgTypes[10].OneofWrappers = []any{}
 	file_rpc_proto_msgTypes[12].OneofWrappers = []any{}
+	file_rpc_proto_msgTypes[14].OneofWrappers = []any{}
+	file_rpc_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_proto_rawDesc), len(file_rpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
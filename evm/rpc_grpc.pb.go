@@ -26,6 +26,8 @@ const (
 	RPCQueryService_GetTransactionByHash_FullMethodName  = "/bds.evm.RPCQueryService/GetTransactionByHash"
 	RPCQueryService_GetTransactionReceipt_FullMethodName = "/bds.evm.RPCQueryService/GetTransactionReceipt"
 	RPCQueryService_GetBlockReceipts_FullMethodName      = "/bds.evm.RPCQueryService/GetBlockReceipts"
+	RPCQueryService_GetFeeHistory_FullMethodName         = "/bds.evm.RPCQueryService/GetFeeHistory"
+	RPCQueryService_SubscribeLogs_FullMethodName         = "/bds.evm.RPCQueryService/SubscribeLogs"
 )
 
 // RPCQueryServiceClient is the client API for RPCQueryService service.
@@ -48,6 +50,11 @@ type RPCQueryServiceClient interface {
 	GetTransactionReceipt(ctx context.Context, in *GetTransactionReceiptRequest, opts ...grpc.CallOption) (*GetTransactionReceiptResponse, error)
 	// Get all transaction receipts for a block (equivalent to eth_getBlockReceipts)
 	GetBlockReceipts(ctx context.Context, in *GetBlockReceiptsRequest, opts ...grpc.CallOption) (*GetBlockReceiptsResponse, error)
+	// Get base fees, gas usage and priority fee percentiles for a range of blocks (equivalent to eth_feeHistory)
+	GetFeeHistory(ctx context.Context, in *GetFeeHistoryRequest, opts ...grpc.CallOption) (*GetFeeHistoryResponse, error)
+	// Stream logs matching filter criteria as blocks arrive (equivalent to eth_subscribe("logs")).
+	// Logs retracted by a reorg are sent again with removed set to true
+	SubscribeLogs(ctx context.Context, in *SubscribeLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeLogsResponse], error)
 }
 
 type rPCQueryServiceClient struct {
@@ -128,6 +135,35 @@ func (c *rPCQueryServiceClient) GetBlockReceipts(ctx context.Context, in *GetBlo
 	return out, nil
 }
 
+func (c *rPCQueryServiceClient) GetFeeHistory(ctx context.Context, in *GetFeeHistoryRequest, opts ...grpc.CallOption) (*GetFeeHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeeHistoryResponse)
+	err := c.cc.Invoke(ctx, RPCQueryService_GetFeeHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rPCQueryServiceClient) SubscribeLogs(ctx context.Context, in *SubscribeLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RPCQueryService_ServiceDesc.Streams[0], RPCQueryService_SubscribeLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeLogsRequest, SubscribeLogsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RPCQueryService_SubscribeLogsClient = grpc.ServerStreamingClient[SubscribeLogsResponse]
+
 // RPCQueryServiceServer is the server API for RPCQueryService service.
 // All implementations must embed UnimplementedRPCQueryServiceServer
 // for forward compatibility.
@@ -148,6 +184,11 @@ type RPCQueryServiceServer interface {
 	GetTransactionReceipt(context.Context, *GetTransactionReceiptRequest) (*GetTransactionReceiptResponse, error)
 	// Get all transaction receipts for a block (equivalent to eth_getBlockReceipts)
 	GetBlockReceipts(context.Context, *GetBlockReceiptsRequest) (*GetBlockReceiptsResponse, error)
+	// Get base fees, gas usage and priority fee percentiles for a range of blocks (equivalent to eth_feeHistory)
+	GetFeeHistory(context.Context, *GetFeeHistoryRequest) (*GetFeeHistoryResponse, error)
+	// Stream logs matching filter criteria as blocks arrive (equivalent to eth_subscribe("logs")).
+	// Logs retracted by a reorg are sent again with removed set to true
+	SubscribeLogs(*SubscribeLogsRequest, grpc.ServerStreamingServer[SubscribeLogsResponse]) error
 	mustEmbedUnimplementedRPCQueryServiceServer()
 }
 
@@ -179,6 +220,12 @@ func (UnimplementedRPCQueryServiceServer) GetTransactionReceipt(context.Context,
 func (UnimplementedRPCQueryServiceServer) GetBlockReceipts(context.Context, *GetBlockReceiptsRequest) (*GetBlockReceiptsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockReceipts not implemented")
 }
+func (UnimplementedRPCQueryServiceServer) GetFeeHistory(context.Context, *GetFeeHistoryRequest) (*GetFeeHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeHistory not implemented")
+}
+func (UnimplementedRPCQueryServiceServer) SubscribeLogs(*SubscribeLogsRequest, grpc.ServerStreamingServer[SubscribeLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeLogs not implemented")
+}
 func (UnimplementedRPCQueryServiceServer) mustEmbedUnimplementedRPCQueryServiceServer() {}
 func (UnimplementedRPCQueryServiceServer) testEmbeddedByValue()                         {}
 
@@ -326,6 +373,35 @@ func _RPCQueryService_GetBlockReceipts_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RPCQueryService_GetFeeHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RPCQueryServiceServer).GetFeeHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RPCQueryService_GetFeeHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RPCQueryServiceServer).GetFeeHistory(ctx, req.(*GetFeeHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RPCQueryService_SubscribeLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RPCQueryServiceServer).SubscribeLogs(m, &grpc.GenericServerStream[SubscribeLogsRequest, SubscribeLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RPCQueryService_SubscribeLogsServer = grpc.ServerStreamingServer[SubscribeLogsResponse]
+
 // RPCQueryService_ServiceDesc is the grpc.ServiceDesc for RPCQueryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -361,7 +437,17 @@ var RPCQueryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockReceipts",
 			Handler:    _RPCQueryService_GetBlockReceipts_Handler,
 		},
+		{
+			MethodName: "GetFeeHistory",
+			Handler:    _RPCQueryService_GetFeeHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeLogs",
+			Handler:       _RPCQueryService_SubscribeLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
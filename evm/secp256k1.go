@@ -0,0 +1,159 @@
+package evm
+
+import (
+	"errors"
+	"math/big"
+)
+
+// secp256k1 public key recovery (ecrecover) on math/big. It is not constant time, which is fine
+// for recovering public keys from public signatures but not for signing.
+
+var (
+	secp256k1P, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	secp256k1N, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	secp256k1Gx, _ = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	secp256k1Gy, _ = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+
+	// secp256k1HalfN bounds the s of EIP-2 low-s signatures
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+
+	// secp256k1SqrtExp is (p+1)/4; a^((p+1)/4) is a square root of a as p = 3 mod 4
+	secp256k1SqrtExp = new(big.Int).Rsh(new(big.Int).Add(secp256k1P, big.NewInt(1)), 2)
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errSignatureHighS   = errors.New("signature s is above secp256k1n/2")
+)
+
+// jacobianPoint is a curve point in Jacobian coordinates (X/Z², Y/Z³); Z = 0 is infinity.
+type jacobianPoint struct {
+	x, y, z *big.Int
+}
+
+func newJacobianPoint(x, y *big.Int) jacobianPoint {
+	return jacobianPoint{new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)}
+}
+
+func (a jacobianPoint) isInfinity() bool { return a.z.Sign() == 0 }
+
+func secp256k1Mod(v *big.Int) *big.Int { return v.Mod(v, secp256k1P) }
+
+// double returns 2a using the a = 0 doubling formulas.
+func (a jacobianPoint) double() jacobianPoint {
+	if a.isInfinity() || a.y.Sign() == 0 {
+		return jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	yy := secp256k1Mod(new(big.Int).Mul(a.y, a.y))
+	s := secp256k1Mod(new(big.Int).Mul(a.x, yy))
+	s.Lsh(s, 2)
+	m := secp256k1Mod(new(big.Int).Mul(a.x, a.x))
+	m.Mul(m, big.NewInt(3))
+	x := secp256k1Mod(new(big.Int).Sub(new(big.Int).Mul(m, m), new(big.Int).Lsh(s, 1)))
+	yyyy := new(big.Int).Mul(yy, yy)
+	y := secp256k1Mod(new(big.Int).Sub(new(big.Int).Mul(m, new(big.Int).Sub(s, x)), yyyy.Lsh(yyyy, 3)))
+	z := secp256k1Mod(new(big.Int).Lsh(new(big.Int).Mul(a.y, a.z), 1))
+	return jacobianPoint{x, y, z}
+}
+
+// add returns a + b.
+func (a jacobianPoint) add(b jacobianPoint) jacobianPoint {
+	if a.isInfinity() {
+		return b
+	}
+	if b.isInfinity() {
+		return a
+	}
+	z1z1 := secp256k1Mod(new(big.Int).Mul(a.z, a.z))
+	z2z2 := secp256k1Mod(new(big.Int).Mul(b.z, b.z))
+	u1 := secp256k1Mod(new(big.Int).Mul(a.x, z2z2))
+	u2 := secp256k1Mod(new(big.Int).Mul(b.x, z1z1))
+	s1 := secp256k1Mod(new(big.Int).Mul(a.y, new(big.Int).Mul(b.z, z2z2)))
+	s2 := secp256k1Mod(new(big.Int).Mul(b.y, new(big.Int).Mul(a.z, z1z1)))
+	if u1.Cmp(u2) == 0 {
+		if s1.Cmp(s2) == 0 {
+			return a.double()
+		}
+		return jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	h := secp256k1Mod(new(big.Int).Sub(u2, u1))
+	r := secp256k1Mod(new(big.Int).Sub(s2, s1))
+	hh := secp256k1Mod(new(big.Int).Mul(h, h))
+	hhh := secp256k1Mod(new(big.Int).Mul(h, hh))
+	v := secp256k1Mod(new(big.Int).Mul(u1, hh))
+	x := secp256k1Mod(new(big.Int).Sub(new(big.Int).Sub(new(big.Int).Mul(r, r), hhh), new(big.Int).Lsh(v, 1)))
+	y := secp256k1Mod(new(big.Int).Sub(new(big.Int).Mul(r, new(big.Int).Sub(v, x)), new(big.Int).Mul(s1, hhh)))
+	z := secp256k1Mod(new(big.Int).Mul(h, new(big.Int).Mul(a.z, b.z)))
+	return jacobianPoint{x, y, z}
+}
+
+// mul returns k·a by double-and-add.
+func (a jacobianPoint) mul(k *big.Int) jacobianPoint {
+	acc := jacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		acc = acc.double()
+		if k.Bit(i) == 1 {
+			acc = acc.add(a)
+		}
+	}
+	return acc
+}
+
+// affine returns the affine coordinates of a, which must not be infinity.
+func (a jacobianPoint) affine() (x, y *big.Int) {
+	zInv := new(big.Int).ModInverse(a.z, secp256k1P)
+	zInv2 := secp256k1Mod(new(big.Int).Mul(zInv, zInv))
+	x = secp256k1Mod(new(big.Int).Mul(a.x, zInv2))
+	y = secp256k1Mod(new(big.Int).Mul(a.y, new(big.Int).Mul(zInv2, zInv)))
+	return x, y
+}
+
+// ecrecover returns the 64-byte uncompressed public key (without the 0x04 prefix) that signed
+// hash with (r, s) and the y parity of the signature's R point. High s values are accepted;
+// callers enforce EIP-2 where it applies.
+func ecrecover(hash []byte, r, s []byte, yParity uint32) ([]byte, error) {
+	if yParity > 1 || len(r) > 32 || len(s) > 32 {
+		return nil, errInvalidSignature
+	}
+	rInt, sInt := new(big.Int).SetBytes(r), new(big.Int).SetBytes(s)
+	if rInt.Sign() == 0 || sInt.Sign() == 0 || rInt.Cmp(secp256k1N) >= 0 || sInt.Cmp(secp256k1N) >= 0 {
+		return nil, errInvalidSignature
+	}
+
+	// R is the point with x = r whose y has the signed parity
+	alpha := new(big.Int).Exp(rInt, big.NewInt(3), secp256k1P)
+	secp256k1Mod(alpha.Add(alpha, big.NewInt(7)))
+	y := new(big.Int).Exp(alpha, secp256k1SqrtExp, secp256k1P)
+	if secp256k1Mod(new(big.Int).Mul(y, y)).Cmp(alpha) != 0 {
+		return nil, errInvalidSignature
+	}
+	if y.Bit(0) != uint(yParity) {
+		y.Sub(secp256k1P, y)
+	}
+
+	// Q = r⁻¹(s·R − e·G)
+	rInv := new(big.Int).ModInverse(rInt, secp256k1N)
+	e := new(big.Int).SetBytes(hash)
+	u1 := new(big.Int).Mul(e, rInv)
+	u1.Neg(u1).Mod(u1, secp256k1N)
+	u2 := new(big.Int).Mul(sInt, rInv)
+	u2.Mod(u2, secp256k1N)
+	q := newJacobianPoint(secp256k1Gx, secp256k1Gy).mul(u1).add(newJacobianPoint(rInt, y).mul(u2))
+	if q.isInfinity() {
+		return nil, errInvalidSignature
+	}
+	qx, qy := q.affine()
+	pub := make([]byte, 64)
+	qx.FillBytes(pub[:32])
+	qy.FillBytes(pub[32:])
+	return pub, nil
+}
+
+// ecrecoverAddress returns the address of the key that signed hash.
+func ecrecoverAddress(hash []byte, r, s []byte, yParity uint32) (Address, error) {
+	pub, err := ecrecover(hash, r, s, yParity)
+	if err != nil {
+		return Address{}, err
+	}
+	return Address(Keccak256(pub)[12:]), nil
+}
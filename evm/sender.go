@@ -0,0 +1,175 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// RecoveredAddressDetail is the BaseError detail key holding the address recovered from a
+// signature when it does not match the address the message carries.
+const RecoveredAddressDetail = "recoveredAddress"
+
+// authorizationMagic prefixes the RLP of EIP-7702 authorizations before they are signed.
+const authorizationMagic = 0x05
+
+// The signing hash of a typed transaction is the Keccak-256 of its type byte followed by the RLP
+// list of its fields without yParity, r and s. Legacy transactions hash the list without v, r
+// and s, extended with [chainId, 0, 0] under EIP-155.
+
+// TransactionSigningHash returns the hash the sender of tx signed. Errors are as for
+// EncodeTransaction.
+func TransactionSigningHash(tx *Transaction) (Hash, error) {
+	if tx == nil {
+		return Hash{}, errors.New("transaction is nil")
+	}
+	p := newFieldParser(true)
+	h, _ := transactionSigningHash(p, tx)
+	return h, p.err()
+}
+
+// transactionSigningHash returns the signing hash of tx and the y parity of its signature.
+func transactionSigningHash(p *fieldParser, tx *Transaction) (Hash, uint32) {
+	typ := tx.TransactionType()
+	if !isEncodableTransactionType(typ) {
+		p.fail("type", fmt.Errorf("recovering the sender of %s transactions is not supported", typ))
+		return Hash{}, 0
+	}
+	fields := appendTransactionFields(p, nil, tx)
+	if typ != TransactionType_LEGACY {
+		return Hash(Keccak256([]byte{byte(typ)}, rlpAppendList(nil, fields))), typedYParity(p, tx)
+	}
+
+	vBytes := legacyV(p, tx)
+	if vBytes == nil {
+		return Hash{}, 0
+	}
+	v := new(big.Int).SetBytes(vBytes)
+	switch {
+	case v.Cmp(big.NewInt(27)) == 0 || v.Cmp(big.NewInt(28)) == 0:
+		return Hash(Keccak256(rlpAppendList(nil, fields))), uint32(v.Uint64() - 27)
+	case v.Cmp(big.NewInt(35)) >= 0:
+		v.Sub(v, big.NewInt(35))
+		parity := uint32(v.Bit(0))
+		chainId := v.Rsh(v, 1)
+		if tx.ChainId != nil && (!chainId.IsUint64() || chainId.Uint64() != *tx.ChainId) {
+			p.fail("v", fmt.Errorf("v encodes chain %s but chainId is %d", chainId, *tx.ChainId))
+		}
+		fields = rlpAppendBigInt(fields, chainId)
+		fields = rlpAppendUint(fields, 0)
+		fields = rlpAppendUint(fields, 0)
+		return Hash(Keccak256(rlpAppendList(nil, fields))), parity
+	}
+	p.fail("v", fmt.Errorf("legacy transactions require v of 27, 28 or at least 35, got %s", v))
+	return Hash{}, 0
+}
+
+// RecoverSender returns the address that signed tx, recovered from r, s and v or yParity.
+// Signatures with s above secp256k1n/2 are accepted, as transactions before Homestead used
+// them. Errors are common.BaseError values with code INVALID_PARAMETER.
+func RecoverSender(tx *Transaction) (Address, error) {
+	if tx == nil {
+		return Address{}, errors.New("transaction is nil")
+	}
+	p := newFieldParser(true)
+	from := recoverSender(p, tx)
+	return from, p.err()
+}
+
+func recoverSender(p *fieldParser, tx *Transaction) Address {
+	h, yParity := transactionSigningHash(p, tx)
+	if p.failed() {
+		return Address{}
+	}
+	from, err := ecrecoverAddress(h[:], tx.R, tx.S, yParity)
+	if err != nil {
+		p.fail("r", err)
+	}
+	return from
+}
+
+// AuthorizationSigningHash returns the hash an EIP-7702 authority signed:
+// Keccak-256(0x05 || rlp([chainId, address, nonce])).
+func AuthorizationSigningHash(auth *AuthorizationListItem) Hash {
+	var fields []byte
+	fields = rlpAppendUint(fields, auth.GetChainId())
+	fields = rlpAppendBytes(fields, auth.GetAddress())
+	fields = rlpAppendUint(fields, auth.GetNonce())
+	return Hash(Keccak256([]byte{authorizationMagic}, rlpAppendList(nil, fields)))
+}
+
+// RecoverAuthority returns the account that signed an EIP-7702 authorization. As required by
+// EIP-7702, signatures with s above secp256k1n/2 are rejected.
+func RecoverAuthority(auth *AuthorizationListItem) (Address, error) {
+	if auth == nil {
+		return Address{}, errors.New("authorization is nil")
+	}
+	if len(auth.Address) != AddressLength {
+		return Address{}, fmt.Errorf("authorization address must be %d bytes, got %d", AddressLength, len(auth.Address))
+	}
+	if new(big.Int).SetBytes(auth.S).Cmp(secp256k1HalfN) > 0 {
+		return Address{}, errSignatureHighS
+	}
+	h := AuthorizationSigningHash(auth)
+	return ecrecoverAddress(h[:], auth.R, auth.S, auth.YParity)
+}
+
+// VerifySender checks that tx.From and the authority of every EIP-7702 authorization that has
+// one are the accounts that signed them. The error is a common.BaseError with code
+// INVALID_PARAMETER listing every mismatch under the paths detail; a single mismatch carries
+// the recovered address under the recoveredAddress detail.
+func VerifySender(tx *Transaction) error {
+	if tx == nil {
+		return errors.New("transaction is nil")
+	}
+	p := newFieldParser(true)
+	from := recoverSender(p, tx)
+	if p.failed() {
+		return p.err()
+	}
+	if !bytes.Equal(from[:], tx.From) {
+		p.failMismatch("from", fmt.Errorf("signed by %s, not %s", from.Hex(), BytesToHex(tx.From)), RecoveredAddressDetail, from.Hex())
+	}
+	for i, auth := range tx.AuthorizationList {
+		if auth == nil || len(auth.Authority) == 0 {
+			continue
+		}
+		ap := p.item("authorizationList", i)
+		authority, err := RecoverAuthority(auth)
+		if err != nil {
+			ap.fail("", err)
+			continue
+		}
+		if !bytes.Equal(authority[:], auth.Authority) {
+			ap.failMismatch("authority", fmt.Errorf("signed by %s, not %s", authority.Hex(), BytesToHex(auth.Authority)), RecoveredAddressDetail, authority.Hex())
+		}
+	}
+	return p.err()
+}
+
+// PopulateSender sets tx.From when it is empty, and the authority of every EIP-7702
+// authorization without one, to the recovered signers. Authorizations whose signature is
+// invalid are left without an authority, as EIP-7702 skips them rather than rejecting the
+// transaction. Existing values are not checked; use VerifySender for that.
+func PopulateSender(tx *Transaction) error {
+	if tx == nil {
+		return errors.New("transaction is nil")
+	}
+	if len(tx.From) == 0 {
+		from, err := RecoverSender(tx)
+		if err != nil {
+			return err
+		}
+		tx.From = from.Bytes()
+	}
+	for _, auth := range tx.AuthorizationList {
+		if auth == nil || len(auth.Authority) > 0 {
+			continue
+		}
+		if authority, err := RecoverAuthority(auth); err == nil {
+			auth.Authority = authority.Bytes()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package evm
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// testSign signs hash with the private key d, deriving the nonce from both so signatures are
+// deterministic. It normalizes s to the lower half of the curve order like real signers.
+func testSign(t *testing.T, hash Hash, d int64) (r, s []byte, yParity uint32) {
+	t.Helper()
+	key := big.NewInt(d)
+	k := new(big.Int).SetBytes(Keccak256(key.Bytes(), hash[:]))
+	k.Mod(k, secp256k1N)
+	rx, ry := newJacobianPoint(secp256k1Gx, secp256k1Gy).mul(k).affine()
+	rInt := new(big.Int).Mod(rx, secp256k1N)
+	sInt := new(big.Int).Mul(rInt, key)
+	sInt.Add(sInt, new(big.Int).SetBytes(hash[:]))
+	sInt.Mul(sInt, new(big.Int).ModInverse(k, secp256k1N))
+	sInt.Mod(sInt, secp256k1N)
+	yParity = uint32(ry.Bit(0))
+	if sInt.Cmp(secp256k1HalfN) > 0 {
+		sInt.Sub(secp256k1N, sInt)
+		yParity ^= 1
+	}
+	return rInt.Bytes(), sInt.Bytes(), yParity
+}
+
+var (
+	// The addresses of the private keys 1 and 2
+	testKey1Address = MustHexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	testKey2Address = MustHexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF")
+)
+
+// signTestTransaction signs tx with key 1 and its authorizations with key 2.
+func signTestTransaction(t *testing.T, tx *Transaction) {
+	t.Helper()
+	for _, auth := range tx.AuthorizationList {
+		auth.R, auth.S, auth.YParity = testSign(t, AuthorizationSigningHash(auth), 2)
+	}
+	// Legacy signing hashes depend on the chain encoded in v, not on the parity
+	var yParity uint32
+	tx.YParity = &yParity
+	h, err := TransactionSigningHash(tx)
+	if err != nil {
+		t.Fatalf("TransactionSigningHash failed: %v", err)
+	}
+	tx.R, tx.S, yParity = testSign(t, h, 1)
+	tx.YParity = &yParity
+}
+
+func TestEcrecover(t *testing.T) {
+	if g := newJacobianPoint(secp256k1Gx, secp256k1Gy).mul(secp256k1N); !g.isInfinity() {
+		t.Errorf("Expected n·G to be the point at infinity")
+	}
+	hash := Hash(Keccak256([]byte("hello")))
+	r, s, yParity := testSign(t, hash, 1)
+	if got, err := ecrecoverAddress(hash[:], r, s, yParity); err != nil || got != testKey1Address {
+		t.Errorf("Expected %s, got %s (%v)", testKey1Address.Hex(), got.Hex(), err)
+	}
+	if got, err := ecrecoverAddress(hash[:], r, s, yParity^1); err == nil && got == testKey1Address {
+		t.Errorf("Recovery with the wrong parity returned the signer")
+	}
+	invalid := map[string][2][]byte{
+		"ZeroR":      {nil, s},
+		"ZeroS":      {r, nil},
+		"ROverN":     {secp256k1N.Bytes(), s},
+		"TooLong":    {append([]byte{1}, make([]byte, 32)...), s},
+		"NotOnCurve": {big.NewInt(5).Bytes(), s},
+	}
+	for name, sig := range invalid {
+		if _, err := ecrecover(hash[:], sig[0], sig[1], 0); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRecoverSenderEIP155(t *testing.T) {
+	tx := eip155ExampleTransaction()
+	h, err := TransactionSigningHash(tx)
+	if err != nil {
+		t.Fatalf("TransactionSigningHash failed: %v", err)
+	}
+	if want := "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"; h.Hex() != want {
+		t.Errorf("Expected signing hash %s, got %s", want, h.Hex())
+	}
+	from, err := RecoverSender(tx)
+	if err != nil {
+		t.Fatalf("RecoverSender failed: %v", err)
+	}
+	if want := "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"; from.Hex() != want {
+		t.Errorf("Expected sender %s, got %s", want, from.Hex())
+	}
+
+	t.Run("ChainIdMismatch", func(t *testing.T) {
+		tx := eip155ExampleTransaction()
+		chainId := uint64(5)
+		tx.ChainId = &chainId
+		if _, err := RecoverSender(tx); err == nil {
+			t.Errorf("Expected an error for v of chain 1 with chainId 5")
+		}
+	})
+}
+
+func TestRecoverSenderAllTypes(t *testing.T) {
+	preEIP155 := eip155ExampleTransaction()
+	preEIP155.V = nil
+	eip155 := eip155ExampleTransaction()
+	chainId := uint64(1)
+	eip155.V, eip155.ChainId = nil, &chainId
+
+	tests := map[string]*Transaction{
+		"Legacy":     preEIP155,
+		"EIP155":     eip155,
+		"AccessList": typedTestTransaction(TransactionType_ACCESS_LIST),
+		"DynamicFee": typedTestTransaction(TransactionType_DYNAMIC_FEE),
+		"Blob":       typedTestTransaction(TransactionType_BLOB),
+		"SetCode":    typedTestTransaction(TransactionType_SET_CODE),
+	}
+	for name, tx := range tests {
+		t.Run(name, func(t *testing.T) {
+			signTestTransaction(t, tx)
+			from, err := RecoverSender(tx)
+			if err != nil || from != testKey1Address {
+				t.Fatalf("Expected %s, got %s (%v)", testKey1Address.Hex(), from.Hex(), err)
+			}
+			if err := PopulateSender(tx); err != nil {
+				t.Fatalf("PopulateSender failed: %v", err)
+			}
+			if BytesToHex(tx.From) != testKey1Address.Hex() {
+				t.Errorf("PopulateSender set from to %s", BytesToHex(tx.From))
+			}
+			for i, auth := range tx.AuthorizationList {
+				if BytesToHex(auth.Authority) != testKey2Address.Hex() {
+					t.Errorf("PopulateSender set authorizationList[%d].authority to %s", i, BytesToHex(auth.Authority))
+				}
+			}
+			if err := VerifySender(tx); err != nil {
+				t.Errorf("VerifySender failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifySenderMismatch(t *testing.T) {
+	tx := typedTestTransaction(TransactionType_SET_CODE)
+	signTestTransaction(t, tx)
+	tx.From = testKey2Address.Bytes()
+
+	err := VerifySender(tx)
+	var baseErr *common.BaseError
+	if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+		t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+	}
+	if path := baseErr.Details[ConvertErrorPathDetail]; path != "from" {
+		t.Errorf("Unexpected path %v", path)
+	}
+	if recovered := baseErr.Details[RecoveredAddressDetail]; recovered != testKey1Address.Hex() {
+		t.Errorf("Unexpected recovered address %v", recovered)
+	}
+
+	tx.AuthorizationList[0].Authority = testKey1Address.Bytes()
+	err = VerifySender(tx)
+	if !errors.As(err, &baseErr) {
+		t.Fatalf("Expected a BaseError, got %v", err)
+	}
+	want := []string{"from", "authorizationList[0].authority"}
+	if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected paths %v, got %v", want, paths)
+	}
+}
+
+func TestRecoverAuthority(t *testing.T) {
+	auth := &AuthorizationListItem{ChainId: 1, Address: testKey1Address.Bytes(), Nonce: 0}
+	auth.R, auth.S, auth.YParity = testSign(t, AuthorizationSigningHash(auth), 2)
+	if got, err := RecoverAuthority(auth); err != nil || got != testKey2Address {
+		t.Errorf("Expected %s, got %s (%v)", testKey2Address.Hex(), got.Hex(), err)
+	}
+
+	highS := &AuthorizationListItem{ChainId: 1, Address: auth.Address, R: auth.R, YParity: auth.YParity ^ 1}
+	highS.S = new(big.Int).Sub(secp256k1N, new(big.Int).SetBytes(auth.S)).Bytes()
+	if _, err := RecoverAuthority(highS); err == nil {
+		t.Errorf("Expected high s authorizations to be rejected")
+	}
+
+	tx := typedTestTransaction(TransactionType_SET_CODE)
+	signTestTransaction(t, tx)
+	tx.AuthorizationList[0].S = highS.S
+	if err := PopulateSender(tx); err != nil {
+		t.Fatalf("PopulateSender failed: %v", err)
+	}
+	if tx.AuthorizationList[0].Authority != nil {
+		t.Errorf("Expected the invalid authorization to be left without an authority")
+	}
+}
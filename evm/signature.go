@@ -0,0 +1,531 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SelectorLength is the length of a function selector in bytes
+const SelectorLength = 4
+
+// Selector is the 4-byte function selector prefixing contract call data: the first four bytes
+// of the Keccak-256 hash of the canonical function signature.
+type Selector [SelectorLength]byte
+
+// Bytes returns a copy of the selector.
+func (s Selector) Bytes() []byte { return append([]byte(nil), s[:]...) }
+
+// Hex returns the 0x-prefixed lowercase hex encoding of the selector.
+func (s Selector) Hex() string { return BytesToHex(s[:]) }
+
+func (s Selector) String() string { return s.Hex() }
+
+func (s Selector) MarshalText() ([]byte, error) { return []byte(s.Hex()), nil }
+
+func (s *Selector) UnmarshalText(text []byte) error { return fixedFromText(s[:], text, "selector") }
+
+// CanonicalSignature normalizes an event, function or error signature to the form that is
+// hashed: the name followed by the comma-separated parameter types, without spaces, parameter
+// names, the indexed keyword or data locations, with type aliases such as uint expanded and
+// tuples written as parenthesized lists. It accepts a bare signature such as
+// "Transfer(address,address,uint256)", a Solidity-style fragment such as
+// "event Transfer(address indexed from, address indexed to, uint256 value)", or a JSON ABI
+// entry with name and inputs.
+func CanonicalSignature(signature string) (string, error) {
+	s := strings.TrimSpace(signature)
+	var (
+		canonical string
+		err       error
+	)
+	if strings.HasPrefix(s, "{") {
+		canonical, err = canonicalJsonAbiSignature(s)
+	} else {
+		canonical, err = canonicalHumanSignature(s)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid signature %q: %w", signature, err)
+	}
+	return canonical, nil
+}
+
+// EventTopic returns topic0 of the logs emitted by the event with the given signature.
+func EventTopic(signature string) (Topic, error) {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return Topic{}, err
+	}
+	var t Topic
+	copy(t[:], Keccak256([]byte(canonical)))
+	return t, nil
+}
+
+// MustEventTopic is like EventTopic but panics on invalid signatures.
+func MustEventTopic(signature string) Topic {
+	t, err := EventTopic(signature)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// FunctionSelector returns the 4-byte selector of the function or custom error with the given
+// signature.
+func FunctionSelector(signature string) (Selector, error) {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return Selector{}, err
+	}
+	var s Selector
+	copy(s[:], Keccak256([]byte(canonical)))
+	return s, nil
+}
+
+// MustFunctionSelector is like FunctionSelector but panics on invalid signatures.
+func MustFunctionSelector(signature string) Selector {
+	s, err := FunctionSelector(signature)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// SignatureKind tells events from functions in a SignatureRegistry.
+type SignatureKind int
+
+const (
+	SignatureEvent SignatureKind = iota
+	SignatureFunction
+)
+
+func (k SignatureKind) String() string {
+	if k == SignatureFunction {
+		return "function"
+	}
+	return "event"
+}
+
+// Signature is a hashed event or function signature.
+type Signature struct {
+	Kind SignatureKind
+	// Name is the event or function name, e.g. "Transfer"
+	Name string
+	// Signature is the canonical signature, e.g. "Transfer(address,address,uint256)"
+	Signature string
+	// Standards lists the standards defining the signature, e.g. "ERC-20"
+	Standards []string
+	// Topic is topic0 of the event's logs; only set for events
+	Topic Topic
+	// Selector is the function selector; only set for functions
+	Selector Selector
+}
+
+// NewEventSignature hashes an event signature in any form accepted by CanonicalSignature.
+func NewEventSignature(signature string, standards ...string) (*Signature, error) {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	sig := &Signature{Kind: SignatureEvent, Name: signatureName(canonical), Signature: canonical, Standards: standards}
+	copy(sig.Topic[:], Keccak256([]byte(canonical)))
+	return sig, nil
+}
+
+// NewFunctionSignature hashes a function signature in any form accepted by CanonicalSignature.
+func NewFunctionSignature(signature string, standards ...string) (*Signature, error) {
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	sig := &Signature{Kind: SignatureFunction, Name: signatureName(canonical), Signature: canonical, Standards: standards}
+	copy(sig.Selector[:], Keccak256([]byte(canonical)))
+	return sig, nil
+}
+
+// SignatureRegistry looks up events by topic0 and functions by selector. It is safe for
+// concurrent use.
+type SignatureRegistry struct {
+	mu        sync.RWMutex
+	events    map[Topic]*Signature
+	functions map[Selector]*Signature
+}
+
+// NewSignatureRegistry returns a registry holding sigs.
+func NewSignatureRegistry(sigs ...*Signature) *SignatureRegistry {
+	r := &SignatureRegistry{events: map[Topic]*Signature{}, functions: map[Selector]*Signature{}}
+	r.Add(sigs...)
+	return r
+}
+
+// Add registers sigs, replacing signatures with the same topic or selector.
+func (r *SignatureRegistry) Add(sigs ...*Signature) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sig := range sigs {
+		if sig.Kind == SignatureFunction {
+			r.functions[sig.Selector] = sig
+		} else {
+			r.events[sig.Topic] = sig
+		}
+	}
+}
+
+// Event returns the event whose logs have topic0 t.
+func (r *SignatureRegistry) Event(t Topic) (*Signature, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sig, ok := r.events[t]
+	return sig, ok
+}
+
+// Function returns the function with selector s.
+func (r *SignatureRegistry) Function(s Selector) (*Signature, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sig, ok := r.functions[s]
+	return sig, ok
+}
+
+// Signatures returns the registered signatures, events first, each group sorted by signature.
+func (r *SignatureRegistry) Signatures() []*Signature {
+	r.mu.RLock()
+	sigs := make([]*Signature, 0, len(r.events)+len(r.functions))
+	for _, sig := range r.events {
+		sigs = append(sigs, sig)
+	}
+	for _, sig := range r.functions {
+		sigs = append(sigs, sig)
+	}
+	r.mu.RUnlock()
+	sort.Slice(sigs, func(i, j int) bool {
+		if sigs[i].Kind != sigs[j].Kind {
+			return sigs[i].Kind < sigs[j].Kind
+		}
+		return sigs[i].Signature < sigs[j].Signature
+	})
+	return sigs
+}
+
+// DefaultSignatureRegistry holds DefaultSignatures; call Add on it to register project-specific
+// signatures.
+var DefaultSignatureRegistry = NewSignatureRegistry(DefaultSignatures...)
+
+// DefaultSignatures covers the events and functions of ERC-20, ERC-721, ERC-1155, WETH and the
+// ERC-4337 EntryPoint (v0.6 and v0.7). ERC-20 and ERC-721 share the Transfer and Approval
+// events; they differ only in which parameters are indexed.
+var DefaultSignatures = []*Signature{
+	mustEvent("Transfer(address,address,uint256)", "ERC-20", "ERC-721"),
+	mustEvent("Approval(address,address,uint256)", "ERC-20", "ERC-721"),
+	mustEvent("ApprovalForAll(address,address,bool)", "ERC-721", "ERC-1155"),
+	mustEvent("TransferSingle(address,address,address,uint256,uint256)", "ERC-1155"),
+	mustEvent("TransferBatch(address,address,address,uint256[],uint256[])", "ERC-1155"),
+	mustEvent("URI(string,uint256)", "ERC-1155"),
+	mustEvent("Deposit(address,uint256)", "WETH"),
+	mustEvent("Withdrawal(address,uint256)", "WETH"),
+	mustEvent("UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)", "ERC-4337"),
+	mustEvent("AccountDeployed(bytes32,address,address,address)", "ERC-4337"),
+	mustEvent("UserOperationRevertReason(bytes32,address,uint256,bytes)", "ERC-4337"),
+	mustEvent("BeforeExecution()", "ERC-4337"),
+	mustEvent("Deposited(address,uint256)", "ERC-4337"),
+	mustEvent("Withdrawn(address,address,uint256)", "ERC-4337"),
+
+	mustFunction("name()", "ERC-20", "ERC-721"),
+	mustFunction("symbol()", "ERC-20", "ERC-721"),
+	mustFunction("decimals()", "ERC-20"),
+	mustFunction("totalSupply()", "ERC-20"),
+	mustFunction("balanceOf(address)", "ERC-20", "ERC-721"),
+	mustFunction("allowance(address,address)", "ERC-20"),
+	mustFunction("transfer(address,uint256)", "ERC-20"),
+	mustFunction("transferFrom(address,address,uint256)", "ERC-20", "ERC-721"),
+	mustFunction("approve(address,uint256)", "ERC-20", "ERC-721"),
+	mustFunction("ownerOf(uint256)", "ERC-721"),
+	mustFunction("tokenURI(uint256)", "ERC-721"),
+	mustFunction("getApproved(uint256)", "ERC-721"),
+	mustFunction("setApprovalForAll(address,bool)", "ERC-721", "ERC-1155"),
+	mustFunction("isApprovedForAll(address,address)", "ERC-721", "ERC-1155"),
+	mustFunction("safeTransferFrom(address,address,uint256)", "ERC-721"),
+	mustFunction("safeTransferFrom(address,address,uint256,bytes)", "ERC-721"),
+	mustFunction("balanceOf(address,uint256)", "ERC-1155"),
+	mustFunction("balanceOfBatch(address[],uint256[])", "ERC-1155"),
+	mustFunction("safeTransferFrom(address,address,uint256,uint256,bytes)", "ERC-1155"),
+	mustFunction("safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)", "ERC-1155"),
+	mustFunction("uri(uint256)", "ERC-1155"),
+	mustFunction("deposit()", "WETH"),
+	mustFunction("withdraw(uint256)", "WETH"),
+	mustFunction("handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address)", "ERC-4337"),
+	mustFunction("handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[],address)", "ERC-4337"),
+	mustFunction("depositTo(address)", "ERC-4337"),
+	mustFunction("getNonce(address,uint192)", "ERC-4337"),
+}
+
+func mustEvent(signature string, standards ...string) *Signature {
+	sig, err := NewEventSignature(signature, standards...)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func mustFunction(signature string, standards ...string) *Signature {
+	sig, err := NewFunctionSignature(signature, standards...)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func signatureName(canonical string) string {
+	return canonical[:strings.IndexByte(canonical, '(')]
+}
+
+// canonicalHumanSignature parses Solidity-style signatures. Anything after the closing
+// parenthesis of the parameter list (anonymous, visibility, mutability, returns) is ignored.
+func canonicalHumanSignature(s string) (string, error) {
+	for _, keyword := range []string{"event", "function", "error"} {
+		if rest, ok := strings.CutPrefix(s, keyword); ok && rest != "" && isSignatureSpace(rest[0]) {
+			s = strings.TrimSpace(rest)
+			break
+		}
+	}
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		return "", errors.New("missing parameter list")
+	}
+	name := strings.TrimSpace(s[:open])
+	if !isIdentifier(name) {
+		return "", fmt.Errorf("invalid name %q", name)
+	}
+	p := &signatureParser{s: s, pos: open}
+	params, err := p.tuple()
+	if err != nil {
+		return "", err
+	}
+	return name + params, nil
+}
+
+type signatureParser struct {
+	s   string
+	pos int
+}
+
+// tuple parses a parenthesized parameter list starting at p.pos and returns its canonical form.
+func (p *signatureParser) tuple() (string, error) {
+	p.pos++ // (
+	var types []string
+	p.skipSpace()
+	if p.peek() == ')' {
+		p.pos++
+		return "()", nil
+	}
+	for {
+		typ, err := p.param()
+		if err != nil {
+			return "", err
+		}
+		types = append(types, typ)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return "(" + strings.Join(types, ",") + ")", nil
+		default:
+			return "", fmt.Errorf("unterminated parameter list at offset %d", p.pos)
+		}
+	}
+}
+
+// param parses one parameter: a type with optional array suffixes, followed by words such as
+// indexed, a data location or the parameter name, which are dropped.
+func (p *signatureParser) param() (string, error) {
+	p.skipSpace()
+	var typ string
+	if word := p.peekWord(); p.peek() == '(' || word == "tuple" && p.peekAfter(len(word)) == '(' {
+		p.pos += len(word)
+		p.skipSpace()
+		inner, err := p.tuple()
+		if err != nil {
+			return "", err
+		}
+		typ = inner
+	} else {
+		if word == "" {
+			return "", fmt.Errorf("missing parameter type at offset %d", p.pos)
+		}
+		p.pos += len(word)
+		elem, err := canonicalElementType(word)
+		if err != nil {
+			return "", err
+		}
+		typ = elem
+	}
+	for p.skipSpace(); p.peek() == '['; p.skipSpace() {
+		end := strings.IndexByte(p.s[p.pos:], ']')
+		if end < 0 {
+			return "", errors.New("unterminated array type")
+		}
+		size := strings.TrimSpace(p.s[p.pos+1 : p.pos+end])
+		if size != "" {
+			if n, err := strconv.ParseUint(size, 10, 64); err != nil || n == 0 {
+				return "", fmt.Errorf("invalid array size %q", size)
+			}
+		}
+		typ += "[" + size + "]"
+		p.pos += end + 1
+	}
+	for {
+		word := p.peekWord()
+		if word == "" {
+			break
+		}
+		p.pos += len(word)
+		p.skipSpace()
+	}
+	return typ, nil
+}
+
+func (p *signatureParser) peek() byte {
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *signatureParser) peekAfter(n int) byte {
+	i := p.pos + n
+	for i < len(p.s) && isSignatureSpace(p.s[i]) {
+		i++
+	}
+	if i >= len(p.s) {
+		return 0
+	}
+	return p.s[i]
+}
+
+func (p *signatureParser) peekWord() string {
+	end := p.pos
+	for end < len(p.s) && isIdentifierByte(p.s[end]) {
+		end++
+	}
+	return p.s[p.pos:end]
+}
+
+func (p *signatureParser) skipSpace() {
+	for p.pos < len(p.s) && isSignatureSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+// canonicalElementType validates an elementary ABI type and expands its aliases.
+func canonicalElementType(t string) (string, error) {
+	switch t {
+	case "address", "bool", "string", "bytes", "function":
+		return t, nil
+	case "uint", "int":
+		return t + "256", nil
+	case "byte":
+		return "bytes1", nil
+	case "fixed", "ufixed":
+		return t + "128x18", nil
+	}
+	switch {
+	case strings.HasPrefix(t, "uint"):
+		if validIntSize(t[4:]) {
+			return t, nil
+		}
+	case strings.HasPrefix(t, "int"):
+		if validIntSize(t[3:]) {
+			return t, nil
+		}
+	case strings.HasPrefix(t, "bytes"):
+		if n, err := strconv.Atoi(t[5:]); err == nil && n >= 1 && n <= 32 && strconv.Itoa(n) == t[5:] {
+			return t, nil
+		}
+	case strings.HasPrefix(t, "ufixed"), strings.HasPrefix(t, "fixed"):
+		m, n, ok := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(t, "u"), "fixed"), "x")
+		if d, err := strconv.Atoi(n); ok && validIntSize(m) && err == nil && d >= 1 && d <= 80 && strconv.Itoa(d) == n {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown type %q", t)
+}
+
+func validIntSize(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 8 && n <= 256 && n%8 == 0 && strconv.Itoa(n) == s
+}
+
+func isIdentifier(s string) bool {
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isIdentifierByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifierByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '$'
+}
+
+func isSignatureSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// jsonAbiParam is an input of a JSON ABI entry. Tuple types list their members in components.
+type jsonAbiParam struct {
+	Type       string         `json:"type"`
+	Components []jsonAbiParam `json:"components"`
+}
+
+func canonicalJsonAbiSignature(s string) (string, error) {
+	var entry struct {
+		Type   string         `json:"type"`
+		Name   string         `json:"name"`
+		Inputs []jsonAbiParam `json:"inputs"`
+	}
+	if err := json.Unmarshal([]byte(s), &entry); err != nil {
+		return "", err
+	}
+	switch entry.Type {
+	case "", "event", "function", "error":
+	default:
+		return "", fmt.Errorf("ABI entries of type %q have no signature", entry.Type)
+	}
+	if !isIdentifier(entry.Name) {
+		return "", fmt.Errorf("invalid name %q", entry.Name)
+	}
+	params, err := canonicalJsonAbiTuple(entry.Inputs)
+	if err != nil {
+		return "", err
+	}
+	return entry.Name + params, nil
+}
+
+func canonicalJsonAbiTuple(params []jsonAbiParam) (string, error) {
+	types := make([]string, len(params))
+	for i, param := range params {
+		base, suffix, _ := strings.Cut(param.Type, "[")
+		if suffix != "" {
+			suffix = "[" + suffix
+		}
+		var err error
+		if base == "tuple" {
+			base, err = canonicalJsonAbiTuple(param.Components)
+		} else {
+			base, err = canonicalElementType(base)
+		}
+		if err != nil {
+			return "", err
+		}
+		types[i] = base + suffix
+	}
+	return "(" + strings.Join(types, ",") + ")", nil
+}
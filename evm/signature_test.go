@@ -0,0 +1,126 @@
+package evm
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCanonicalSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		want      string
+	}{
+		{"Bare", "Transfer(address,address,uint256)", "Transfer(address,address,uint256)"},
+		{"EventFragment", "event Transfer(address indexed from, address indexed to, uint256 value)", "Transfer(address,address,uint256)"},
+		{"Aliases", "function foo(uint a, int b, byte c, fixed d) external view returns (uint)", "foo(uint256,int256,bytes1,fixed128x18)"},
+		{"Locations", "function bar(bytes calldata data, string memory s, address payable to)", "bar(bytes,string,address)"},
+		{"NestedTuples", "function f((uint a, (bool, bytes32[2])[] inner) calldata x, tuple(address) y)", "f((uint256,(bool,bytes32[2])[]),(address))"},
+		{"NoParams", "  BeforeExecution( ) ", "BeforeExecution()"},
+		{"AnonymousEvent", "event Log(bytes32 indexed) anonymous", "Log(bytes32)"},
+		{
+			"JsonAbi",
+			`{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[{"name":"userOpHash","type":"bytes32","indexed":true},{"name":"sender","type":"address","indexed":true},{"name":"paymaster","type":"address","indexed":true},{"name":"nonce","type":"uint256"},{"name":"success","type":"bool"},{"name":"actualGasCost","type":"uint256"},{"name":"actualGasUsed","type":"uint256"}]}`,
+			"UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)",
+		},
+		{
+			"JsonAbiTuple",
+			`{"type":"function","name":"handleOps","inputs":[{"type":"tuple[]","components":[{"type":"address"},{"type":"uint"},{"type":"tuple[2]","components":[{"type":"bytes"}]}]},{"type":"address"}]}`,
+			"handleOps((address,uint256,(bytes)[2])[],address)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalSignature(tt.signature)
+			if err != nil {
+				t.Fatalf("CanonicalSignature() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalSignature() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "Transfer", "Transfer(address", "Transfer(address,,uint256)", "Transfer(uint7)", "Transfer(bytes33)", "1x(uint256)", "f(uint256[0])", `{"type":"constructor","inputs":[]}`} {
+		if got, err := CanonicalSignature(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected, got %s", invalid, got)
+		}
+	}
+}
+
+func TestSignatureHashes(t *testing.T) {
+	events := map[string]string{
+		"event Transfer(address indexed from, address indexed to, uint256 value)":  TransferEventSignature,
+		"Approval(address,address,uint256)":                                        ApprovalEventSignature,
+		"ApprovalForAll(address,address,bool)":                                     ApprovalForAllEventSignature,
+		"TransferSingle(address,address,address,uint256,uint256)":                  TransferSingleEventSignature,
+		"TransferBatch(address,address,address,uint256[],uint256[])":               TransferBatchEventSignature,
+		"Deposit(address,uint256)":                                                 DepositEventSignature,
+		"Withdrawal(address,uint256)":                                              WithdrawalEventSignature,
+		"UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)": UserOperationEventSignature,
+	}
+	for signature, want := range events {
+		if got := MustEventTopic(signature).Hex(); got != want {
+			t.Errorf("EventTopic(%s) = %s, want %s", signature, got, want)
+		}
+	}
+
+	functions := map[string]string{
+		"transfer(address,uint256)":                                     "0xa9059cbb",
+		"function approve(address spender, uint amount) returns (bool)": "0x095ea7b3",
+		"balanceOf(address)":                                            "0x70a08231",
+		"safeTransferFrom(address,address,uint256,uint256,bytes)":       "0xf242432a",
+		"handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address)": "0x1fad948c",
+		"handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[],address)":                 "0x765e827f",
+	}
+	for signature, want := range functions {
+		if got := MustFunctionSelector(signature).Hex(); got != want {
+			t.Errorf("FunctionSelector(%s) = %s, want %s", signature, got, want)
+		}
+	}
+}
+
+func TestSignatureRegistry(t *testing.T) {
+	sig, ok := DefaultSignatureRegistry.Event(MustHexToTopic(TransferEventSignature))
+	if !ok || sig.Name != "Transfer" || len(sig.Standards) != 2 {
+		t.Errorf("Unexpected Transfer signature: %+v", sig)
+	}
+	sig, ok = DefaultSignatureRegistry.Function(MustFunctionSelector("withdraw(uint256)"))
+	if !ok || sig.Kind != SignatureFunction || sig.Standards[0] != "WETH" {
+		t.Errorf("Unexpected withdraw signature: %+v", sig)
+	}
+	if _, ok := DefaultSignatureRegistry.Function(Selector{}); ok {
+		t.Error("Expected no function with a zero selector")
+	}
+
+	r := NewSignatureRegistry(DefaultSignatures...)
+	custom, err := NewEventSignature("event Swap(address indexed sender, uint amount0In, uint amount1In, uint amount0Out, uint amount1Out, address indexed to)", "Uniswap V2")
+	if err != nil {
+		t.Fatalf("NewEventSignature() error = %v", err)
+	}
+	r.Add(custom)
+	if got, ok := r.Event(custom.Topic); !ok || got.Signature != "Swap(address,uint256,uint256,uint256,uint256,address)" {
+		t.Errorf("Custom signature not found: %+v", got)
+	}
+	if _, ok := DefaultSignatureRegistry.Event(custom.Topic); ok {
+		t.Error("Adding to a registry must not change DefaultSignatureRegistry")
+	}
+	if sigs := r.Signatures(); len(sigs) != len(DefaultSignatures)+1 || sigs[0].Kind != SignatureEvent || sigs[len(sigs)-1].Kind != SignatureFunction {
+		t.Errorf("Unexpected Signatures(): %d entries", len(sigs))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r.Add(custom)
+		}()
+		go func() {
+			defer wg.Done()
+			r.Event(custom.Topic)
+			r.Signatures()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,268 @@
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ComputedHashDetail is the BaseError detail key holding the hash computed from a message's
+// contents when it does not match the hash the message carries.
+const ComputedHashDetail = "computedHash"
+
+// Signed transactions are encoded as RLP lists. Legacy transactions are the bare list; EIP-2718
+// typed transactions are the type byte followed by the list:
+//
+//	legacy: [nonce, gasPrice, gas, to, value, data, v, r, s]
+//	0x01:   [chainId, nonce, gasPrice, gas, to, value, data, accessList, yParity, r, s]
+//	0x02:   [chainId, nonce, maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList, yParity, r, s]
+//	0x03:   [chainId, nonce, maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList,
+//	         maxFeePerBlobGas, blobVersionedHashes, yParity, r, s]
+//	0x04:   [chainId, nonce, maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList,
+//	         authorizationList, yParity, r, s]
+//
+// The legacy v is chainId*2+35+parity for EIP-155 transactions and 27+parity before it.
+
+// EncodeTransaction returns the raw signed transaction of tx, as accepted by
+// eth_sendRawTransaction, and its hash. Wei amounts are read from their U256 fields when set and
+// from the string fields otherwise. Deposit and other chain-specific types are not supported.
+// Errors are common.BaseError values with code INVALID_PARAMETER listing every missing or
+// invalid field under the paths detail.
+func EncodeTransaction(tx *Transaction) ([]byte, Hash, error) {
+	if tx == nil {
+		return nil, Hash{}, errors.New("transaction is nil")
+	}
+	p := newFieldParser(true)
+	raw := appendSignedTransaction(p, nil, tx)
+	if err := p.err(); err != nil {
+		return nil, Hash{}, err
+	}
+	return raw, Hash(Keccak256(raw)), nil
+}
+
+// TransactionHash returns the hash of tx computed from its contents.
+func TransactionHash(tx *Transaction) (Hash, error) {
+	_, h, err := EncodeTransaction(tx)
+	return h, err
+}
+
+// VerifyTransactionHash checks that tx.Hash is the hash of the transaction contents. A mismatch
+// is a common.BaseError with code INVALID_PARAMETER, the path detail "hash" and the computed
+// hash under the computedHash detail.
+func VerifyTransactionHash(tx *Transaction) error {
+	_, h, err := EncodeTransaction(tx)
+	if err != nil {
+		return err
+	}
+	p := newFieldParser(true)
+	if !bytes.Equal(h[:], tx.Hash) {
+		p.failMismatch("hash", fmt.Errorf("transaction hashes to %s, not %s", h.Hex(), BytesToHex(tx.Hash)), ComputedHashDetail, h.Hex())
+	}
+	return p.err()
+}
+
+// appendSignedTransaction appends the raw signed transaction of tx to dst.
+func appendSignedTransaction(p *fieldParser, dst []byte, tx *Transaction) []byte {
+	typ := tx.TransactionType()
+	if !isEncodableTransactionType(typ) {
+		p.fail("type", fmt.Errorf("encoding %s transactions is not supported", typ))
+		return dst
+	}
+	payload := appendTransactionFields(p, nil, tx)
+	if typ == TransactionType_LEGACY {
+		payload = rlpAppendBytes(payload, trimLeadingZeros(legacyV(p, tx)))
+	} else {
+		payload = rlpAppendUint(payload, uint64(typedYParity(p, tx)))
+	}
+	payload = appendRlpInteger(p, payload, "r", tx.R)
+	payload = appendRlpInteger(p, payload, "s", tx.S)
+	if typ != TransactionType_LEGACY {
+		dst = append(dst, byte(typ))
+	}
+	return rlpAppendList(dst, payload)
+}
+
+func isEncodableTransactionType(t TransactionType) bool {
+	switch t {
+	case TransactionType_LEGACY, TransactionType_ACCESS_LIST, TransactionType_DYNAMIC_FEE,
+		TransactionType_BLOB, TransactionType_SET_CODE:
+		return true
+	}
+	return false
+}
+
+// appendTransactionFields appends the RLP items of tx that precede its signature. The type
+// must be one of isEncodableTransactionType.
+func appendTransactionFields(p *fieldParser, dst []byte, tx *Transaction) []byte {
+	typ := tx.TransactionType()
+	switch typ {
+	case TransactionType_LEGACY:
+		dst = rlpAppendUint(dst, tx.Nonce)
+		dst = appendRlpQuantity(p, dst, "gasPrice", tx.GasPrice, tx.GasPriceU256)
+		return appendTransactionCall(p, dst, tx)
+	case TransactionType_ACCESS_LIST:
+		dst = rlpAppendUint(dst, requiredChainId(p, tx))
+		dst = rlpAppendUint(dst, tx.Nonce)
+		dst = appendRlpQuantity(p, dst, "gasPrice", tx.GasPrice, tx.GasPriceU256)
+		dst = appendTransactionCall(p, dst, tx)
+		return appendRlpAccessList(p, dst, tx.AccessList)
+	case TransactionType_DYNAMIC_FEE, TransactionType_BLOB, TransactionType_SET_CODE:
+		dst = rlpAppendUint(dst, requiredChainId(p, tx))
+		dst = rlpAppendUint(dst, tx.Nonce)
+		dst = appendRlpQuantity(p, dst, "maxPriorityFeePerGas", tx.MaxPriorityFeePerGas, tx.MaxPriorityFeePerGasU256)
+		dst = appendRlpQuantity(p, dst, "maxFeePerGas", tx.MaxFeePerGas, tx.MaxFeePerGasU256)
+		dst = appendTransactionCall(p, dst, tx)
+		dst = appendRlpAccessList(p, dst, tx.AccessList)
+	}
+
+	switch typ {
+	case TransactionType_BLOB:
+		if len(tx.To) == 0 {
+			p.fail("to", errors.New("blob transactions cannot create contracts"))
+		}
+		dst = appendRlpQuantity(p, dst, "maxFeePerBlobGas", tx.MaxFeePerBlobGas, tx.MaxFeePerBlobGasU256)
+		var hashes []byte
+		for i, h := range tx.BlobVersionedHashes {
+			if len(h) != HashLength {
+				p.fail(fmt.Sprintf("blobVersionedHashes[%d]", i), fmt.Errorf("expected %d bytes, got %d", HashLength, len(h)))
+			}
+			hashes = rlpAppendBytes(hashes, h)
+		}
+		dst = rlpAppendList(dst, hashes)
+	case TransactionType_SET_CODE:
+		if len(tx.To) == 0 {
+			p.fail("to", errors.New("set code transactions cannot create contracts"))
+		}
+		var auths []byte
+		for i, auth := range tx.AuthorizationList {
+			ap := p.item("authorizationList", i)
+			if auth == nil {
+				ap.fail("", errors.New("authorization is nil"))
+				continue
+			}
+			var item []byte
+			item = rlpAppendUint(item, auth.ChainId)
+			item = appendRlpFixed(ap, item, "address", auth.Address, AddressLength)
+			item = rlpAppendUint(item, auth.Nonce)
+			item = rlpAppendUint(item, uint64(auth.YParity))
+			item = appendRlpInteger(ap, item, "r", auth.R)
+			item = appendRlpInteger(ap, item, "s", auth.S)
+			auths = rlpAppendList(auths, item)
+		}
+		dst = rlpAppendList(dst, auths)
+	}
+	return dst
+}
+
+// appendTransactionCall appends the gas, to, value and data items shared by every type.
+func appendTransactionCall(p *fieldParser, dst []byte, tx *Transaction) []byte {
+	dst = rlpAppendUint(dst, tx.GasLimit)
+	if len(tx.To) == 0 {
+		dst = rlpAppendBytes(dst, nil)
+	} else {
+		dst = appendRlpFixed(p, dst, "to", tx.To, AddressLength)
+	}
+	dst = appendRlpQuantity(p, dst, "value", &tx.Value, tx.ValueU256)
+	return rlpAppendBytes(dst, tx.Input)
+}
+
+func appendRlpAccessList(p *fieldParser, dst []byte, list []*AccessListItem) []byte {
+	var items []byte
+	for i, entry := range list {
+		ep := p.item("accessList", i)
+		if entry == nil {
+			ep.fail("", errors.New("access list entry is nil"))
+			continue
+		}
+		var item, keys []byte
+		item = appendRlpFixed(ep, item, "address", entry.Address, AddressLength)
+		for j, key := range entry.StorageKeys {
+			keys = appendRlpFixed(ep, keys, fmt.Sprintf("storageKeys[%d]", j), key, HashLength)
+		}
+		item = rlpAppendList(item, keys)
+		items = rlpAppendList(items, item)
+	}
+	return rlpAppendList(dst, items)
+}
+
+// appendRlpQuantity appends a wei amount from its U256 bytes or, failing that, its string.
+// Missing amounts are encoded as 0.
+func appendRlpQuantity(p *fieldParser, dst []byte, name string, s *string, wide []byte) []byte {
+	var z U256
+	var err error
+	switch {
+	case wide != nil:
+		z, err = U256FromBytes(wide)
+	case s != nil && *s != "":
+		z, err = ParseU256(*s)
+	}
+	if err != nil {
+		p.fail(name, err)
+	}
+	return rlpAppendBytes(dst, trimLeadingZeros(z.Bytes()))
+}
+
+// appendRlpInteger appends a big-endian integer such as a signature value of at most 32 bytes.
+func appendRlpInteger(p *fieldParser, dst []byte, name string, b []byte) []byte {
+	b = trimLeadingZeros(b)
+	if len(b) > 32 {
+		p.fail(name, fmt.Errorf("expected at most 32 bytes, got %d", len(b)))
+	}
+	return rlpAppendBytes(dst, b)
+}
+
+func appendRlpFixed(p *fieldParser, dst []byte, name string, b []byte, size int) []byte {
+	if len(b) != size {
+		p.fail(name, fmt.Errorf("expected %d bytes, got %d", size, len(b)))
+	}
+	return rlpAppendBytes(dst, b)
+}
+
+func requiredChainId(p *fieldParser, tx *Transaction) uint64 {
+	requireUint64(p, "chainId", tx.ChainId)
+	return tx.GetChainId()
+}
+
+// legacyV returns the v of a legacy transaction, deriving it from yParity and chainId when the
+// transaction has no v.
+func legacyV(p *fieldParser, tx *Transaction) []byte {
+	if len(tx.V) > 0 {
+		return tx.V
+	}
+	if tx.YParity == nil {
+		p.fail("v", errors.New("signed transactions require v or yParity"))
+		return nil
+	}
+	v := new(big.Int).SetUint64(27 + uint64(*tx.YParity))
+	if tx.ChainId != nil {
+		v.SetUint64(*tx.ChainId)
+		v.Lsh(v, 1)
+		v.Add(v, big.NewInt(35+int64(*tx.YParity)))
+	}
+	return v.Bytes()
+}
+
+// typedYParity returns the signature parity of a typed transaction from yParity or v.
+func typedYParity(p *fieldParser, tx *Transaction) uint32 {
+	if tx.YParity != nil {
+		if *tx.YParity > 1 {
+			p.fail("yParity", fmt.Errorf("expected 0 or 1, got %d", *tx.YParity))
+		}
+		return *tx.YParity
+	}
+	v := trimLeadingZeros(tx.V)
+	switch {
+	case tx.V == nil:
+		p.fail("v", errors.New("signed transactions require v or yParity"))
+	case len(v) > 1 || len(v) == 1 && v[0] > 1:
+		p.fail("v", fmt.Errorf("typed transactions require v of 0 or 1, got %s", BytesToHex(tx.V)))
+	case len(v) == 1:
+		return 1
+	}
+	return 0
+}
+
+func trimLeadingZeros(b []byte) []byte {
+	return bytes.TrimLeft(b, "\x00")
+}
@@ -0,0 +1,225 @@
+package evm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+// eip155ExampleTransaction is the signed example transaction of EIP-155.
+func eip155ExampleTransaction() *Transaction {
+	gasPrice := "20000000000"
+	return &Transaction{
+		Nonce:    9,
+		GasPrice: &gasPrice,
+		GasLimit: 21000,
+		To:       MustHexToBytes("0x3535353535353535353535353535353535353535"),
+		Value:    "1000000000000000000",
+		V:        []byte{37},
+		R:        MustHexToBytes("0x28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"),
+		S:        MustHexToBytes("0x67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+	}
+}
+
+const eip155ExampleRaw = "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+
+// typedTestTransaction returns a signed transaction of typ with the fields every type uses.
+func typedTestTransaction(typ TransactionType) *Transaction {
+	chainId := uint64(1)
+	yParity := uint32(1)
+	gasPrice, maxFee, maxPriorityFee, maxFeePerBlobGas := "0x3b9aca00", "0x77359400", "0x3b9aca00", "0x1"
+	tx := &Transaction{
+		Type:     uint32(typ),
+		ChainId:  &chainId,
+		Nonce:    7,
+		GasLimit: 100000,
+		To:       MustHexToBytes("0x3535353535353535353535353535353535353535"),
+		Value:    "0x0",
+		Input:    MustHexToBytes("0xa9059cbb"),
+		AccessList: []*AccessListItem{{
+			Address:     MustHexToBytes("0xdac17f958d2ee523a2206206994597c13d831ec7"),
+			StorageKeys: [][]byte{make([]byte, 32)},
+		}},
+		YParity: &yParity,
+		R:       MustHexToBytes("0x28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"),
+		S:       MustHexToBytes("0x67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+	}
+	switch typ {
+	case TransactionType_ACCESS_LIST:
+		tx.GasPrice = &gasPrice
+	default:
+		tx.MaxFeePerGas = &maxFee
+		tx.MaxPriorityFeePerGas = &maxPriorityFee
+	}
+	switch typ {
+	case TransactionType_BLOB:
+		tx.MaxFeePerBlobGas = &maxFeePerBlobGas
+		tx.BlobVersionedHashes = [][]byte{MustHexToBytes("0x01a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")}
+	case TransactionType_SET_CODE:
+		tx.AuthorizationList = []*AuthorizationListItem{{
+			ChainId: 1,
+			Address: MustHexToBytes("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b"),
+			Nonce:   3,
+			YParity: 0,
+			R:       MustHexToBytes("0x01"),
+			S:       MustHexToBytes("0x02"),
+		}}
+	}
+	return tx
+}
+
+func TestEncodeTransactionLegacy(t *testing.T) {
+	t.Run("EIP155", func(t *testing.T) {
+		tx := eip155ExampleTransaction()
+		raw, h, err := EncodeTransaction(tx)
+		if err != nil {
+			t.Fatalf("EncodeTransaction failed: %v", err)
+		}
+		if BytesToHex(raw) != eip155ExampleRaw {
+			t.Errorf("Unexpected raw transaction %s", BytesToHex(raw))
+		}
+		if h.Hex() != BytesToHex(Keccak256(raw)) {
+			t.Errorf("Hash %s is not the Keccak-256 of the raw transaction", h.Hex())
+		}
+	})
+
+	t.Run("VFromYParity", func(t *testing.T) {
+		tx := eip155ExampleTransaction()
+		chainId, yParity := uint64(1), uint32(0)
+		tx.V, tx.ChainId, tx.YParity = nil, &chainId, &yParity
+		raw, _, err := EncodeTransaction(tx)
+		if err != nil || BytesToHex(raw) != eip155ExampleRaw {
+			t.Errorf("Expected v=37 to be derived, got %s (%v)", BytesToHex(raw), err)
+		}
+	})
+
+	t.Run("PreEIP155", func(t *testing.T) {
+		tx := eip155ExampleTransaction()
+		yParity := uint32(1)
+		tx.V, tx.YParity = nil, &yParity
+		raw, _, err := EncodeTransaction(tx)
+		if err != nil {
+			t.Fatalf("EncodeTransaction failed: %v", err)
+		}
+		items, err := rlpDecodeList(raw)
+		if err != nil || len(items) != 9 {
+			t.Fatalf("Expected a 9 item list, got %d (%v)", len(items), err)
+		}
+		if items[6][0] != 28 {
+			t.Errorf("Expected v=28, got %x", items[6])
+		}
+	})
+}
+
+func TestEncodeTransactionTyped(t *testing.T) {
+	tests := []struct {
+		typ    TransactionType
+		fields int
+	}{
+		{TransactionType_ACCESS_LIST, 11},
+		{TransactionType_DYNAMIC_FEE, 12},
+		{TransactionType_BLOB, 14},
+		{TransactionType_SET_CODE, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			tx := typedTestTransaction(tt.typ)
+			raw, h, err := EncodeTransaction(tx)
+			if err != nil {
+				t.Fatalf("EncodeTransaction failed: %v", err)
+			}
+			if raw[0] != byte(tt.typ) {
+				t.Fatalf("Expected type prefix %d, got %d", tt.typ, raw[0])
+			}
+			items, err := rlpDecodeList(raw[1:])
+			if err != nil {
+				t.Fatalf("Payload is not an RLP list: %v", err)
+			}
+			if len(items) != tt.fields {
+				t.Fatalf("Expected %d fields, got %d", tt.fields, len(items))
+			}
+			if items[0][0] != 1 || items[1][0] != 7 {
+				t.Errorf("Expected chainId 1 and nonce 7, got %x %x", items[0], items[1])
+			}
+			if parity := items[len(items)-3]; parity[0] != 1 {
+				t.Errorf("Expected yParity 1, got %x", parity)
+			}
+
+			tx.Hash = h.Bytes()
+			if err := VerifyTransactionHash(tx); err != nil {
+				t.Errorf("VerifyTransactionHash failed: %v", err)
+			}
+			withV := typedTestTransaction(tt.typ)
+			withV.YParity, withV.V = nil, []byte{1}
+			if _, h2, err := EncodeTransaction(withV); err != nil || h2 != h {
+				t.Errorf("Expected yParity to be read from v, got %s (%v)", h2.Hex(), err)
+			}
+		})
+	}
+
+	t.Run("U256Preferred", func(t *testing.T) {
+		tx := typedTestTransaction(TransactionType_DYNAMIC_FEE)
+		_, want, _ := EncodeTransaction(tx)
+		stale := "0x1"
+		tx.MaxFeePerGasU256 = MustParseU256(*tx.MaxFeePerGas).Bytes()
+		tx.MaxFeePerGas = &stale
+		if _, got, err := EncodeTransaction(tx); err != nil || got != want {
+			t.Errorf("Expected maxFeePerGasU256 to take precedence (%v)", err)
+		}
+	})
+}
+
+func TestVerifyTransactionHashMismatch(t *testing.T) {
+	tx := eip155ExampleTransaction()
+	tx.Hash = make([]byte, 32)
+	err := VerifyTransactionHash(tx)
+	var baseErr *common.BaseError
+	if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+		t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+	}
+	if path := baseErr.Details[ConvertErrorPathDetail]; path != "hash" {
+		t.Errorf("Unexpected path %v", path)
+	}
+	_, h, _ := EncodeTransaction(tx)
+	if computed := baseErr.Details[ComputedHashDetail]; computed != h.Hex() {
+		t.Errorf("Expected computed hash %s, got %v", h.Hex(), computed)
+	}
+}
+
+func TestEncodeTransactionErrors(t *testing.T) {
+	tx := typedTestTransaction(TransactionType_SET_CODE)
+	tx.ChainId = nil
+	tx.To = tx.To[:19]
+	tx.AccessList[0].StorageKeys[0] = []byte{1}
+	tx.AuthorizationList[0].Address = nil
+	badFee := "lots"
+	tx.MaxFeePerGas = &badFee
+
+	_, _, err := EncodeTransaction(tx)
+	var baseErr *common.BaseError
+	if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+		t.Fatalf("Expected an INVALID_PARAMETER BaseError, got %v", err)
+	}
+	want := []string{"chainId", "maxFeePerGas", "to", "accessList[0].storageKeys[0]", "authorizationList[0].address"}
+	if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected paths %v, got %v", want, paths)
+	}
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, _, err := EncodeTransaction(&Transaction{Type: uint32(TransactionType_OPTIMISM_DEPOSIT)})
+		if !errors.As(err, &baseErr) || baseErr.Details[ConvertErrorPathDetail] != "type" {
+			t.Errorf("Expected a type error, got %v", err)
+		}
+	})
+
+	t.Run("MissingSignature", func(t *testing.T) {
+		tx := typedTestTransaction(TransactionType_DYNAMIC_FEE)
+		tx.YParity = nil
+		_, _, err := EncodeTransaction(tx)
+		if !errors.As(err, &baseErr) || baseErr.Details[ConvertErrorPathDetail] != "v" {
+			t.Errorf("Expected a v error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TransactionType returns the EIP-2718 type of the transaction. Types missing from the
+// TransactionType enum are returned as is; see TransactionType.IsKnown.
+func (x *Transaction) TransactionType() TransactionType {
+	return TransactionType(x.GetType())
+}
+
+// TransactionType returns the EIP-2718 type of the receipt's transaction.
+func (x *Receipt) TransactionType() TransactionType {
+	return TransactionType(x.GetType())
+}
+
+// IsKnown reports whether t is listed in the TransactionType enum.
+func (t TransactionType) IsKnown() bool {
+	_, ok := TransactionType_name[int32(t)]
+	return ok
+}
+
+// IsDeposit reports whether t is minted from an L1 message rather than signed by its sender:
+// OP stack deposits and Arbitrum deposits, unsigned, contract, retry and submit-retryable
+// transactions.
+func (t TransactionType) IsDeposit() bool {
+	switch t {
+	case TransactionType_OPTIMISM_DEPOSIT,
+		TransactionType_ARBITRUM_DEPOSIT,
+		TransactionType_ARBITRUM_UNSIGNED,
+		TransactionType_ARBITRUM_CONTRACT,
+		TransactionType_ARBITRUM_RETRY,
+		TransactionType_ARBITRUM_SUBMIT_RETRYABLE:
+		return true
+	}
+	return false
+}
+
+// Ecosystem returns the chain family defining t, or EcosystemAuto for Ethereum types.
+func (t TransactionType) Ecosystem() Ecosystem {
+	return transactionTypeEcosystem(uint32(t))
+}
+
+// transactionTypeValidators check the fields each transaction type requires. Paths are
+// relative to the transaction, e.g. "optimism.sourceHash".
+var transactionTypeValidators = map[TransactionType]func(p *fieldParser, tx *Transaction){
+	TransactionType_LEGACY: func(p *fieldParser, tx *Transaction) {
+		requireQuantity(p, "gasPrice", tx.GasPrice, tx.GasPriceU256)
+		requireSignature(p, tx)
+	},
+	TransactionType_ACCESS_LIST: func(p *fieldParser, tx *Transaction) {
+		requireQuantity(p, "gasPrice", tx.GasPrice, tx.GasPriceU256)
+		requireUint64(p, "chainId", tx.ChainId)
+		requireSignature(p, tx)
+	},
+	TransactionType_DYNAMIC_FEE: func(p *fieldParser, tx *Transaction) {
+		requireDynamicFee(p, tx)
+		requireSignature(p, tx)
+	},
+	TransactionType_BLOB: func(p *fieldParser, tx *Transaction) {
+		requireDynamicFee(p, tx)
+		requireQuantity(p, "maxFeePerBlobGas", tx.MaxFeePerBlobGas, tx.MaxFeePerBlobGasU256)
+		requireBytes(p, "to", tx.To)
+		if len(tx.BlobVersionedHashes) == 0 {
+			p.fail("blobVersionedHashes", errors.New("blob transactions carry at least one blob"))
+		}
+		requireSignature(p, tx)
+	},
+	TransactionType_SET_CODE: func(p *fieldParser, tx *Transaction) {
+		requireDynamicFee(p, tx)
+		requireBytes(p, "to", tx.To)
+		if len(tx.AuthorizationList) == 0 {
+			p.fail("authorizationList", errors.New("set code transactions carry at least one authorization"))
+		}
+		requireSignature(p, tx)
+	},
+	TransactionType_OPTIMISM_DEPOSIT: func(p *fieldParser, tx *Transaction) {
+		ext := tx.Optimism
+		if ext == nil {
+			p.fail("optimism", errors.New("deposit transactions require the optimism extension"))
+			return
+		}
+		op := p.child("optimism")
+		if len(ext.SourceHash) != HashLength {
+			op.fail("sourceHash", fmt.Errorf("expected %d bytes, got %d", HashLength, len(ext.SourceHash)))
+		}
+		if ext.Mint != nil {
+			if _, ok := parseWei(*ext.Mint); !ok {
+				op.fail("mint", fmt.Errorf("invalid amount %q", *ext.Mint))
+			}
+		}
+	},
+	TransactionType_ARBITRUM_DEPOSIT: func(p *fieldParser, tx *Transaction) {
+		a := requireArbitrum(p, tx)
+		if a == nil {
+			return
+		}
+		requireBytes(a, "requestId", tx.Arbitrum.RequestId)
+		requireBytes(p, "to", tx.To)
+	},
+	TransactionType_ARBITRUM_UNSIGNED: func(p *fieldParser, tx *Transaction) {
+		requireQuantity(p, "maxFeePerGas", tx.MaxFeePerGas, tx.MaxFeePerGasU256)
+	},
+	TransactionType_ARBITRUM_CONTRACT: func(p *fieldParser, tx *Transaction) {
+		requireQuantity(p, "maxFeePerGas", tx.MaxFeePerGas, tx.MaxFeePerGasU256)
+		if a := requireArbitrum(p, tx); a != nil {
+			requireBytes(a, "requestId", tx.Arbitrum.RequestId)
+		}
+	},
+	TransactionType_ARBITRUM_RETRY: func(p *fieldParser, tx *Transaction) {
+		a := requireArbitrum(p, tx)
+		if a == nil {
+			return
+		}
+		ext := tx.Arbitrum
+		requireBytes(a, "ticketId", ext.TicketId)
+		requireBytes(a, "refundTo", ext.RefundTo)
+		requireQuantity(a, "maxRefund", ext.MaxRefund, ext.MaxRefundU256)
+		requireQuantity(a, "submissionFeeRefund", ext.SubmissionFeeRefund, ext.SubmissionFeeRefundU256)
+	},
+	TransactionType_ARBITRUM_SUBMIT_RETRYABLE: func(p *fieldParser, tx *Transaction) {
+		a := requireArbitrum(p, tx)
+		if a == nil {
+			return
+		}
+		ext := tx.Arbitrum
+		requireBytes(a, "requestId", ext.RequestId)
+		requireQuantity(a, "l1BaseFee", ext.L1BaseFee, ext.L1BaseFeeU256)
+		requireQuantity(a, "depositValue", ext.DepositValue, ext.DepositValueU256)
+		requireQuantity(a, "maxSubmissionFee", ext.MaxSubmissionFee, ext.MaxSubmissionFeeU256)
+		requireBytes(a, "beneficiary", ext.Beneficiary)
+		requireBytes(a, "refundTo", ext.RefundTo)
+	},
+	TransactionType_ARBITRUM_INTERNAL: func(p *fieldParser, tx *Transaction) {
+		requireBytes(p, "input", tx.Input)
+	},
+}
+
+// ValidateTransaction checks that tx carries the fields its type requires, such as the
+// signature of signed types, sourceHash for OP deposits and ticketId for Arbitrum retries.
+// Chain-specific fields are read from the chain extensions, so run
+// PopulateTransactionExtensions first on messages that only have the flat fields. Required
+// quantities may be set as strings or only as their U256 fields. Types missing from the
+// TransactionType enum are not checked. The error is a common.BaseError with code
+// INVALID_PARAMETER listing every missing or invalid field under the paths detail.
+func ValidateTransaction(tx *Transaction) error {
+	if tx == nil {
+		return errors.New("transaction is nil")
+	}
+	validate, ok := transactionTypeValidators[tx.TransactionType()]
+	if !ok {
+		return nil
+	}
+	p := newFieldParser(true)
+	validate(p, tx)
+	return p.err()
+}
+
+func requireDynamicFee(p *fieldParser, tx *Transaction) {
+	requireQuantity(p, "maxFeePerGas", tx.MaxFeePerGas, tx.MaxFeePerGasU256)
+	requireQuantity(p, "maxPriorityFeePerGas", tx.MaxPriorityFeePerGas, tx.MaxPriorityFeePerGasU256)
+	requireUint64(p, "chainId", tx.ChainId)
+}
+
+func requireSignature(p *fieldParser, tx *Transaction) {
+	requireBytes(p, "r", tx.R)
+	requireBytes(p, "s", tx.S)
+	if tx.V == nil && tx.YParity == nil {
+		p.fail("v", errors.New("signed transactions require v or yParity"))
+	}
+}
+
+// requireArbitrum returns a parser for the arbitrum extension, or nil after recording its absence.
+func requireArbitrum(p *fieldParser, tx *Transaction) *fieldParser {
+	if tx.Arbitrum == nil {
+		p.fail("arbitrum", fmt.Errorf("%s transactions require the arbitrum extension", tx.TransactionType()))
+		return nil
+	}
+	return p.child("arbitrum")
+}
+
+// requireQuantity accepts either the string quantity or its U256 field, which is all that
+// is left after ClearStringQuantityFields.
+func requireQuantity(p *fieldParser, name string, v *string, wide []byte) {
+	if (v == nil || *v == "") && len(wide) == 0 {
+		p.fail(name, errors.New("required field is missing"))
+	}
+}
+
+func requireUint64(p *fieldParser, name string, v *uint64) {
+	if v == nil {
+		p.fail(name, errors.New("required field is missing"))
+	}
+}
+
+func requireBytes(p *fieldParser, name string, v []byte) {
+	if len(v) == 0 {
+		p.fail(name, errors.New("required field is missing"))
+	}
+}
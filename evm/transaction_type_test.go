@@ -0,0 +1,179 @@
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+const depositTestTransactionJson = `{
+  "blockHash": "0x5a1e0c1e4e2f6b0c1c0f3b6a7f4b1d5e9d7c3a2b1e0f9e8d7c6b5a4f3e2d1c0b",
+  "blockNumber": "0x7a1200",
+  "from": "0x977f82a600a1414e583f7f13623f1ac5d58b1c0b",
+  "gas": "0x30d40",
+  "gasPrice": "0x0",
+  "hash": "0x8d2f4d3f9e1b7d1b3a6c1e2f4a5b6c7d8e9f0a1b2c3d4e5f60718293a4b5c6d7",
+  "input": "0x",
+  "mint": "0xde0b6b3a7640000",
+  "nonce": "0x1f",
+  "r": "0x0",
+  "s": "0x0",
+  "sourceHash": "0x7b4a0ebf7d8a84c86c7a3b9f4e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d5",
+  "to": "0x977f82a600a1414e583f7f13623f1ac5d58b1c0b",
+  "transactionIndex": "0x1",
+  "type": "0x7e",
+  "v": "0x0",
+  "value": "0xde0b6b3a7640000",
+  "isSystemTx": false,
+  "depositReceiptVersion": "0x1"
+}`
+
+func TestTransactionTypeAccessors(t *testing.T) {
+	tx := &Transaction{Type: 0x7e}
+	if tx.TransactionType() != TransactionType_OPTIMISM_DEPOSIT {
+		t.Errorf("TransactionType() = %v", tx.TransactionType())
+	}
+	for _, typ := range []TransactionType{TransactionType_OPTIMISM_DEPOSIT, TransactionType_ARBITRUM_RETRY} {
+		if !typ.IsKnown() || !typ.IsDeposit() {
+			t.Errorf("Expected %v to be a known deposit type", typ)
+		}
+	}
+	if TransactionType_ARBITRUM_INTERNAL.IsDeposit() || TransactionType_DYNAMIC_FEE.IsDeposit() {
+		t.Error("Internal and signed transactions are not deposits")
+	}
+	if TransactionType_ARBITRUM_SUBMIT_RETRYABLE.Ecosystem() != EcosystemArbitrum || TransactionType_BLOB.Ecosystem() != EcosystemAuto {
+		t.Error("Unexpected type ecosystems")
+	}
+	if TransactionType(0x71).IsKnown() {
+		t.Error("Expected zkSync EIP-712 transactions not to be listed")
+	}
+	if (&Receipt{Type: 0x68}).TransactionType() != TransactionType_ARBITRUM_RETRY {
+		t.Error("Unexpected receipt transaction type")
+	}
+}
+
+func TestOptimismDepositFields(t *testing.T) {
+	var jt JsonRpcTransaction
+	if err := json.Unmarshal([]byte(depositTestTransactionJson), &jt); err != nil {
+		t.Fatalf("Failed to unmarshal deposit: %v", err)
+	}
+	tx, err := jt.ToProto(WithDialect(DialectOpGeth()), WithStrict(true))
+	if err != nil {
+		t.Fatalf("Failed to convert deposit: %v", err)
+	}
+	if BytesToHex(tx.Optimism.GetSourceHash()) != jt.SourceHash || tx.Optimism.GetMint() != jt.Mint {
+		t.Errorf("Unexpected deposit fields: %v", tx.Optimism)
+	}
+	if err := ValidateTransaction(tx); err != nil {
+		t.Errorf("ValidateTransaction() error = %v", err)
+	}
+
+	out := TransactionToJsonRpc(tx, WithDialect(DialectOpGeth()))
+	if out["sourceHash"] != jt.SourceHash || out["mint"] != jt.Mint {
+		t.Errorf("Deposit fields not emitted: sourceHash=%v mint=%v", out["sourceHash"], out["mint"])
+	}
+	assertSameAsMap(t, out, AppendTransactionJsonRpc(nil, tx, WithDialect(DialectOpGeth())))
+}
+
+func TestValidateTransaction(t *testing.T) {
+	signed := func(typ TransactionType) *Transaction {
+		return &Transaction{
+			Type:                 uint32(typ),
+			To:                   MustHexToBytes("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+			ChainId:              Uint64Ptr(1),
+			GasPrice:             StringPtr("1000000000"),
+			MaxFeePerGas:         StringPtr("2000000000"),
+			MaxPriorityFeePerGas: StringPtr("1000000000"),
+			R:                    []byte{1},
+			S:                    []byte{2},
+			YParity:              Uint32Ptr(1),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		tx    *Transaction
+		paths []string
+	}{
+		{name: "Legacy", tx: signed(TransactionType_LEGACY)},
+		{name: "DynamicFee", tx: signed(TransactionType_DYNAMIC_FEE)},
+		{
+			name: "DynamicFeeMissingFees",
+			tx: func() *Transaction {
+				tx := signed(TransactionType_DYNAMIC_FEE)
+				tx.MaxFeePerGas, tx.MaxPriorityFeePerGas, tx.YParity = nil, nil, nil
+				return tx
+			}(),
+			paths: []string{"maxFeePerGas", "maxPriorityFeePerGas", "v"},
+		},
+		{
+			name: "DynamicFeeU256Only",
+			tx: func() *Transaction {
+				tx := signed(TransactionType_DYNAMIC_FEE)
+				PopulateU256Fields(tx)
+				ClearStringQuantityFields(tx)
+				return tx
+			}(),
+		},
+		{
+			name: "SubmitRetryableU256Only",
+			tx: func() *Transaction {
+				tx := &Transaction{Type: 0x69, Arbitrum: &ArbitrumTransactionExtension{
+					RequestId: []byte{1}, L1BaseFee: StringPtr("1"), DepositValue: StringPtr("1"), MaxSubmissionFee: StringPtr("1"),
+					Beneficiary: []byte{2}, RefundTo: []byte{3},
+				}}
+				PopulateU256Fields(tx)
+				ClearStringQuantityFields(tx)
+				return tx
+			}(),
+		},
+		{name: "BlobWithoutBlobs", tx: signed(TransactionType_BLOB), paths: []string{"maxFeePerBlobGas", "blobVersionedHashes"}},
+		{name: "DepositWithoutExtension", tx: &Transaction{Type: 0x7e}, paths: []string{"optimism"}},
+		{
+			name:  "DepositWithShortSourceHash",
+			tx:    &Transaction{Type: 0x7e, Optimism: &OptimismTransactionExtension{SourceHash: []byte{1}, Mint: StringPtr("lots")}},
+			paths: []string{"optimism.sourceHash", "optimism.mint"},
+		},
+		{
+			name: "RetryWithoutTicket",
+			tx: &Transaction{Type: 0x68, Arbitrum: &ArbitrumTransactionExtension{
+				RefundTo: []byte{1}, MaxRefund: StringPtr("1"), SubmissionFeeRefund: StringPtr("0"),
+			}},
+			paths: []string{"arbitrum.ticketId"},
+		},
+		{
+			name: "SubmitRetryable",
+			tx: &Transaction{Type: 0x69, Arbitrum: &ArbitrumTransactionExtension{
+				RequestId: []byte{1}, L1BaseFee: StringPtr("1"), DepositValue: StringPtr("1"), MaxSubmissionFee: StringPtr("1"),
+				Beneficiary: []byte{2}, RefundTo: []byte{3},
+			}},
+		},
+		{name: "ArbitrumInternalWithoutInput", tx: &Transaction{Type: 0x6a}, paths: []string{"input"}},
+		{name: "UnknownType", tx: &Transaction{Type: 0x71}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransaction(tt.tx)
+			if len(tt.paths) == 0 {
+				if err != nil {
+					t.Errorf("ValidateTransaction() error = %v", err)
+				}
+				return
+			}
+			var baseErr *common.BaseError
+			if !errors.As(err, &baseErr) || baseErr.Code != common.ErrorCode_INVALID_PARAMETER {
+				t.Fatalf("Expected INVALID_PARAMETER, got %v", err)
+			}
+			paths, ok := baseErr.Details[ConvertErrorPathsDetail].([]string)
+			if !ok {
+				paths = []string{baseErr.Details[ConvertErrorPathDetail].(string)}
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("Unexpected paths\nwant: %v\ngot:  %v", tt.paths, paths)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package evm
+
+// Trie is an in-memory Merkle-Patricia trie builder for computing the roots committed to in
+// block headers. Keys are used as given: the transactions, receipts and withdrawals tries are
+// keyed by the RLP of the item index, while state and storage tries (secure tries) are keyed by
+// the Keccak-256 of the address or slot, which callers hash themselves. The zero value is not
+// usable; create tries with NewTrie.
+type Trie struct {
+	entries map[string][]byte // nibble path -> value
+}
+
+// NewTrie returns an empty trie.
+func NewTrie() *Trie {
+	return &Trie{entries: map[string][]byte{}}
+}
+
+// Put sets the value of key. An empty value removes the key, as in Ethereum tries.
+func (t *Trie) Put(key, value []byte) {
+	path := string(keyNibbles(key))
+	if len(value) == 0 {
+		delete(t.entries, path)
+		return
+	}
+	t.entries[path] = value
+}
+
+// Root returns the root hash of the trie, EmptyTrieRoot when it has no keys.
+func (t *Trie) Root() Hash {
+	return t.commit(nil)
+}
+
+// commit returns the root hash and passes every node referenced by hash, the root included,
+// to onNode when it is not nil.
+func (t *Trie) commit(onNode func(node []byte)) Hash {
+	if len(t.entries) == 0 {
+		return Hash(EmptyTrieRoot)
+	}
+	paths := make([]string, 0, len(t.entries))
+	for p := range t.entries {
+		paths = append(paths, p)
+	}
+	b := trieBuilder{entries: t.entries, onNode: onNode}
+	root := b.build(paths, 0)
+	if onNode != nil {
+		onNode(root)
+	}
+	return Hash(Keccak256(root))
+}
+
+type trieBuilder struct {
+	entries map[string][]byte
+	onNode  func(node []byte)
+}
+
+// build returns the node holding paths, which share their first depth nibbles.
+func (b *trieBuilder) build(paths []string, depth int) []byte {
+	if len(paths) == 1 {
+		var payload []byte
+		payload = rlpAppendBytes(payload, compactNibbles([]byte(paths[0][depth:]), true))
+		payload = rlpAppendBytes(payload, b.entries[paths[0]])
+		return rlpAppendList(nil, payload)
+	}
+
+	// Extension node over the nibbles every path shares
+	prefix := len(paths[0])
+	for _, p := range paths[1:] {
+		n := depth
+		for n < prefix && n < len(p) && p[n] == paths[0][n] {
+			n++
+		}
+		prefix = n
+	}
+	if prefix > depth {
+		var payload []byte
+		payload = rlpAppendBytes(payload, compactNibbles([]byte(paths[0][depth:prefix]), false))
+		payload = append(payload, b.ref(b.build(paths, prefix))...)
+		return rlpAppendList(nil, payload)
+	}
+
+	// Branch node; a path ending here stores its value in the 17th slot
+	var groups [16][]string
+	var value []byte
+	for _, p := range paths {
+		if len(p) == depth {
+			value = b.entries[p]
+			continue
+		}
+		groups[p[depth]] = append(groups[p[depth]], p)
+	}
+	var payload []byte
+	for _, g := range groups {
+		if len(g) == 0 {
+			payload = append(payload, 0x80)
+			continue
+		}
+		payload = append(payload, b.ref(b.build(g, depth+1))...)
+	}
+	payload = rlpAppendBytes(payload, value)
+	return rlpAppendList(nil, payload)
+}
+
+// ref returns how a parent refers to node: embedded when shorter than 32 bytes, by hash otherwise.
+func (b *trieBuilder) ref(node []byte) []byte {
+	if len(node) < 32 {
+		return node
+	}
+	if b.onNode != nil {
+		b.onNode(node)
+	}
+	return rlpAppendBytes(nil, Keccak256(node))
+}
+
+// compactNibbles hex-prefix encodes a nibble path, the inverse of compactToNibbles.
+func compactNibbles(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		nibbles = append([]byte{flag + 1}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+	out := make([]byte, len(nibbles)/2)
+	for i := range out {
+		out[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return out
+}
+
+// OrderedTrieRoot returns the root of the trie mapping the RLP of each index to its item, as
+// used for the transactions, receipts and withdrawals of a block.
+func OrderedTrieRoot(items [][]byte) Hash {
+	t := NewTrie()
+	for i, item := range items {
+		t.Put(rlpAppendUint(nil, uint64(i)), item)
+	}
+	return t.Root()
+}
@@ -0,0 +1,77 @@
+package evm
+
+import "testing"
+
+func TestTrieRoot(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries [][2]string
+		want    string
+	}{
+		{"Empty", nil, BytesToHex(EmptyTrieRoot)},
+		{"Dogs", [][2]string{
+			{"doe", "reindeer"},
+			{"dog", "puppy"},
+			{"dogglesworth", "cat"},
+		}, "0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3"},
+		{"BranchValue", [][2]string{
+			{"do", "verb"},
+			{"horse", "stallion"},
+			{"doge", "coin"},
+			{"dog", "puppy"},
+		}, "0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84"},
+		{"Deleted", [][2]string{
+			{"do", "verb"},
+			{"ether", "wookiedoo"},
+			{"horse", "stallion"},
+			{"shaman", "horse"},
+			{"doge", "coin"},
+			{"ether", ""},
+			{"dog", "puppy"},
+			{"shaman", ""},
+		}, "0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTrie()
+			for _, e := range tt.entries {
+				tr.Put([]byte(e[0]), []byte(e[1]))
+			}
+			if got := tr.Root().Hex(); got != tt.want {
+				t.Errorf("Expected root %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderedTrieRoot(t *testing.T) {
+	if got := OrderedTrieRoot(nil).Hex(); got != BytesToHex(EmptyTrieRoot) {
+		t.Errorf("Expected the empty root, got %s", got)
+	}
+
+	// Indexes 0, 1..127 and 128+ have keys of different lengths (0x80, 0x01, 0x8180)
+	items := make([][]byte, 300)
+	tr := NewTrie()
+	for i := range items {
+		items[i] = []byte{byte(i), byte(i >> 8), 0xff}
+		tr.Put(rlpAppendUint(nil, uint64(i)), items[i])
+	}
+	if got, want := OrderedTrieRoot(items), tr.Root(); got != want {
+		t.Errorf("Expected %s, got %s", want.Hex(), got.Hex())
+	}
+	if OrderedTrieRoot(items[:299]) == tr.Root() {
+		t.Errorf("Expected the root to change when an item is dropped")
+	}
+}
+
+func TestTrieMatchesTestTrie(t *testing.T) {
+	tr, ref := NewTrie(), newTestTrie()
+	for i := 0; i < 200; i++ {
+		key, value := rlpAppendUint(nil, uint64(i)), []byte{byte(i), 0x01}
+		tr.Put(Keccak256(key), value)
+		ref.put(key, value)
+	}
+	if got, want := tr.Root().Hex(), BytesToHex(ref.root()); got != want {
+		t.Errorf("Expected the test trie root %s, got %s", want, got)
+	}
+}
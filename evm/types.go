@@ -1,27 +1,56 @@
 package evm
 
-// Common type aliases for better readability
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fixed-size value types for addresses, hashes and topics. They are comparable, usable as map
+// keys, and encode as 0x-prefixed lowercase hex in JSON and text. Conversions from the proto
+// bytes fields reject inputs of the wrong length.
 type (
 	// Address represents a 20-byte Ethereum address
-	Address []byte
+	Address [AddressLength]byte
 
 	// Hash represents a 32-byte hash (block hash, transaction hash, etc.)
-	Hash []byte
+	Hash [HashLength]byte
 
 	// Topic represents a 32-byte log topic
-	Topic []byte
+	Topic [TopicLength]byte
 
 	// Topics represents an array of log topics
 	Topics []Topic
 )
 
-// Common event signatures
+// Common event signatures (topic0). Others can be computed with EventTopic or looked up in
+// DefaultSignatureRegistry.
 const (
 	// Transfer event signature: Transfer(address,address,uint256)
 	TransferEventSignature = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
 
 	// Approval event signature: Approval(address,address,uint256)
 	ApprovalEventSignature = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+
+	// ApprovalForAll event signature: ApprovalForAll(address,address,bool)
+	ApprovalForAllEventSignature = "0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31"
+
+	// TransferSingle event signature: TransferSingle(address,address,address,uint256,uint256)
+	TransferSingleEventSignature = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+
+	// TransferBatch event signature: TransferBatch(address,address,address,uint256[],uint256[])
+	TransferBatchEventSignature = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+
+	// WETH Deposit event signature: Deposit(address,uint256)
+	DepositEventSignature = "0xe1fffcc4923d04b559f4d29a8bfc6cda04eb5b0d3c460751c2402c5c5cc9109c"
+
+	// WETH Withdrawal event signature: Withdrawal(address,uint256)
+	WithdrawalEventSignature = "0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65"
+
+	// ERC-4337 UserOperationEvent signature:
+	// UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)
+	UserOperationEventSignature = "0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f"
 )
 
 // Well-known addresses
@@ -48,35 +77,150 @@ const (
 	MaxTopics = 4
 )
 
-// NewAddress creates an Address from a byte slice, ensuring it's the correct length
-func NewAddress(b []byte) Address {
-	if len(b) == AddressLength {
-		return Address(b)
+// NewAddress converts a proto bytes field into an Address. It fails unless b is exactly 20
+// bytes long.
+func NewAddress(b []byte) (Address, error) {
+	var a Address
+	return a, fixedFromBytes(a[:], b, "address")
+}
+
+// NewHash converts a proto bytes field into a Hash. It fails unless b is exactly 32 bytes long.
+func NewHash(b []byte) (Hash, error) {
+	var h Hash
+	return h, fixedFromBytes(h[:], b, "hash")
+}
+
+// NewTopic converts a proto bytes field into a Topic. It fails unless b is exactly 32 bytes long.
+func NewTopic(b []byte) (Topic, error) {
+	var t Topic
+	return t, fixedFromBytes(t[:], b, "topic")
+}
+
+// NewTopics converts the topics of a proto Log into Topics.
+func NewTopics(b [][]byte) (Topics, error) {
+	topics := make(Topics, len(b))
+	for i, t := range b {
+		if err := fixedFromBytes(topics[i][:], t, "topic"); err != nil {
+			return nil, fmt.Errorf("topics[%d]: %w", i, err)
+		}
 	}
-	// Pad or truncate to correct length
-	addr := make([]byte, AddressLength)
-	copy(addr[AddressLength-len(b):], b)
-	return Address(addr)
+	return topics, nil
 }
 
-// NewHash creates a Hash from a byte slice, ensuring it's the correct length
-func NewHash(b []byte) Hash {
-	if len(b) == HashLength {
-		return Hash(b)
+// Bytes returns a copy of the address for a proto bytes field.
+func (a Address) Bytes() []byte { return append([]byte(nil), a[:]...) }
+
+// Hex returns the 0x-prefixed lowercase hex encoding of the address.
+func (a Address) Hex() string { return BytesToHex(a[:]) }
+
+func (a Address) String() string { return a.Hex() }
+
+// IsZero reports whether a is the zero address.
+func (a Address) IsZero() bool { return a == Address{} }
+
+// Cmp compares addresses as big-endian numbers, returning -1, 0 or 1.
+func (a Address) Cmp(other Address) int { return bytes.Compare(a[:], other[:]) }
+
+func (a Address) MarshalText() ([]byte, error) { return []byte(a.Hex()), nil }
+
+func (a *Address) UnmarshalText(text []byte) error { return fixedFromText(a[:], text, "address") }
+
+// Scan implements sql.Scanner for columns holding the raw 20 bytes or 0x-prefixed hex text.
+func (a *Address) Scan(src interface{}) error { return fixedScan(a[:], src, "address") }
+
+// Value implements driver.Valuer, storing the raw 20 bytes.
+func (a Address) Value() (driver.Value, error) { return a.Bytes(), nil }
+
+// Bytes returns a copy of the hash for a proto bytes field.
+func (h Hash) Bytes() []byte { return append([]byte(nil), h[:]...) }
+
+// Hex returns the 0x-prefixed lowercase hex encoding of the hash.
+func (h Hash) Hex() string { return BytesToHex(h[:]) }
+
+func (h Hash) String() string { return h.Hex() }
+
+// IsZero reports whether every byte of h is zero.
+func (h Hash) IsZero() bool { return h == Hash{} }
+
+// Cmp compares hashes as big-endian numbers, returning -1, 0 or 1.
+func (h Hash) Cmp(other Hash) int { return bytes.Compare(h[:], other[:]) }
+
+func (h Hash) MarshalText() ([]byte, error) { return []byte(h.Hex()), nil }
+
+func (h *Hash) UnmarshalText(text []byte) error { return fixedFromText(h[:], text, "hash") }
+
+// Scan implements sql.Scanner for columns holding the raw 32 bytes or 0x-prefixed hex text.
+func (h *Hash) Scan(src interface{}) error { return fixedScan(h[:], src, "hash") }
+
+// Value implements driver.Valuer, storing the raw 32 bytes.
+func (h Hash) Value() (driver.Value, error) { return h.Bytes(), nil }
+
+// Bytes returns a copy of the topic for a proto bytes field.
+func (t Topic) Bytes() []byte { return append([]byte(nil), t[:]...) }
+
+// Hex returns the 0x-prefixed lowercase hex encoding of the topic.
+func (t Topic) Hex() string { return BytesToHex(t[:]) }
+
+func (t Topic) String() string { return t.Hex() }
+
+// IsZero reports whether every byte of t is zero.
+func (t Topic) IsZero() bool { return t == Topic{} }
+
+// Cmp compares topics as big-endian numbers, returning -1, 0 or 1.
+func (t Topic) Cmp(other Topic) int { return bytes.Compare(t[:], other[:]) }
+
+func (t Topic) MarshalText() ([]byte, error) { return []byte(t.Hex()), nil }
+
+func (t *Topic) UnmarshalText(text []byte) error { return fixedFromText(t[:], text, "topic") }
+
+// Scan implements sql.Scanner for columns holding the raw 32 bytes or 0x-prefixed hex text.
+func (t *Topic) Scan(src interface{}) error { return fixedScan(t[:], src, "topic") }
+
+// Value implements driver.Valuer, storing the raw 32 bytes.
+func (t Topic) Value() (driver.Value, error) { return t.Bytes(), nil }
+
+// Bytes returns the topics for the topics field of a proto Log.
+func (ts Topics) Bytes() [][]byte {
+	out := make([][]byte, len(ts))
+	for i, t := range ts {
+		out[i] = t.Bytes()
+	}
+	return out
+}
+
+func fixedFromBytes(dst, b []byte, kind string) error {
+	if len(b) != len(dst) {
+		return fmt.Errorf("%s must be %d bytes, got %d", kind, len(dst), len(b))
+	}
+	copy(dst, b)
+	return nil
+}
+
+// fixedFromText decodes 0x-prefixed hex of exactly len(dst) bytes into dst.
+func fixedFromText(dst, text []byte, kind string) error {
+	if len(text) < 2 || text[0] != '0' || (text[1] != 'x' && text[1] != 'X') {
+		return fmt.Errorf("%s %q is missing the 0x prefix", kind, text)
+	}
+	text = text[2:]
+	if len(text) != 2*len(dst) {
+		return fmt.Errorf("%s must be %d bytes, got %d hex digits", kind, len(dst), len(text))
+	}
+	if _, err := hex.Decode(dst, text); err != nil {
+		return fmt.Errorf("invalid %s: %w", kind, err)
 	}
-	// Pad or truncate to correct length
-	hash := make([]byte, HashLength)
-	copy(hash[HashLength-len(b):], b)
-	return Hash(hash)
+	return nil
 }
 
-// NewTopic creates a Topic from a byte slice, ensuring it's the correct length
-func NewTopic(b []byte) Topic {
-	if len(b) == TopicLength {
-		return Topic(b)
+func fixedScan(dst []byte, src interface{}, kind string) error {
+	switch v := src.(type) {
+	case []byte:
+		if len(v) == len(dst) {
+			copy(dst, v)
+			return nil
+		}
+		return fixedFromText(dst, v, kind)
+	case string:
+		return fixedFromText(dst, []byte(v), kind)
 	}
-	// Pad or truncate to correct length
-	topic := make([]byte, TopicLength)
-	copy(topic[TopicLength-len(b):], b)
-	return Topic(topic)
+	return fmt.Errorf("cannot scan %T into %s", src, kind)
 }
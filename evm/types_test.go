@@ -0,0 +1,87 @@
+package evm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*Address)(nil)
+	_ driver.Valuer = Hash{}
+)
+
+func TestFixedSizeTypes(t *testing.T) {
+	usdc := MustHexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+	t.Run("WrongLengthIsAnError", func(t *testing.T) {
+		if _, err := NewAddress(make([]byte, 21)); err == nil {
+			t.Error("Expected an error for a 21-byte address")
+		}
+		if _, err := NewHash(make([]byte, 31)); err == nil {
+			t.Error("Expected an error for a 31-byte hash")
+		}
+		if _, err := HexToTopic("0x01"); err == nil {
+			t.Error("Expected an error for a short topic")
+		}
+		if _, err := NewTopics([][]byte{make([]byte, 32), {1}}); err == nil {
+			t.Error("Expected an error for a short topic in topics")
+		}
+		a, err := NewAddress(usdc.Bytes())
+		if err != nil || a != usdc {
+			t.Errorf("NewAddress() = %v, %v", a, err)
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		type doc struct {
+			Address Address         `json:"address"`
+			Hashes  map[Hash]Topics `json:"hashes"`
+		}
+		in := doc{Address: usdc, Hashes: map[Hash]Topics{{1}: {{2}, {3}}}}
+		b, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		var out doc
+		if err := json.Unmarshal(b, &out); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", b, err)
+		}
+		if out.Address != usdc || len(out.Hashes[Hash{1}]) != 2 || out.Hashes[Hash{1}][1] != (Topic{3}) {
+			t.Errorf("Round trip mismatch: %s -> %+v", b, out)
+		}
+		for _, s := range []string{`"a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"`, `"0xa0b8"`, `"0xz0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"`} {
+			if err := json.Unmarshal([]byte(s), &out.Address); err == nil {
+				t.Errorf("Expected %s to be rejected", s)
+			}
+		}
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		v, _ := usdc.Value()
+		var a Address
+		if err := a.Scan(v); err != nil || a != usdc {
+			t.Errorf("Scan(raw) = %v, %v", a, err)
+		}
+		var h Hash
+		if err := h.Scan("0x" + "11" + "00000000000000000000000000000000000000000000000000000000000000"); err != nil || h != (Hash{0x11}) {
+			t.Errorf("Scan(hex) = %v, %v", h, err)
+		}
+		if err := h.Scan(int64(1)); err == nil {
+			t.Error("Expected an error scanning an integer")
+		}
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		if !(Address{}).IsZero() || usdc.IsZero() {
+			t.Error("Unexpected IsZero")
+		}
+		if (Address{}).Cmp(usdc) != -1 || usdc.Cmp(usdc) != 0 || (Topic{2}).Cmp(Topic{1}) != 1 {
+			t.Error("Unexpected Cmp")
+		}
+		if usdc.String() != "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" {
+			t.Errorf("String() = %s", usdc)
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// U256 is an unsigned 256-bit integer, the type of wei amounts, fees and other EVM quantities.
+// It is a value type of four 64-bit limbs, least significant first, so it is comparable and
+// its zero value is 0. Add, Sub and Mul wrap modulo 2^256 like the EVM; their Overflow
+// variants report the wrap. In proto messages a U256 is stored as 32 big-endian bytes.
+type U256 [4]uint64
+
+// MaxU256 is 2^256 - 1.
+var MaxU256 = U256{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+
+var (
+	errU256Overflow = errors.New("value exceeds 256 bits")
+	errU256Empty    = errors.New("empty quantity")
+)
+
+// NewU256 returns v as a U256.
+func NewU256(v uint64) U256 {
+	return U256{v}
+}
+
+// ParseU256 parses a 0x-prefixed hex quantity or a decimal string. Leading zeros are allowed;
+// signs, spaces and values of 2^256 or more are not. A bare "0x" is 0, as some clients encode
+// zero that way.
+func ParseU256(s string) (U256, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return parseU256Hex(s[2:])
+	}
+	if s == "" {
+		return U256{}, errU256Empty
+	}
+	var z U256
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return U256{}, fmt.Errorf("invalid decimal quantity %q", s)
+		}
+		var overflow bool
+		if z, overflow = z.mulAddUint64(10, uint64(c-'0')); overflow {
+			return U256{}, errU256Overflow
+		}
+	}
+	return z, nil
+}
+
+func parseU256Hex(s string) (U256, error) {
+	digits := strings.TrimLeft(s, "0")
+	if len(digits) > 64 {
+		return U256{}, errU256Overflow
+	}
+	var z U256
+	for i := 0; i < len(digits); i++ {
+		c := digits[len(digits)-1-i]
+		var nibble byte
+		switch {
+		case c >= '0' && c <= '9':
+			nibble = c - '0'
+		case c >= 'a' && c <= 'f':
+			nibble = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			nibble = c - 'A' + 10
+		default:
+			return U256{}, fmt.Errorf("invalid hex quantity %q", "0x"+s)
+		}
+		z[i/16] |= uint64(nibble) << (4 * (i % 16))
+	}
+	return z, nil
+}
+
+// MustParseU256 is like ParseU256 but panics on invalid input.
+func MustParseU256(s string) U256 {
+	z, err := ParseU256(s)
+	if err != nil {
+		panic(fmt.Sprintf("invalid quantity %q: %v", s, err))
+	}
+	return z
+}
+
+// U256FromBig converts b, failing when it is negative or does not fit in 256 bits.
+func U256FromBig(b *big.Int) (U256, error) {
+	if b.Sign() < 0 {
+		return U256{}, errors.New("negative value")
+	}
+	if b.BitLen() > 256 {
+		return U256{}, errU256Overflow
+	}
+	var buf [32]byte
+	return U256FromBytes(b.FillBytes(buf[:]))
+}
+
+// U256FromBytes decodes a big-endian integer of at most 32 bytes, such as a U256 proto field.
+func U256FromBytes(b []byte) (U256, error) {
+	if len(b) > 32 {
+		return U256{}, fmt.Errorf("quantity must be at most 32 bytes, got %d", len(b))
+	}
+	var z U256
+	for i := 0; i < len(b); i++ {
+		z[i/8] |= uint64(b[len(b)-1-i]) << (8 * (i % 8))
+	}
+	return z, nil
+}
+
+// Bytes32 returns z as 32 big-endian bytes.
+func (z U256) Bytes32() [32]byte {
+	var b [32]byte
+	for i := 0; i < 32; i++ {
+		b[31-i] = byte(z[i/8] >> (8 * (i % 8)))
+	}
+	return b
+}
+
+// Bytes returns z as 32 big-endian bytes for a U256 proto field.
+func (z U256) Bytes() []byte {
+	b := z.Bytes32()
+	return b[:]
+}
+
+// Big returns z as a new big.Int.
+func (z U256) Big() *big.Int {
+	b := z.Bytes32()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// Uint64 returns z and true when it fits in a uint64.
+func (z U256) Uint64() (uint64, bool) {
+	return z[0], z[1]|z[2]|z[3] == 0
+}
+
+// IsZero reports whether z is 0.
+func (z U256) IsZero() bool {
+	return z == U256{}
+}
+
+// BitLen returns the number of bits needed to represent z; 0 for 0.
+func (z U256) BitLen() int {
+	for i := 3; i >= 0; i-- {
+		if z[i] != 0 {
+			return 64*i + bits.Len64(z[i])
+		}
+	}
+	return 0
+}
+
+// Cmp returns -1, 0 or 1 when z is less than, equal to or greater than x.
+func (z U256) Cmp(x U256) int {
+	for i := 3; i >= 0; i-- {
+		switch {
+		case z[i] < x[i]:
+			return -1
+		case z[i] > x[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Add returns z + x modulo 2^256.
+func (z U256) Add(x U256) U256 {
+	sum, _ := z.AddOverflow(x)
+	return sum
+}
+
+// AddOverflow returns z + x modulo 2^256 and whether the sum wrapped.
+func (z U256) AddOverflow(x U256) (U256, bool) {
+	var sum U256
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		sum[i], carry = bits.Add64(z[i], x[i], carry)
+	}
+	return sum, carry != 0
+}
+
+// Sub returns z - x modulo 2^256.
+func (z U256) Sub(x U256) U256 {
+	diff, _ := z.SubOverflow(x)
+	return diff
+}
+
+// SubOverflow returns z - x modulo 2^256 and whether the difference wrapped, i.e. x > z.
+func (z U256) SubOverflow(x U256) (U256, bool) {
+	var diff U256
+	var borrow uint64
+	for i := 0; i < 4; i++ {
+		diff[i], borrow = bits.Sub64(z[i], x[i], borrow)
+	}
+	return diff, borrow != 0
+}
+
+// Mul returns z * x modulo 2^256.
+func (z U256) Mul(x U256) U256 {
+	product, _ := z.MulOverflow(x)
+	return product
+}
+
+// MulOverflow returns z * x modulo 2^256 and whether the product wrapped.
+func (z U256) MulOverflow(x U256) (U256, bool) {
+	var product [8]uint64
+	for i := 0; i < 4; i++ {
+		if z[i] == 0 {
+			continue
+		}
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(z[i], x[j])
+			var c uint64
+			lo, c = bits.Add64(lo, product[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			product[i+j] = lo
+			carry = hi
+		}
+		product[i+4] = carry
+	}
+	return U256{product[0], product[1], product[2], product[3]}, product[4]|product[5]|product[6]|product[7] != 0
+}
+
+// Div returns z / x, or 0 when x is 0 as in the EVM's DIV.
+func (z U256) Div(x U256) U256 {
+	if x.IsZero() {
+		return U256{}
+	}
+	if d, ok := x.Uint64(); ok {
+		q, _ := z.divUint64(d)
+		return q
+	}
+	q, _ := U256FromBig(new(big.Int).Quo(z.Big(), x.Big()))
+	return q
+}
+
+// Mod returns z % x, or 0 when x is 0 as in the EVM's MOD.
+func (z U256) Mod(x U256) U256 {
+	if x.IsZero() {
+		return U256{}
+	}
+	if d, ok := x.Uint64(); ok {
+		_, r := z.divUint64(d)
+		return NewU256(r)
+	}
+	r, _ := U256FromBig(new(big.Int).Rem(z.Big(), x.Big()))
+	return r
+}
+
+// mulAddUint64 returns z*m + a and whether the result wrapped.
+func (z U256) mulAddUint64(m, a uint64) (U256, bool) {
+	carry := a
+	for i := 0; i < 4; i++ {
+		hi, lo := bits.Mul64(z[i], m)
+		var c uint64
+		z[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+	return z, carry != 0
+}
+
+func (z U256) divUint64(d uint64) (U256, uint64) {
+	var q U256
+	var r uint64
+	for i := 3; i >= 0; i-- {
+		q[i], r = bits.Div64(r, z[i], d)
+	}
+	return q, r
+}
+
+// Hex returns z as a JSON-RPC quantity: 0x-prefixed lowercase hex without leading zeros.
+func (z U256) Hex() string {
+	top := 3
+	for top > 0 && z[top] == 0 {
+		top--
+	}
+	b := make([]byte, 0, 2+16*(top+1))
+	b = append(b, "0x"...)
+	b = strconv.AppendUint(b, z[top], 16)
+	for i := top - 1; i >= 0; i-- {
+		b = appendPadded(b, strconv.FormatUint(z[i], 16), 16)
+	}
+	return string(b)
+}
+
+// String returns z in decimal.
+func (z U256) String() string {
+	// Peel off 19 decimal digits at a time, the most that fit in a uint64
+	const chunk = 10_000_000_000_000_000_000
+	var parts []uint64
+	for {
+		var r uint64
+		z, r = z.divUint64(chunk)
+		parts = append(parts, r)
+		if z.IsZero() {
+			break
+		}
+	}
+	b := strconv.AppendUint(nil, parts[len(parts)-1], 10)
+	for i := len(parts) - 2; i >= 0; i-- {
+		b = appendPadded(b, strconv.FormatUint(parts[i], 10), 19)
+	}
+	return string(b)
+}
+
+// FormatUnits returns z divided by 10^decimals in decimal notation without trailing zeros,
+// e.g. 1500000000000000000 with 18 decimals (wei to ether) is "1.5".
+func (z U256) FormatUnits(decimals int) string {
+	s := z.String()
+	if decimals <= 0 {
+		return s
+	}
+	if len(s) <= decimals {
+		s = strings.Repeat("0", decimals-len(s)+1) + s
+	}
+	whole, frac := s[:len(s)-decimals], strings.TrimRight(s[len(s)-decimals:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+func (z U256) MarshalText() ([]byte, error) {
+	return []byte(z.Hex()), nil
+}
+
+// UnmarshalText accepts the forms accepted by ParseU256.
+func (z *U256) UnmarshalText(text []byte) error {
+	v, err := ParseU256(string(text))
+	if err != nil {
+		return err
+	}
+	*z = v
+	return nil
+}
+
+func appendPadded(b []byte, digits string, width int) []byte {
+	for i := len(digits); i < width; i++ {
+		b = append(b, '0')
+	}
+	return append(b, digits...)
+}
@@ -0,0 +1,254 @@
+package evm
+
+import (
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Wei amounts and other 256-bit quantities are moving from string fields, which hold whatever
+// the node sent (hex or decimal), to 32-byte big-endian bytes fields named after them with a
+// U256 suffix, e.g. Transaction.value and Transaction.valueU256. The migration runs in steps:
+//
+//  1. Converters set both fields. Stored messages are upgraded with PopulateU256Fields.
+//  2. Consumers read the U256 fields, decoding them with U256FromBytes.
+//  3. Writers drop the strings with ClearStringQuantityFields; encoders still emit them.
+//  4. The string fields are removed from the schema and their numbers reserved.
+//
+// FormatU256Fields undoes step 3 for consumers that still read the strings.
+
+// u256Field pairs a string quantity field with its U256 counterpart.
+type u256Field struct {
+	str, wide protoreflect.FieldDescriptor
+}
+
+var u256FieldCache sync.Map // protoreflect.FullName -> []u256Field
+
+// u256Fields finds the U256 fields of md by their suffix.
+func u256Fields(md protoreflect.MessageDescriptor) []u256Field {
+	if cached, ok := u256FieldCache.Load(md.FullName()); ok {
+		return cached.([]u256Field)
+	}
+	var pairs []u256Field
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		wide := fields.Get(i)
+		name, ok := strings.CutSuffix(string(wide.Name()), "U256")
+		if !ok || wide.Kind() != protoreflect.BytesKind {
+			continue
+		}
+		if str := fields.ByName(protoreflect.Name(name)); str != nil && str.Kind() == protoreflect.StringKind && !str.IsList() {
+			pairs = append(pairs, u256Field{str: str, wide: wide})
+		}
+	}
+	u256FieldCache.Store(md.FullName(), pairs)
+	return pairs
+}
+
+// PopulateU256Fields sets the U256 field of every string quantity of m and its nested messages
+// (block header, transactions, chain extensions), overwriting U256 fields already set. Strings
+// that are not hex or decimal numbers below 2^256 leave their U256 field unset and are listed in
+// the returned error, a common.BaseError with code INVALID_PARAMETER.
+func PopulateU256Fields(m proto.Message) error {
+	p := newFieldParser(true)
+	populateU256Fields(p, m.ProtoReflect())
+	return p.err()
+}
+
+func populateU256Fields(p *fieldParser, m protoreflect.Message) {
+	for _, f := range u256Fields(m.Descriptor()) {
+		s := m.Get(f.str).String()
+		if s == "" {
+			continue
+		}
+		z, err := ParseU256(s)
+		if err != nil {
+			m.Clear(f.wide)
+			p.fail(string(f.str.Name()), err)
+			continue
+		}
+		m.Set(f.wide, protoreflect.ValueOfBytes(z.Bytes()))
+	}
+	rangeNestedMessages(m, func(name string, i int, nested protoreflect.Message) {
+		if i < 0 {
+			populateU256Fields(p.child(name), nested)
+		} else {
+			populateU256Fields(p.item(name, i), nested)
+		}
+	})
+}
+
+// u256 parses the string quantity s of the named field for its U256 field, returning nil when
+// s is unset or empty. The converters set the U256 fields with it, as walking every message
+// with PopulateU256Fields would cost more than the conversion itself.
+func (p *fieldParser) u256(name string, s *string) []byte {
+	if s == nil || *s == "" {
+		return nil
+	}
+	z, err := ParseU256(*s)
+	if err != nil {
+		p.fail(name, err)
+		return nil
+	}
+	return z.Bytes()
+}
+
+func setBlockHeaderU256Fields(p *fieldParser, h *BlockHeader) {
+	h.BaseFeePerGasU256 = p.u256("baseFeePerGas", h.BaseFeePerGas)
+	h.DifficultyU256 = p.u256("difficulty", h.Difficulty)
+	h.TotalDifficultyU256 = p.u256("totalDifficulty", h.TotalDifficulty)
+}
+
+// setTransactionU256Fields sets the U256 fields of tx and its chain extensions. Extension fields
+// are reported under their JSON-RPC names.
+func setTransactionU256Fields(p *fieldParser, tx *Transaction) {
+	tx.ValueU256 = p.u256("value", &tx.Value)
+	tx.GasPriceU256 = p.u256("gasPrice", tx.GasPrice)
+	tx.MaxFeePerGasU256 = p.u256("maxFeePerGas", tx.MaxFeePerGas)
+	tx.MaxPriorityFeePerGasU256 = p.u256("maxPriorityFeePerGas", tx.MaxPriorityFeePerGas)
+	tx.EffectiveGasPriceU256 = p.u256("effectiveGasPrice", tx.EffectiveGasPrice)
+	tx.MaxFeePerBlobGasU256 = p.u256("maxFeePerBlobGas", tx.MaxFeePerBlobGas)
+	if ext := tx.Optimism; ext != nil {
+		ext.L1FeeU256 = p.u256("l1Fee", ext.L1Fee)
+		ext.L1GasPriceU256 = p.u256("l1GasPrice", ext.L1GasPrice)
+		ext.L1BlobBaseFeeU256 = p.u256("l1BlobBaseFee", ext.L1BlobBaseFee)
+		ext.MintU256 = p.u256("mint", ext.Mint)
+	}
+	if ext := tx.Arbitrum; ext != nil {
+		ext.DepositValueU256 = p.u256("depositValue", ext.DepositValue)
+		ext.L1BaseFeeU256 = p.u256("l1BaseFee", ext.L1BaseFee)
+		ext.MaxSubmissionFeeU256 = p.u256("maxSubmissionFee", ext.MaxSubmissionFee)
+		ext.RetryValueU256 = p.u256("retryValue", ext.RetryValue)
+		ext.MaxRefundU256 = p.u256("maxRefund", ext.MaxRefund)
+		ext.SubmissionFeeRefundU256 = p.u256("submissionFeeRefund", ext.SubmissionFeeRefund)
+	}
+	if ext := tx.Celo; ext != nil {
+		ext.GatewayFeeU256 = p.u256("gatewayFee", ext.GatewayFee)
+	}
+}
+
+// setReceiptU256Fields is setTransactionU256Fields for receipts.
+func setReceiptU256Fields(p *fieldParser, r *Receipt) {
+	r.EffectiveGasPriceU256 = p.u256("effectiveGasPrice", &r.EffectiveGasPrice)
+	r.BlobGasPriceU256 = p.u256("blobGasPrice", r.BlobGasPrice)
+	if ext := r.Optimism; ext != nil {
+		ext.L1FeeU256 = p.u256("l1Fee", ext.L1Fee)
+		ext.L1GasPriceU256 = p.u256("l1GasPrice", ext.L1GasPrice)
+		ext.L1BlobBaseFeeU256 = p.u256("l1BlobBaseFee", ext.L1BlobBaseFee)
+	}
+	if ext := r.Celo; ext != nil {
+		ext.GatewayFeeU256 = p.u256("gatewayFee", ext.GatewayFee)
+	}
+}
+
+// ClearStringQuantityFields clears the string quantities of m and its nested messages whose
+// U256 field is set, so no value is lost.
+func ClearStringQuantityFields(m proto.Message) {
+	clearStringQuantityFields(m.ProtoReflect())
+}
+
+func clearStringQuantityFields(m protoreflect.Message) {
+	for _, f := range u256Fields(m.Descriptor()) {
+		if m.Has(f.wide) {
+			m.Clear(f.str)
+		}
+	}
+	rangeNestedMessages(m, func(_ string, _ int, nested protoreflect.Message) {
+		clearStringQuantityFields(nested)
+	})
+}
+
+// FormatU256Fields sets every unset string quantity of m and its nested messages whose U256
+// field is set to the hex quantity of that field.
+func FormatU256Fields(m proto.Message) {
+	formatU256Fields(m.ProtoReflect())
+}
+
+func formatU256Fields(m protoreflect.Message) {
+	for _, f := range u256Fields(m.Descriptor()) {
+		if s, ok := u256String(m, f); ok {
+			m.Set(f.str, protoreflect.ValueOfString(s))
+		}
+	}
+	rangeNestedMessages(m, func(_ string, _ int, nested protoreflect.Message) {
+		formatU256Fields(nested)
+	})
+}
+
+// u256String returns the hex quantity of f's U256 field when its string field is unset.
+func u256String(m protoreflect.Message, f u256Field) (string, bool) {
+	if m.Get(f.str).String() != "" || !m.Has(f.wide) {
+		return "", false
+	}
+	z, err := U256FromBytes(m.Get(f.wide).Bytes())
+	if err != nil {
+		return "", false
+	}
+	return z.Hex(), true
+}
+
+// rangeNestedMessages calls fn for every message set in a singular (i < 0) or repeated message
+// field of m. Map values are skipped.
+func rangeNestedMessages(m protoreflect.Message, fn func(name string, i int, nested protoreflect.Message)) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if !fd.IsList() {
+			fn(fd.JSONName(), -1, v.Message())
+			return true
+		}
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			if item := list.Get(i).Message(); item.IsValid() {
+				fn(fd.JSONName(), i, item)
+			}
+		}
+		return true
+	})
+}
+
+// withU256Strings returns m, or when some string quantity of m or of its singular nested
+// messages is only set in its U256 field, a shallow copy with the string filled in. The
+// ...ToJsonRpc maps use it so messages migrated with ClearStringQuantityFields keep their
+// JSON-RPC form without being modified; the Append...JsonRpc encoders read the U256 fields
+// directly instead.
+func withU256Strings[M proto.Message](m M) M {
+	if out := u256StringsCopy(m.ProtoReflect()); out != nil {
+		return out.Interface().(M)
+	}
+	return m
+}
+
+// u256StringsCopy returns nil when nothing needs filling in.
+func u256StringsCopy(m protoreflect.Message) protoreflect.Message {
+	if !m.IsValid() {
+		return nil
+	}
+	var out protoreflect.Message
+	copyOnce := func() {
+		if out == nil {
+			out = m.Type().New()
+			shallowCopyMessage(out.Interface(), m.Interface())
+		}
+	}
+	for _, f := range u256Fields(m.Descriptor()) {
+		if s, ok := u256String(m, f); ok {
+			copyOnce()
+			out.Set(f.str, protoreflect.ValueOfString(s))
+		}
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return true
+		}
+		if nested := u256StringsCopy(v.Message()); nested != nil {
+			copyOnce()
+			out.Set(fd, protoreflect.ValueOfMessage(nested))
+		}
+		return true
+	})
+	return out
+}
@@ -0,0 +1,224 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/blockchain-data-standards/manifesto/common"
+)
+
+func TestU256(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		tests := []struct {
+			in   string
+			want string
+		}{
+			{"0x0", "0"},
+			{"0x", "0"},
+			{"0x00ff", "255"},
+			{"1000000000000000000000000000", "1000000000000000000000000000"},
+			{"0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF", "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+			{"115792089237316195423570985008687907853269984665640564039457584007913129639935", "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		}
+		for _, tt := range tests {
+			z, err := ParseU256(tt.in)
+			if err != nil {
+				t.Errorf("ParseU256(%q) error = %v", tt.in, err)
+				continue
+			}
+			if z.String() != tt.want || z.Big().String() != tt.want {
+				t.Errorf("ParseU256(%q) = %s, want %s", tt.in, z, tt.want)
+			}
+		}
+		for _, in := range []string{"", "-1", "+1", " 1", "0xg", "1e18", "0x1" + string(bytes.Repeat([]byte{'0'}, 64)), "115792089237316195423570985008687907853269984665640564039457584007913129639936"} {
+			if _, err := ParseU256(in); err == nil {
+				t.Errorf("Expected %q to be rejected", in)
+			}
+		}
+	})
+
+	t.Run("Encoding", func(t *testing.T) {
+		z := MustParseU256("0x1234567890abcdef1234")
+		if z.Hex() != "0x1234567890abcdef1234" || NewU256(0).Hex() != "0x0" || MaxU256.Hex() != "0x"+string(bytes.Repeat([]byte{'f'}, 64)) {
+			t.Errorf("Unexpected Hex(): %s", z.Hex())
+		}
+		b := z.Bytes()
+		if len(b) != 32 || !bytes.Equal(b[22:], MustHexToBytes("0x1234567890abcdef1234")) {
+			t.Errorf("Unexpected Bytes(): %x", b)
+		}
+		if back, err := U256FromBytes(b); err != nil || back != z {
+			t.Errorf("U256FromBytes() = %s, %v", back, err)
+		}
+		if _, err := U256FromBytes(make([]byte, 33)); err == nil {
+			t.Error("Expected an error for 33 bytes")
+		}
+		if _, err := U256FromBig(big.NewInt(-1)); err == nil {
+			t.Error("Expected an error for a negative big.Int")
+		}
+		if _, err := U256FromBig(new(big.Int).Lsh(big.NewInt(1), 256)); err == nil {
+			t.Error("Expected an error for 2^256")
+		}
+
+		var out struct{ Fee U256 }
+		if err := json.Unmarshal([]byte(`{"Fee":"1500000000"}`), &out); err != nil || out.Fee != NewU256(1500000000) {
+			t.Fatalf("Unmarshal = %v, %v", out.Fee, err)
+		}
+		if got, _ := json.Marshal(out); string(got) != `{"Fee":"0x59682f00"}` {
+			t.Errorf("Marshal = %s", got)
+		}
+
+		units := []struct {
+			wei      string
+			decimals int
+			want     string
+		}{
+			{"1500000000000000000", 18, "1.5"},
+			{"1000000000000000000", 18, "1"},
+			{"1", 18, "0.000000000000000001"},
+			{"0", 18, "0"},
+			{"123456", 0, "123456"},
+			{"1234567", 6, "1.234567"},
+		}
+		for _, u := range units {
+			if got := MustParseU256(u.wei).FormatUnits(u.decimals); got != u.want {
+				t.Errorf("FormatUnits(%s, %d) = %s, want %s", u.wei, u.decimals, got, u.want)
+			}
+		}
+	})
+
+	t.Run("Arithmetic", func(t *testing.T) {
+		mod := new(big.Int).Lsh(big.NewInt(1), 256)
+		values := []U256{
+			{}, NewU256(1), NewU256(10), MaxU256,
+			MustParseU256("0xffffffffffffffff"),
+			MustParseU256("0x1" + string(bytes.Repeat([]byte{'0'}, 32))),
+			MustParseU256("0xdeadbeefcafebabe0123456789abcdef0011223344556677"),
+		}
+		for _, a := range values {
+			for _, b := range values {
+				ab, bb := a.Big(), b.Big()
+				check := func(op string, got U256, want *big.Int) {
+					t.Helper()
+					if got.Big().Cmp(want) != 0 {
+						t.Errorf("%s %s %s = %s, want %s", a, op, b, got, want)
+					}
+				}
+				check("+", a.Add(b), new(big.Int).Mod(new(big.Int).Add(ab, bb), mod))
+				check("-", a.Sub(b), new(big.Int).Mod(new(big.Int).Sub(ab, bb), mod))
+				check("*", a.Mul(b), new(big.Int).Mod(new(big.Int).Mul(ab, bb), mod))
+				if !b.IsZero() {
+					check("/", a.Div(b), new(big.Int).Quo(ab, bb))
+					check("%", a.Mod(b), new(big.Int).Rem(ab, bb))
+				}
+				if _, overflow := a.MulOverflow(b); overflow != (new(big.Int).Mul(ab, bb).BitLen() > 256) {
+					t.Errorf("%s * %s overflow = %v", a, b, overflow)
+				}
+				if _, borrow := a.SubOverflow(b); borrow != (a.Cmp(b) < 0) || a.Cmp(b) != ab.Cmp(bb) {
+					t.Errorf("%s - %s borrow = %v", a, b, borrow)
+				}
+			}
+			if a.BitLen() != a.Big().BitLen() {
+				t.Errorf("BitLen(%s) = %d", a, a.BitLen())
+			}
+		}
+		if !MaxU256.Div(U256{}).IsZero() {
+			t.Error("Expected division by zero to return 0")
+		}
+	})
+}
+
+func TestU256Fields(t *testing.T) {
+	t.Run("Converters", func(t *testing.T) {
+		jt := dialectTestTransaction()
+		jt.Type = "0x7e"
+		jt.Value = "0xde0b6b3a7640000"
+		jt.L1Fee = "1000"
+		tx, err := jt.ToProto(WithDialect(DialectOpGeth()))
+		if err != nil {
+			t.Fatalf("Failed to convert transaction: %v", err)
+		}
+		if z, _ := U256FromBytes(tx.ValueU256); z.String() != "1000000000000000000" {
+			t.Errorf("valueU256 = %x", tx.ValueU256)
+		}
+		if z, _ := U256FromBytes(tx.Optimism.GetL1FeeU256()); z != NewU256(1000) {
+			t.Errorf("optimism.l1FeeU256 = %x", tx.Optimism.GetL1FeeU256())
+		}
+
+		jt.GasPrice = "lots"
+		jt.L1Fee = "0xzz"
+		_, err = jt.ToProto(WithDialect(DialectOpGeth()), WithCollectErrors(true))
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) || !reflect.DeepEqual(baseErr.Details[ConvertErrorPathsDetail], []string{"gasPrice", "l1Fee"}) {
+			t.Errorf("Expected gasPrice and l1Fee to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Migration", func(t *testing.T) {
+		want := TransactionToJsonRpc(fullEncodeTestTransaction())
+
+		tx := fullEncodeTestTransaction()
+		PopulateTransactionExtensions(tx, EcosystemAuto)
+		ClearFlatTransactionFields(tx)
+		err := PopulateU256Fields(tx)
+		var baseErr *common.BaseError
+		if !errors.As(err, &baseErr) {
+			t.Fatalf("Expected the invalid fixture quantities to be reported, got %v", err)
+		}
+		if paths := baseErr.Details[ConvertErrorPathsDetail]; !reflect.DeepEqual(paths, []string{"maxPriorityFeePerGas", "optimism.l1Fee"}) {
+			t.Errorf("Unexpected paths %v", paths)
+		}
+
+		ClearStringQuantityFields(tx)
+		if tx.Value != "" || tx.GasPrice != nil || tx.Optimism.L1GasPrice != nil || tx.MaxPriorityFeePerGas == nil {
+			t.Fatalf("Expected only parsed strings to be cleared, got %v", tx)
+		}
+		got, _ := json.Marshal(TransactionToJsonRpc(tx))
+		wantJson, _ := json.Marshal(want)
+		if !bytes.Equal(got, wantJson) {
+			t.Errorf("Migrated transaction encodes differently\nwant: %s\ngot:  %s", wantJson, got)
+		}
+		assertSameAsMap(t, want, AppendTransactionJsonRpc(nil, tx))
+		if tx.Value != "" {
+			t.Error("Encoding must not modify the transaction")
+		}
+
+		FormatU256Fields(tx)
+		if tx.Value != "0x33b2e3c9fd0803ce8000000" || tx.Optimism.GetL1GasPrice() != "0x1" {
+			t.Errorf("Unexpected formatted strings: value=%s l1GasPrice=%s", tx.Value, tx.Optimism.GetL1GasPrice())
+		}
+	})
+
+	t.Run("Receipt", func(t *testing.T) {
+		want := ReceiptToJsonRpc(fullEncodeTestReceipt())
+
+		r := fullEncodeTestReceipt()
+		PopulateReceiptExtensions(r, EcosystemAuto)
+		ClearFlatReceiptFields(r)
+		PopulateU256Fields(r)
+		ClearStringQuantityFields(r)
+		if r.EffectiveGasPrice != "" || r.Optimism.L1Fee != nil {
+			t.Fatalf("Expected the strings to be cleared, got %v", r)
+		}
+		assertSameAsMap(t, want, AppendReceiptJsonRpc(nil, r))
+
+		// U256 bytes that do not decode are ignored like an unset field.
+		r.BlobGasPriceU256 = make([]byte, 33)
+		r.BlobGasPrice = nil
+		assertSameAsMap(t, ReceiptToJsonRpc(r), AppendReceiptJsonRpc(nil, r))
+	})
+
+	t.Run("BlockHeader", func(t *testing.T) {
+		header := &BlockHeader{Number: 1, BaseFeePerGasU256: NewU256(7).Bytes(), Difficulty: StringPtr("")}
+		if err := PopulateU256Fields(header); err != nil {
+			t.Errorf("Expected empty strings to be skipped, got %v", err)
+		}
+		if out := BlockToJsonRpc(header, nil, nil, nil); out["baseFeePerGas"] != "0x7" {
+			t.Errorf("baseFeePerGas = %v", out["baseFeePerGas"])
+		}
+		assertSameAsMap(t, BlockToJsonRpc(header, nil, nil, nil), AppendBlockJsonRpc(nil, header, nil, nil, nil))
+	})
+}
@@ -1,61 +1,21 @@
 package evm
 
 import (
-	"encoding/hex"
 	"fmt"
 	"math"
 	"math/big"
 	"strconv"
 	"strings"
-	"sync"
 )
 
 // Hex conversion utilities
 
-// Pool for reusing byte slices during hex decoding to reduce allocations
-var hexDecodePool = sync.Pool{
-	New: func() interface{} {
-		// Pre-allocate for common sizes (32 bytes for hashes, 20 bytes for addresses)
-		// Using 32 as capacity since that's the most common case (block hashes, tx hashes, etc.)
-		return make([]byte, 0, 32)
-	},
-}
-
-// HexToBytes converts a hex string to bytes, removing the 0x prefix if present
+// HexToBytes converts a hex string to bytes, removing the 0x prefix if present.
+// Odd-length strings are read as if they had a leading zero, as RPC nodes return values like
+// "0x1" instead of "0x01".
 func HexToBytes(s string) ([]byte, error) {
-	s = RemoveHexPrefix(s)
-	// Normalize odd-length hex strings by padding with leading zero
-	// This handles cases where RPC nodes return values like "0x1" instead of "0x01"
-	if len(s)%2 != 0 {
-		s = "0" + s
-	}
-
-	// For very common sizes, use the pool to reduce allocations
-	expectedLen := len(s) / 2
-	if expectedLen <= 64 { // Common for hashes (32), addresses (20), etc.
-		poolBuf := hexDecodePool.Get().([]byte)
-		defer hexDecodePool.Put(poolBuf[:0]) // Reset length but keep capacity
-
-		// Ensure buffer has enough capacity
-		if cap(poolBuf) < expectedLen {
-			poolBuf = make([]byte, 0, expectedLen*2)
-		}
-
-		// Decode into pool buffer
-		decoded := poolBuf[:expectedLen]
-		n, err := hex.Decode(decoded, []byte(s))
-		if err != nil {
-			return nil, err
-		}
-
-		// Copy to final result to avoid holding pool buffer reference
-		result := make([]byte, n)
-		copy(result, decoded[:n])
-		return result, nil
-	}
-
-	// Fall back to standard decoding for larger values
-	return hex.DecodeString(s)
+	digits := len(RemoveHexPrefix(s))
+	return decodeHex(make([]byte, 0, (digits+1)/2), s)
 }
 
 // MustHexToBytes converts a hex string to bytes, panicking on error
@@ -68,40 +28,42 @@ func MustHexToBytes(s string) []byte {
 	return b
 }
 
-// HexToAddress converts a hex string to an Address
-func HexToAddress(s string) (Address, error) {
+// HexToAddress converts a hex string to an Address, failing unless it encodes exactly 20 bytes.
+// WithChecksum and WithRequireChecksum also validate the casing of the input.
+func HexToAddress(s string, opts ...AddressOption) (Address, error) {
 	b, err := HexToBytes(s)
 	if err != nil {
-		return nil, err
+		return Address{}, err
+	}
+	a, err := NewAddress(b)
+	if err != nil || len(opts) == 0 {
+		return a, err
+	}
+	if err := newAddressConfig(opts).verify(a, s); err != nil {
+		return Address{}, err
 	}
-	return NewAddress(b), nil
+	return a, nil
 }
 
 // MustHexToAddress converts a hex string to an Address, panicking on error
-func MustHexToAddress(s string) Address {
-	addr, err := HexToAddress(s)
+func MustHexToAddress(s string, opts ...AddressOption) Address {
+	addr, err := HexToAddress(s, opts...)
 	if err != nil {
 		panic(fmt.Sprintf("invalid address hex: %s", s))
 	}
 	return addr
 }
 
+// HexToUint32 parses a 0x-prefixed hex QUANTITY into uint32, failing on values above MaxUint32.
 func HexToUint32(hex string) (uint32, error) {
-	if len(hex) < 2 || hex[:2] != "0x" {
-		return 0, fmt.Errorf("invalid hex string: %s", hex)
-	}
-	var result uint32
-	_, err := fmt.Sscanf(hex, "0x%x", &result)
-	return result, err
+	v, err := decodeQuantity(hex, false, 32)
+	return uint32(v), err
 }
 
+// HexToUint64 parses a 0x-prefixed hex QUANTITY into uint64. Leading zeros are accepted; use
+// DecodeQuantity to require the canonical encoding.
 func HexToUint64(hex string) (uint64, error) {
-	if len(hex) < 2 || hex[:2] != "0x" {
-		return 0, fmt.Errorf("invalid hex string: %s", hex)
-	}
-	var result uint64
-	_, err := fmt.Sscanf(hex, "0x%x", &result)
-	return result, err
+	return decodeQuantity(hex, false, 64)
 }
 
 func MustHexToUint64(hex string) uint64 {
@@ -152,13 +114,13 @@ func NumberishToUint32(s string) (uint32, error) {
 	return uint32(u), nil
 }
 
-// HexToHash converts a hex string to a Hash
+// HexToHash converts a hex string to a Hash, failing unless it encodes exactly 32 bytes
 func HexToHash(s string) (Hash, error) {
 	b, err := HexToBytes(s)
 	if err != nil {
-		return nil, err
+		return Hash{}, err
 	}
-	return NewHash(b), nil
+	return NewHash(b)
 }
 
 // MustHexToHash converts a hex string to a Hash, panicking on error
@@ -170,13 +132,13 @@ func MustHexToHash(s string) Hash {
 	return h
 }
 
-// HexToTopic converts a hex string to a Topic
+// HexToTopic converts a hex string to a Topic, failing unless it encodes exactly 32 bytes
 func HexToTopic(s string) (Topic, error) {
 	b, err := HexToBytes(s)
 	if err != nil {
-		return nil, err
+		return Topic{}, err
 	}
-	return NewTopic(b), nil
+	return NewTopic(b)
 }
 
 // MustHexToTopic converts a hex string to a Topic, panicking on error
@@ -190,7 +152,7 @@ func MustHexToTopic(s string) Topic {
 
 // BytesToHex converts bytes to a hex string with 0x prefix
 func BytesToHex(b []byte) string {
-	return "0x" + hex.EncodeToString(b)
+	return hexString(b)
 }
 
 // BytesToHexFixed left-pads the input with zero bytes to the desired size (in bytes)